@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// autoArchiveInterval is how often achieved goals are checked for archiving.
+const autoArchiveInterval = time.Hour
+
+// autoArchive archives achieved goals of every user who enabled it in their
+// settings.
+func (app *app) autoArchive(ctx context.Context) error {
+	prefs, err := app.services.preferences.GetAllWithAutoArchive(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pref := range prefs {
+		before := time.Now().AddDate(0, 0, -int(pref.AutoArchiveDays.Int64))
+
+		archived, err := app.services.goals.AutoArchive(ctx, int(pref.UserID), before)
+		if err != nil {
+			return err
+		}
+
+		if archived > 0 {
+			app.logger.Info("archived goals", "user_id", pref.UserID, "goals", archived)
+		}
+	}
+
+	return nil
+}
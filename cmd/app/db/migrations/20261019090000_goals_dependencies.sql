@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_dependencies (
+    goal_id INTEGER NOT NULL,
+    depends_on_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    PRIMARY KEY (goal_id, depends_on_id),
+    CHECK (goal_id <> depends_on_id),
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (depends_on_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_dependencies_depends_on_id ON goal_dependencies(depends_on_id);
+CREATE INDEX idx_goal_dependencies_user_id ON goal_dependencies(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_dependencies_user_id;
+DROP INDEX IF EXISTS idx_goal_dependencies_depends_on_id;
+DROP TABLE IF EXISTS goal_dependencies;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals ADD recurrence TEXT;
+ALTER TABLE goals ADD next_occurrence_id INTEGER REFERENCES goals(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goals DROP next_occurrence_id;
+ALTER TABLE goals DROP recurrence;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals ADD status TEXT NOT NULL DEFAULT 'not_started'
+    CHECK (status IN ('not_started', 'in_progress', 'at_risk', 'on_hold', 'achieved', 'abandoned'));
+
+UPDATE goals SET status = 'achieved' WHERE achieved = 1;
+
+ALTER TABLE goals DROP achieved;
+
+CREATE TABLE goal_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    note TEXT,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_status_history_goal_id ON goal_status_history(goal_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_status_history_goal_id;
+DROP TABLE IF EXISTS goal_status_history;
+
+ALTER TABLE goals ADD achieved INTEGER DEFAULT 0;
+UPDATE goals SET achieved = 1 WHERE status = 'achieved';
+ALTER TABLE goals DROP status;
+-- +goose StatementEnd
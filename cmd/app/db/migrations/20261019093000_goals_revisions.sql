@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    goal TEXT,
+    description TEXT,
+    due INTEGER,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_revisions_goal_id ON goal_revisions(goal_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_revisions_goal_id;
+DROP TABLE IF EXISTS goal_revisions;
+-- +goose StatementEnd
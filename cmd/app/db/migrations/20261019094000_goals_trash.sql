@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals ADD deleted_at INTEGER;
+
+CREATE INDEX idx_goals_deleted_at ON goals(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goals_deleted_at;
+ALTER TABLE goals DROP deleted_at;
+-- +goose StatementEnd
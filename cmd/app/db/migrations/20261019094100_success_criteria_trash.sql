@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE success_criteria ADD deleted_at INTEGER;
+
+CREATE INDEX idx_success_criteria_deleted_at ON success_criteria(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_success_criteria_deleted_at;
+ALTER TABLE success_criteria DROP deleted_at;
+-- +goose StatementEnd
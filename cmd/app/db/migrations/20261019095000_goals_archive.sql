@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals ADD archived_at INTEGER;
+
+CREATE INDEX idx_goals_archived_at ON goals(archived_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goals_archived_at;
+ALTER TABLE goals DROP archived_at;
+-- +goose StatementEnd
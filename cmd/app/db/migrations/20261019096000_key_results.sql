@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE key_results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    unit TEXT NOT NULL DEFAULT '',
+    start_value REAL NOT NULL DEFAULT 0,
+    target_value REAL NOT NULL,
+    current_value REAL NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_key_results_goal_id ON key_results(goal_id);
+CREATE INDEX idx_key_results_user_id ON key_results(user_id);
+
+CREATE TABLE key_result_check_ins (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    key_result_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    value REAL NOT NULL,
+    note TEXT,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (key_result_id) REFERENCES key_results(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_key_result_check_ins_key_result_id ON key_result_check_ins(key_result_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_key_result_check_ins_key_result_id;
+DROP TABLE IF EXISTS key_result_check_ins;
+DROP INDEX IF EXISTS idx_key_results_user_id;
+DROP INDEX IF EXISTS idx_key_results_goal_id;
+DROP TABLE IF EXISTS key_results;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE journal_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    mood TEXT CHECK (mood IN ('great', 'good', 'okay', 'struggling')),
+    confidence INTEGER CHECK (confidence BETWEEN 1 AND 5),
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER,
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_journal_entries_goal_id ON journal_entries(goal_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_journal_entries_goal_id;
+DROP TABLE IF EXISTS journal_entries;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goals ADD achieved_at INTEGER;
+
+-- Goals achieved before this column existed take the date of their last
+-- transition to achieved, or their due date if it is in the past.
+UPDATE goals
+SET achieved_at = COALESCE(
+    (SELECT MAX(created_at) FROM goal_status_history
+     WHERE goal_status_history.goal_id = goals.id AND goal_status_history.to_status = 'achieved'),
+    MIN(due, unixepoch()),
+    unixepoch()
+)
+WHERE status = 'achieved';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goals DROP achieved_at;
+-- +goose StatementEnd
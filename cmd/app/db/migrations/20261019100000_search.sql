@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE VIRTUAL TABLE goals_fts USING fts5(
+    goal,
+    description,
+    content = 'goals',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER goals_fts_insert AFTER INSERT ON goals BEGIN
+    INSERT INTO goals_fts (rowid, goal, description)
+    VALUES (new.id, new.goal, new.description);
+END;
+
+CREATE TRIGGER goals_fts_delete AFTER DELETE ON goals BEGIN
+    INSERT INTO goals_fts (goals_fts, rowid, goal, description)
+    VALUES ('delete', old.id, old.goal, old.description);
+END;
+
+CREATE TRIGGER goals_fts_update AFTER UPDATE OF goal, description ON goals BEGIN
+    INSERT INTO goals_fts (goals_fts, rowid, goal, description)
+    VALUES ('delete', old.id, old.goal, old.description);
+    INSERT INTO goals_fts (rowid, goal, description)
+    VALUES (new.id, new.goal, new.description);
+END;
+
+CREATE VIRTUAL TABLE success_criteria_fts USING fts5(
+    description,
+    content = 'success_criteria',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER success_criteria_fts_insert AFTER INSERT ON success_criteria BEGIN
+    INSERT INTO success_criteria_fts (rowid, description)
+    VALUES (new.id, new.description);
+END;
+
+CREATE TRIGGER success_criteria_fts_delete AFTER DELETE ON success_criteria BEGIN
+    INSERT INTO success_criteria_fts (success_criteria_fts, rowid, description)
+    VALUES ('delete', old.id, old.description);
+END;
+
+CREATE TRIGGER success_criteria_fts_update AFTER UPDATE OF description ON success_criteria BEGIN
+    INSERT INTO success_criteria_fts (success_criteria_fts, rowid, description)
+    VALUES ('delete', old.id, old.description);
+    INSERT INTO success_criteria_fts (rowid, description)
+    VALUES (new.id, new.description);
+END;
+
+-- Index the goals and success criteria that already exist.
+INSERT INTO goals_fts (goals_fts) VALUES ('rebuild');
+INSERT INTO success_criteria_fts (success_criteria_fts) VALUES ('rebuild');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS success_criteria_fts_update;
+DROP TRIGGER IF EXISTS success_criteria_fts_delete;
+DROP TRIGGER IF EXISTS success_criteria_fts_insert;
+DROP TABLE IF EXISTS success_criteria_fts;
+DROP TRIGGER IF EXISTS goals_fts_update;
+DROP TRIGGER IF EXISTS goals_fts_delete;
+DROP TRIGGER IF EXISTS goals_fts_insert;
+DROP TABLE IF EXISTS goals_fts;
+-- +goose StatementEnd
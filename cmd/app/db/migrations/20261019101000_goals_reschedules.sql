@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_reschedules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    delta INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_reschedules_user_id ON goal_reschedules(user_id);
+
+CREATE TABLE goal_reschedule_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reschedule_id INTEGER NOT NULL,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    previous_due INTEGER NOT NULL,
+    previous_start_date INTEGER,
+
+    FOREIGN KEY (reschedule_id) REFERENCES goal_reschedules(id) ON DELETE CASCADE,
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_reschedule_items_reschedule_id ON goal_reschedule_items(reschedule_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_reschedule_items_reschedule_id;
+DROP TABLE IF EXISTS goal_reschedule_items;
+DROP INDEX IF EXISTS idx_goal_reschedules_user_id;
+DROP TABLE IF EXISTS goal_reschedules;
+-- +goose StatementEnd
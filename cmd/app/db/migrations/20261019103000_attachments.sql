@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('link', 'file')),
+    title TEXT NOT NULL,
+    -- Links only.
+    url TEXT,
+    -- Files only. stored_name is the name of the file in the attachments
+    -- directory, file_name the name it was uploaded with.
+    file_name TEXT,
+    stored_name TEXT UNIQUE,
+    content_type TEXT,
+    size INTEGER NOT NULL DEFAULT 0,
+    public INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_attachments_goal_id ON goal_attachments(goal_id);
+CREATE INDEX idx_goal_attachments_user_id ON goal_attachments(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_attachments_user_id;
+DROP INDEX IF EXISTS idx_goal_attachments_goal_id;
+DROP TABLE IF EXISTS goal_attachments;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_comments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    -- The owner of the goal, who moderates the comment.
+    user_id INTEGER NOT NULL,
+    -- The signed in user who wrote the comment, NULL for anonymous viewers.
+    author_id INTEGER,
+    author_name TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    -- Comments wait for the owner until they are approved.
+    approved_at INTEGER,
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE SET NULL
+) STRICT;
+
+CREATE INDEX idx_goal_comments_goal_id ON goal_comments(goal_id);
+CREATE INDEX idx_goal_comments_user_id ON goal_comments(user_id);
+CREATE INDEX idx_goal_comments_author_id ON goal_comments(author_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_comments_author_id;
+DROP INDEX IF EXISTS idx_goal_comments_user_id;
+DROP INDEX IF EXISTS idx_goal_comments_goal_id;
+DROP TABLE IF EXISTS goal_comments;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keys to sign values that are handed out to browsers, like cookies. They
+-- are created on first use and kept, so signatures survive restarts.
+CREATE TABLE signing_keys (
+    name TEXT PRIMARY KEY,
+    key BLOB NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_keys;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goal_reactions (
+    goal_id INTEGER NOT NULL,
+    -- The owner of the goal.
+    user_id INTEGER NOT NULL,
+    -- The random ID in the signed cookie of the viewer.
+    viewer_id TEXT NOT NULL,
+    reaction TEXT NOT NULL CHECK (reaction IN ('clap', 'fire', 'strong', 'party', 'heart')),
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    -- A viewer can give each reaction once per goal.
+    PRIMARY KEY (goal_id, viewer_id, reaction),
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_reactions_user_id_viewer_id ON goal_reactions(user_id, viewer_id);
+
+-- Counts of the reactions, kept up to date by triggers, so share pages
+-- don't count every reaction on every view.
+CREATE TABLE goal_reaction_counts (
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    reaction TEXT NOT NULL,
+    count INTEGER NOT NULL,
+
+    PRIMARY KEY (goal_id, reaction),
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_reaction_counts_user_id ON goal_reaction_counts(user_id);
+
+CREATE TRIGGER goal_reactions_insert AFTER INSERT ON goal_reactions BEGIN
+    INSERT INTO goal_reaction_counts (goal_id, user_id, reaction, count)
+    VALUES (new.goal_id, new.user_id, new.reaction, 1)
+    ON CONFLICT (goal_id, reaction) DO UPDATE SET count = count + 1;
+END;
+
+CREATE TRIGGER goal_reactions_delete AFTER DELETE ON goal_reactions BEGIN
+    UPDATE goal_reaction_counts SET count = count - 1
+    WHERE goal_id = old.goal_id AND reaction = old.reaction;
+    DELETE FROM goal_reaction_counts
+    WHERE goal_id = old.goal_id AND reaction = old.reaction AND count <= 0;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS goal_reactions_delete;
+DROP TRIGGER IF EXISTS goal_reactions_insert;
+DROP INDEX IF EXISTS idx_goal_reaction_counts_user_id;
+DROP TABLE IF EXISTS goal_reaction_counts;
+DROP INDEX IF EXISTS idx_goal_reactions_user_id_viewer_id;
+DROP TABLE IF EXISTS goal_reactions;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL keeps the default thresholds.
+ALTER TABLE preferences ADD risk_days INTEGER;
+ALTER TABLE preferences ADD risk_progress INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE preferences DROP risk_progress;
+ALTER TABLE preferences DROP risk_days;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Comma separated days before the due date to send reminders on. NULL keeps
+-- the default days, an empty string turns reminders off.
+ALTER TABLE preferences ADD reminder_days TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE preferences DROP reminder_days;
+-- +goose StatementEnd
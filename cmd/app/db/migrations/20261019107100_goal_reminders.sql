@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reminders that were sent, so each is sent once, even across restarts.
+CREATE TABLE goal_reminders (
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    -- The reminder setting the reminder was sent for, in days before due.
+    days_before INTEGER NOT NULL,
+    -- The due date the reminder was sent for, so moved goals are reminded
+    -- again.
+    due INTEGER NOT NULL,
+    sent_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    PRIMARY KEY (goal_id, days_before, due),
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_goal_reminders_user_id ON goal_reminders(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_reminders_user_id;
+DROP TABLE IF EXISTS goal_reminders;
+-- +goose StatementEnd
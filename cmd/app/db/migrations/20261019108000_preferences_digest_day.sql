@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The weekday the weekly digest is sent on. NULL keeps the default, Monday.
+ALTER TABLE preferences ADD digest_day TEXT
+    CHECK (digest_day IN ('off', 'monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', 'sunday'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE preferences DROP digest_day;
+-- +goose StatementEnd
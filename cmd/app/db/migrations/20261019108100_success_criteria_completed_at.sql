@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- When the success criterion was completed, kept by triggers. It is unknown
+-- for criteria completed before this migration.
+ALTER TABLE success_criteria ADD completed_at INTEGER;
+
+CREATE INDEX idx_success_criteria_user_id_completed_at ON success_criteria(user_id, completed_at);
+
+CREATE TRIGGER success_criteria_completed_insert AFTER INSERT ON success_criteria
+WHEN new.completed = 1 BEGIN
+    UPDATE success_criteria SET completed_at = unixepoch() WHERE id = new.id;
+END;
+
+CREATE TRIGGER success_criteria_completed_update AFTER UPDATE OF completed ON success_criteria
+WHEN new.completed IS NOT old.completed BEGIN
+    UPDATE success_criteria
+    SET completed_at = CASE WHEN new.completed = 1 THEN unixepoch() END
+    WHERE id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS success_criteria_completed_update;
+DROP TRIGGER IF EXISTS success_criteria_completed_insert;
+DROP INDEX IF EXISTS idx_success_criteria_user_id_completed_at;
+ALTER TABLE success_criteria DROP completed_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Weekly digests that were sent, so each is sent once, even across restarts.
+CREATE TABLE user_digests (
+    user_id INTEGER NOT NULL,
+    -- The day the digest was sent for, as Unix epoch of its midnight UTC.
+    day INTEGER NOT NULL,
+    sent_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    PRIMARY KEY (user_id, day),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_digests;
+-- +goose StatementEnd
@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Viewers of a share link who get its updates by email. Subscriptions belong
+-- to the link, so they end when it is deleted.
+CREATE TABLE share_subscriptions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    share_id INTEGER NOT NULL,
+    email TEXT NOT NULL,
+    -- The random token in the confirm and unsubscribe links.
+    token TEXT NOT NULL UNIQUE,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    -- Subscriptions get no updates until the link in the confirmation email
+    -- was followed.
+    confirmed_at INTEGER,
+    -- The last share update the subscriber was notified of.
+    last_update_id INTEGER NOT NULL DEFAULT 0,
+
+    UNIQUE (share_id, email),
+    FOREIGN KEY (share_id) REFERENCES share(id) ON DELETE CASCADE
+) STRICT;
+
+-- Changes to public goals that subscribers are notified of, recorded by
+-- triggers.
+CREATE TABLE share_updates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('added', 'achieved', 'rescheduled')),
+    due INTEGER,
+    -- The due date before the goal was rescheduled.
+    previous_due INTEGER,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_share_updates_user_id ON share_updates(user_id, id);
+CREATE INDEX idx_share_updates_goal_id ON share_updates(goal_id);
+
+CREATE TRIGGER share_updates_goal_insert AFTER INSERT ON goals
+WHEN new.visible_to_public = 1 BEGIN
+    INSERT INTO share_updates (goal_id, user_id, kind, due)
+    VALUES (new.id, new.user_id, 'added', new.due);
+END;
+
+-- Goals made public are new to the viewers of the timeline.
+CREATE TRIGGER share_updates_goal_public AFTER UPDATE OF visible_to_public ON goals
+WHEN new.visible_to_public = 1 AND old.visible_to_public IS NOT 1 BEGIN
+    INSERT INTO share_updates (goal_id, user_id, kind, due)
+    VALUES (new.id, new.user_id, 'added', new.due);
+END;
+
+CREATE TRIGGER share_updates_goal_achieved AFTER UPDATE OF status ON goals
+WHEN new.status = 'achieved' AND old.status IS NOT 'achieved' AND new.visible_to_public = 1 BEGIN
+    INSERT INTO share_updates (goal_id, user_id, kind, due)
+    VALUES (new.id, new.user_id, 'achieved', new.due);
+END;
+
+CREATE TRIGGER share_updates_goal_rescheduled AFTER UPDATE OF due ON goals
+WHEN new.due IS NOT old.due AND new.visible_to_public = 1 AND old.visible_to_public = 1 BEGIN
+    INSERT INTO share_updates (goal_id, user_id, kind, due, previous_due)
+    VALUES (new.id, new.user_id, 'rescheduled', new.due, old.due);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS share_updates_goal_rescheduled;
+DROP TRIGGER IF EXISTS share_updates_goal_achieved;
+DROP TRIGGER IF EXISTS share_updates_goal_public;
+DROP TRIGGER IF EXISTS share_updates_goal_insert;
+DROP INDEX IF EXISTS idx_share_updates_goal_id;
+DROP INDEX IF EXISTS idx_share_updates_user_id;
+DROP TABLE IF EXISTS share_updates;
+DROP TABLE IF EXISTS share_subscriptions;
+-- +goose StatementEnd
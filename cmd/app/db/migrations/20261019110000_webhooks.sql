@@ -0,0 +1,152 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    -- The key requests to the webhook are signed with.
+    secret TEXT NOT NULL,
+    -- The events the webhook receives, separated by commas.
+    events TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_webhooks_user_id ON webhooks(user_id);
+
+-- The queue and log of requests to webhooks. Deliveries are added by
+-- triggers, so events are recorded in the same transaction as the change.
+CREATE TABLE webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    event TEXT NOT NULL,
+    -- The JSON data of the event.
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    last_attempt_at INTEGER,
+    -- The HTTP status of the last attempt, NULL if there was no response.
+    response_status INTEGER,
+    last_error TEXT,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    delivered_at INTEGER,
+
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_user_id ON webhook_deliveries(user_id, id);
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+
+CREATE TRIGGER webhooks_goal_created AFTER INSERT ON goals BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'goal.created', json_object(
+        'id', new.id,
+        'goal', new.goal,
+        'description', new.description,
+        'start', date(new.start_date, 'unixepoch'),
+        'due', date(new.due, 'unixepoch'),
+        'status', new.status,
+        'achieved_at', date(new.achieved_at, 'unixepoch'),
+        'public', json(CASE WHEN new.visible_to_public = 1 THEN 'true' ELSE 'false' END)
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',goal.created,') > 0;
+END;
+
+CREATE TRIGGER webhooks_goal_updated AFTER UPDATE ON goals
+WHEN new.deleted_at IS NULL AND (
+    new.goal IS NOT old.goal
+    OR new.description IS NOT old.description
+    OR new.start_date IS NOT old.start_date
+    OR new.due IS NOT old.due
+    OR new.status IS NOT old.status
+    OR new.visible_to_public IS NOT old.visible_to_public
+) BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'goal.updated', json_object(
+        'id', new.id,
+        'goal', new.goal,
+        'description', new.description,
+        'start', date(new.start_date, 'unixepoch'),
+        'due', date(new.due, 'unixepoch'),
+        'status', new.status,
+        'achieved_at', date(new.achieved_at, 'unixepoch'),
+        'public', json(CASE WHEN new.visible_to_public = 1 THEN 'true' ELSE 'false' END)
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',goal.updated,') > 0;
+END;
+
+CREATE TRIGGER webhooks_goal_achieved AFTER UPDATE OF status ON goals
+WHEN new.status = 'achieved' AND old.status IS NOT 'achieved' AND new.deleted_at IS NULL BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'goal.achieved', json_object(
+        'id', new.id,
+        'goal', new.goal,
+        'description', new.description,
+        'start', date(new.start_date, 'unixepoch'),
+        'due', date(new.due, 'unixepoch'),
+        'status', new.status,
+        'achieved_at', date(new.achieved_at, 'unixepoch'),
+        'public', json(CASE WHEN new.visible_to_public = 1 THEN 'true' ELSE 'false' END)
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',goal.achieved,') > 0;
+END;
+
+-- Goals are deleted by moving them to the trash.
+CREATE TRIGGER webhooks_goal_deleted AFTER UPDATE OF deleted_at ON goals
+WHEN new.deleted_at IS NOT NULL AND old.deleted_at IS NULL BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'goal.deleted', json_object(
+        'id', new.id,
+        'goal', new.goal
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',goal.deleted,') > 0;
+END;
+
+CREATE TRIGGER webhooks_criterion_toggled AFTER UPDATE OF completed ON success_criteria
+WHEN new.completed IS NOT old.completed BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'criterion.toggled', json_object(
+        'id', new.id,
+        'goal_id', new.goal_id,
+        'description', new.description,
+        'completed', json(CASE WHEN new.completed = 1 THEN 'true' ELSE 'false' END)
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',criterion.toggled,') > 0;
+END;
+
+CREATE TRIGGER webhooks_share_created AFTER INSERT ON share BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+    SELECT id, user_id, 'share.created', json_object(
+        'id', new.id,
+        'path', '/s/' || new.public_id
+    )
+    FROM webhooks
+    WHERE user_id = new.user_id AND instr(',' || events || ',', ',share.created,') > 0;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS webhooks_share_created;
+DROP TRIGGER IF EXISTS webhooks_criterion_toggled;
+DROP TRIGGER IF EXISTS webhooks_goal_deleted;
+DROP TRIGGER IF EXISTS webhooks_goal_achieved;
+DROP TRIGGER IF EXISTS webhooks_goal_updated;
+DROP TRIGGER IF EXISTS webhooks_goal_created;
+DROP INDEX IF EXISTS idx_webhook_deliveries_webhook_id;
+DROP INDEX IF EXISTS idx_webhook_deliveries_user_id;
+DROP INDEX IF EXISTS idx_webhook_deliveries_status;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP INDEX IF EXISTS idx_webhooks_user_id;
+DROP TABLE IF EXISTS webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The due date a reschedule moved the goal to. Undo only moves goals back
+-- that are still due then.
+ALTER TABLE goal_reschedule_items ADD due INTEGER NOT NULL DEFAULT 0;
+
+UPDATE goal_reschedule_items
+SET due = previous_due + (
+    SELECT delta FROM goal_reschedules
+    WHERE goal_reschedules.id = goal_reschedule_items.reschedule_id
+);
+
+CREATE INDEX idx_goal_reschedules_created_at ON goal_reschedules(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goal_reschedules_created_at;
+ALTER TABLE goal_reschedule_items DROP due;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Timelines are read in pages, sorted by due date and ID.
+CREATE INDEX idx_goals_user_id_due_id ON goals(user_id, due, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_goals_user_id_due_id;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Search marks matches with \x02 and \x03, so remove them from stored text.
+-- The search triggers reindex the updated rows.
+UPDATE goals
+SET goal = replace(replace(goal, char(2), ''), char(3), ''),
+    description = replace(replace(description, char(2), ''), char(3), '')
+WHERE instr(goal, char(2)) > 0 OR instr(goal, char(3)) > 0
+   OR instr(description, char(2)) > 0 OR instr(description, char(3)) > 0;
+
+UPDATE success_criteria
+SET description = replace(replace(description, char(2), ''), char(3), '')
+WHERE instr(description, char(2)) > 0 OR instr(description, char(3)) > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The removed characters cannot be restored.
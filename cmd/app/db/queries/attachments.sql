@@ -0,0 +1,40 @@
+-- name: CreateAttachment :one
+INSERT INTO goal_attachments (goal_id, user_id, kind, title, url, file_name, stored_name, content_type, size)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT * FROM goal_attachments
+WHERE id = ? AND goal_id = ? AND user_id = ?;
+
+-- name: GetPublicAttachment :one
+SELECT * FROM goal_attachments
+WHERE id = ? AND goal_id = ? AND user_id = ? AND public = 1;
+
+-- name: GetAllAttachmentsByGoal :many
+SELECT * FROM goal_attachments
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetAllPublicAttachmentsByGoals :many
+SELECT * FROM goal_attachments
+WHERE user_id = ? AND goal_id IN (sqlc.slice('goal_ids')) AND public = 1
+ORDER BY goal_id ASC, created_at ASC, id ASC;
+
+-- name: GetAllStoredNames :many
+SELECT stored_name FROM goal_attachments
+WHERE stored_name IS NOT NULL;
+
+-- name: GetTotalAttachmentSize :one
+SELECT CAST(COALESCE(SUM(size), 0) AS INTEGER) AS total FROM goal_attachments
+WHERE user_id = ?;
+
+-- name: SetAttachmentPublic :execresult
+UPDATE goal_attachments
+SET public = ?
+WHERE id = ? AND goal_id = ? AND user_id = ?;
+
+-- name: DeleteAttachment :one
+DELETE FROM goal_attachments
+WHERE id = ? AND goal_id = ? AND user_id = ?
+RETURNING *;
@@ -0,0 +1,33 @@
+-- name: CreateComment :one
+INSERT INTO goal_comments (goal_id, user_id, author_id, author_name, body, approved_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAllApprovedCommentsByGoals :many
+SELECT * FROM goal_comments
+WHERE user_id = ? AND goal_id IN (sqlc.slice('goal_ids')) AND approved_at IS NOT NULL
+ORDER BY goal_id ASC, created_at ASC, id ASC;
+
+-- name: GetAllCommentsByUser :many
+SELECT
+    goal_comments.id,
+    goal_comments.goal_id,
+    goal_comments.author_id,
+    goal_comments.author_name,
+    goal_comments.body,
+    goal_comments.created_at,
+    goal_comments.approved_at,
+    goals.goal
+FROM goal_comments
+JOIN goals ON goals.id = goal_comments.goal_id
+WHERE goal_comments.user_id = ? AND goals.deleted_at IS NULL
+ORDER BY goal_comments.approved_at IS NOT NULL, goal_comments.created_at DESC, goal_comments.id DESC;
+
+-- name: ApproveComment :execresult
+UPDATE goal_comments
+SET approved_at = unixepoch()
+WHERE id = ? AND user_id = ? AND approved_at IS NULL;
+
+-- name: DeleteComment :execresult
+DELETE FROM goal_comments
+WHERE id = ? AND user_id = ?;
@@ -0,0 +1,46 @@
+-- name: CreateDigest :execresult
+INSERT INTO user_digests (user_id, day)
+VALUES (?, ?)
+ON CONFLICT (user_id, day) DO NOTHING;
+
+-- name: DeleteDigest :exec
+DELETE FROM user_digests
+WHERE user_id = ? AND day = ?;
+
+-- name: GetAllCriteriaCompletedBetween :many
+SELECT success_criteria.goal_id, goals.goal, success_criteria.description
+FROM success_criteria
+JOIN goals ON goals.id = success_criteria.goal_id
+WHERE success_criteria.user_id = ?
+  AND success_criteria.completed = 1
+  AND success_criteria.completed_at >= CAST(sqlc.arg(completed_from) AS INTEGER)
+  AND success_criteria.completed_at < CAST(sqlc.arg(completed_to) AS INTEGER)
+  AND success_criteria.deleted_at IS NULL
+  AND goals.deleted_at IS NULL
+ORDER BY success_criteria.completed_at ASC, success_criteria.id ASC;
+
+-- name: GetAllGoalsAchievedBetween :many
+SELECT id, goal, due FROM goals
+WHERE user_id = ?
+  AND status = 'achieved'
+  AND achieved_at >= CAST(sqlc.arg(achieved_from) AS INTEGER)
+  AND achieved_at < CAST(sqlc.arg(achieved_to) AS INTEGER)
+  AND deleted_at IS NULL
+ORDER BY achieved_at ASC, id ASC;
+
+-- name: GetAllOpenGoalsDueBefore :many
+SELECT id, goal, due FROM goals
+WHERE user_id = ?
+  AND due < CAST(sqlc.arg(due_before) AS INTEGER)
+  AND status NOT IN ('achieved', 'abandoned')
+  AND deleted_at IS NULL
+  AND archived_at IS NULL
+ORDER BY due ASC, id ASC;
+
+-- name: GetAllSubscribersByDay :many
+-- Users without a digest day get the digest on Mondays.
+SELECT users.id, users.email
+FROM users
+LEFT JOIN preferences ON preferences.user_id = users.id
+WHERE COALESCE(preferences.digest_day, 'monday') = CAST(sqlc.arg(day) AS TEXT)
+ORDER BY users.id ASC;
@@ -1,27 +1,200 @@
 -- name: Create :one
-INSERT INTO goals (user_id, goal, description, due, visible_to_public, achieved)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO goals (user_id, goal, description, due, visible_to_public, status, recurrence, start_date)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: Get :one
 SELECT * FROM goals
-WHERE id = ? AND user_id = ?;
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
 
 -- name: GetAll :many
 SELECT * FROM goals
-WHERE user_id = ?
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NULL
 ORDER BY due ASC;
 
--- name: GetAllShared :many
+-- name: GetPage :many
 SELECT * FROM goals
-WHERE user_id = ? AND visible_to_public = 1
-ORDER BY due ASC;
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND (archived_at IS NULL OR CAST(sqlc.arg(include_archived) AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(sqlc.arg(shared) AS INTEGER) = 0)
+  AND IFNULL(visible_to_public, 0) = COALESCE(CAST(sqlc.narg(visible) AS INTEGER), IFNULL(visible_to_public, 0))
+  AND (status = 'achieved') = COALESCE(CAST(sqlc.narg(achieved) AS INTEGER), status = 'achieved')
+  AND due >= COALESCE(CAST(sqlc.narg(due_from) AS INTEGER), due)
+  AND COALESCE(MIN(start_date, due), due) < COALESCE(CAST(sqlc.narg(start_before) AS INTEGER), due + 1)
+  AND due < COALESCE(CAST(sqlc.narg(due_before) AS INTEGER), due + 1)
+  AND (due, id) > (CAST(sqlc.arg(after_due) AS INTEGER), CAST(sqlc.arg(after_id) AS INTEGER))
+ORDER BY due ASC, id ASC
+LIMIT ?;
+
+-- name: GetAllRecurring :many
+SELECT * FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND recurrence IS NOT NULL AND next_occurrence_id IS NULL
+  AND (archived_at IS NULL OR CAST(sqlc.arg(include_archived) AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(sqlc.arg(shared) AS INTEGER) = 0)
+ORDER BY due ASC, id ASC;
+
+-- name: GetYears :many
+SELECT DISTINCT CAST(strftime('%Y', due, 'unixepoch') AS INTEGER) AS year FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND (archived_at IS NULL OR CAST(sqlc.arg(include_archived) AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(sqlc.arg(shared) AS INTEGER) = 0)
+ORDER BY year ASC;
+
+-- name: GetAllNeedingAttention :many
+SELECT * FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NULL AND due IS NOT NULL
+  AND status NOT IN ('achieved', 'abandoned')
+  AND (due < CAST(sqlc.arg(due_before) AS INTEGER) OR status = 'at_risk')
+ORDER BY due ASC, id ASC;
 
 -- name: Update :execresult
 UPDATE goals
-SET goal = ?, description = ?, due = ?, visible_to_public = ?, achieved = ?
+SET goal = ?, description = ?, due = ?, visible_to_public = ?, status = ?, recurrence = ?, achieved_at = ?, start_date = ?
 WHERE id = ? AND user_id = ?;
 
 -- name: Delete :execresult
 DELETE FROM goals
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL;
+
+-- name: UpdateDue :execresult
+UPDATE goals
+SET due = ?, start_date = start_date + CAST(sqlc.arg(start_shift) AS INTEGER)
 WHERE id = ? AND user_id = ?;
+
+-- name: SetNextOccurrence :execresult
+UPDATE goals
+SET next_occurrence_id = ?
+WHERE id = ? AND user_id = ?;
+
+-- name: CreateDependency :exec
+INSERT INTO goal_dependencies (goal_id, depends_on_id, user_id)
+VALUES (?, ?, ?);
+
+-- name: DeleteDependency :execresult
+DELETE FROM goal_dependencies
+WHERE goal_id = ? AND depends_on_id = ? AND user_id = ?;
+
+-- name: GetAllDependencies :many
+SELECT goal_id, depends_on_id FROM goal_dependencies
+WHERE user_id = ?;
+
+-- name: GetPrerequisites :many
+SELECT goals.* FROM goals
+JOIN goal_dependencies ON goal_dependencies.depends_on_id = goals.id
+WHERE goal_dependencies.goal_id = ? AND goal_dependencies.user_id = ? AND goals.deleted_at IS NULL
+ORDER BY goals.due ASC;
+
+-- name: GetDependents :many
+SELECT goals.* FROM goals
+JOIN goal_dependencies ON goal_dependencies.goal_id = goals.id
+WHERE goal_dependencies.depends_on_id = ? AND goal_dependencies.user_id = ? AND goals.deleted_at IS NULL
+ORDER BY goals.due ASC;
+
+-- name: GetScheduleConflicts :many
+SELECT goal_dependencies.goal_id, prerequisite.goal AS prerequisite_goal
+FROM goal_dependencies
+JOIN goals AS dependent ON dependent.id = goal_dependencies.goal_id
+JOIN goals AS prerequisite ON prerequisite.id = goal_dependencies.depends_on_id
+WHERE goal_dependencies.user_id = ? AND dependent.due < prerequisite.due
+  AND dependent.deleted_at IS NULL AND prerequisite.deleted_at IS NULL
+ORDER BY prerequisite.due ASC;
+
+-- name: CreateStatusChange :exec
+INSERT INTO goal_status_history (goal_id, user_id, from_status, to_status, note)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetStatusHistory :many
+SELECT * FROM goal_status_history
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateContent :execresult
+UPDATE goals
+SET goal = ?, description = ?, due = ?
+WHERE id = ? AND user_id = ?;
+
+-- name: CreateRevision :exec
+INSERT INTO goal_revisions (goal_id, user_id, goal, description, due)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetRevisions :many
+SELECT * FROM goal_revisions
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetRevision :one
+SELECT * FROM goal_revisions
+WHERE id = ? AND goal_id = ? AND user_id = ?;
+
+-- name: Trash :execresult
+UPDATE goals
+SET deleted_at = unixepoch()
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: Restore :execresult
+UPDATE goals
+SET deleted_at = NULL
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL;
+
+-- name: GetAllTrashed :many
+SELECT * FROM goals
+WHERE user_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: PurgeTrash :execresult
+DELETE FROM goals
+WHERE deleted_at IS NOT NULL AND deleted_at < ?;
+
+-- name: GetAllArchived :many
+SELECT * FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NOT NULL
+ORDER BY due DESC;
+
+-- name: Archive :execresult
+UPDATE goals
+SET archived_at = unixepoch()
+WHERE id = ? AND user_id = ? AND archived_at IS NULL;
+
+-- name: Unarchive :execresult
+UPDATE goals
+SET archived_at = NULL
+WHERE id = ? AND user_id = ? AND archived_at IS NOT NULL;
+
+-- name: AutoArchive :execresult
+UPDATE goals
+SET archived_at = unixepoch()
+WHERE user_id = ? AND status = 'achieved' AND archived_at IS NULL AND deleted_at IS NULL
+  AND achieved_at < CAST(sqlc.arg(achieved_before) AS INTEGER);
+
+-- name: SetJournalPublic :execresult
+UPDATE goals
+SET journal_public = ?
+WHERE id = ? AND user_id = ?;
+
+-- name: CreateReschedule :one
+INSERT INTO goal_reschedules (goal_id, user_id, delta)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: CreateRescheduleItem :exec
+INSERT INTO goal_reschedule_items (reschedule_id, goal_id, user_id, previous_due, previous_start_date, due)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetRescheduleItems :many
+SELECT * FROM goal_reschedule_items
+WHERE reschedule_id = ? AND user_id = ?
+ORDER BY id ASC;
+
+-- name: DeleteReschedule :execresult
+DELETE FROM goal_reschedules
+WHERE id = ? AND user_id = ? AND created_at >= ?;
+
+-- name: DeleteReschedulesBefore :exec
+DELETE FROM goal_reschedules
+WHERE created_at < ?;
+
+-- name: SetSchedule :execresult
+UPDATE goals
+SET due = ?, start_date = ?
+WHERE id = ? AND user_id = ? AND due = sqlc.arg(current_due);
@@ -0,0 +1,23 @@
+-- name: CreateEntry :one
+INSERT INTO journal_entries (goal_id, user_id, body, mood, confidence)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEntry :one
+SELECT * FROM journal_entries
+WHERE id = ? AND user_id = ?;
+
+-- name: GetAllEntriesByGoal :many
+SELECT * FROM journal_entries
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateEntry :one
+UPDATE journal_entries
+SET body = ?, mood = ?, confidence = ?, updated_at = unixepoch()
+WHERE id = ? AND user_id = ?
+RETURNING *;
+
+-- name: DeleteEntry :execresult
+DELETE FROM journal_entries
+WHERE id = ? AND user_id = ?;
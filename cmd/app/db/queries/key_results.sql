@@ -0,0 +1,36 @@
+-- name: CreateKeyResult :one
+INSERT INTO key_results (goal_id, user_id, description, unit, start_value, target_value, current_value)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetKeyResult :one
+SELECT * FROM key_results
+WHERE id = ? AND user_id = ?;
+
+-- name: GetAllKeyResultsByGoal :many
+SELECT * FROM key_results
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetAllKeyResultsByUser :many
+SELECT * FROM key_results
+WHERE user_id = ?
+ORDER BY goal_id ASC, created_at ASC, id ASC;
+
+-- name: UpdateKeyResultCurrentValue :execresult
+UPDATE key_results
+SET current_value = ?
+WHERE id = ? AND user_id = ?;
+
+-- name: DeleteKeyResult :execresult
+DELETE FROM key_results
+WHERE id = ? AND user_id = ?;
+
+-- name: CreateCheckIn :exec
+INSERT INTO key_result_check_ins (key_result_id, user_id, value, note)
+VALUES (?, ?, ?, ?);
+
+-- name: GetCheckIns :many
+SELECT * FROM key_result_check_ins
+WHERE key_result_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC;
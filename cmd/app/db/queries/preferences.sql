@@ -0,0 +1,34 @@
+-- name: GetByUserID :one
+SELECT user_id, auto_archive_days, risk_days, risk_progress, reminder_days, digest_day
+FROM preferences
+WHERE user_id = ?;
+
+-- name: SetAutoArchiveDays :exec
+INSERT INTO preferences (user_id, auto_archive_days)
+VALUES (?, ?)
+ON CONFLICT(user_id) DO UPDATE SET
+    auto_archive_days = excluded.auto_archive_days;
+
+-- name: SetRiskThresholds :exec
+INSERT INTO preferences (user_id, risk_days, risk_progress)
+VALUES (?, ?, ?)
+ON CONFLICT(user_id) DO UPDATE SET
+    risk_days = excluded.risk_days,
+    risk_progress = excluded.risk_progress;
+
+-- name: SetDigestDay :exec
+INSERT INTO preferences (user_id, digest_day)
+VALUES (?, ?)
+ON CONFLICT(user_id) DO UPDATE SET
+    digest_day = excluded.digest_day;
+
+-- name: SetReminderDays :exec
+INSERT INTO preferences (user_id, reminder_days)
+VALUES (?, ?)
+ON CONFLICT(user_id) DO UPDATE SET
+    reminder_days = excluded.reminder_days;
+
+-- name: GetAllWithAutoArchive :many
+SELECT user_id, auto_archive_days, risk_days, risk_progress, reminder_days, digest_day
+FROM preferences
+WHERE auto_archive_days IS NOT NULL;
@@ -0,0 +1,17 @@
+-- name: CreateReaction :execresult
+INSERT INTO goal_reactions (goal_id, user_id, viewer_id, reaction)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (goal_id, viewer_id, reaction) DO NOTHING;
+
+-- name: GetAllReactionCountsByGoals :many
+SELECT goal_id, reaction, count FROM goal_reaction_counts
+WHERE user_id = ? AND goal_id IN (sqlc.slice('goal_ids'))
+ORDER BY goal_id ASC;
+
+-- name: GetAllReactionsByViewer :many
+SELECT goal_id, reaction FROM goal_reactions
+WHERE user_id = ? AND viewer_id = ?;
+
+-- name: DeleteReaction :execresult
+DELETE FROM goal_reactions
+WHERE goal_id = ? AND viewer_id = ? AND reaction = ?;
@@ -0,0 +1,21 @@
+-- name: CreateReminder :execresult
+INSERT INTO goal_reminders (goal_id, user_id, days_before, due)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (goal_id, days_before, due) DO NOTHING;
+
+-- name: DeleteReminder :exec
+DELETE FROM goal_reminders
+WHERE goal_id = ? AND days_before = ? AND due = ?;
+
+-- name: GetAllOpenGoalsDueBetween :many
+SELECT goals.id, goals.user_id, goals.goal, goals.due, users.email, preferences.reminder_days
+FROM goals
+JOIN users ON users.id = goals.user_id
+LEFT JOIN preferences ON preferences.user_id = goals.user_id
+WHERE goals.due >= CAST(sqlc.arg(due_from) AS INTEGER)
+  AND goals.due < CAST(sqlc.arg(due_to) AS INTEGER)
+  AND goals.deleted_at IS NULL
+  AND goals.archived_at IS NULL
+  AND goals.status NOT IN ('achieved', 'abandoned')
+  AND (preferences.reminder_days IS NULL OR preferences.reminder_days <> '')
+ORDER BY goals.user_id ASC, goals.due ASC, goals.id ASC;
@@ -0,0 +1,33 @@
+-- name: SearchCriteria :many
+SELECT
+  success_criteria.id,
+  success_criteria.goal_id,
+  goals.goal,
+  CAST(snippet(success_criteria_fts, 0, char(2), char(3), '…', 12) AS TEXT) AS snippet,
+  CAST(bm25(success_criteria_fts) AS REAL) AS rank
+FROM success_criteria_fts
+JOIN success_criteria ON success_criteria.id = success_criteria_fts.rowid
+JOIN goals ON goals.id = success_criteria.goal_id
+WHERE success_criteria_fts MATCH sqlc.arg(query)
+  AND success_criteria.user_id = sqlc.arg(user_id)
+  AND success_criteria.deleted_at IS NULL
+  AND goals.deleted_at IS NULL
+ORDER BY rank
+LIMIT sqlc.arg(limit);
+
+-- name: SearchGoals :many
+SELECT
+  goals.id,
+  goals.due,
+  goals.status,
+  goals.archived_at,
+  CAST(highlight(goals_fts, 0, char(2), char(3)) AS TEXT) AS goal,
+  CAST(snippet(goals_fts, 1, char(2), char(3), '…', 12) AS TEXT) AS snippet,
+  CAST(bm25(goals_fts, 10.0, 1.0) AS REAL) AS rank
+FROM goals_fts
+JOIN goals ON goals.id = goals_fts.rowid
+WHERE goals_fts MATCH sqlc.arg(query)
+  AND goals.user_id = sqlc.arg(user_id)
+  AND goals.deleted_at IS NULL
+ORDER BY rank
+LIMIT sqlc.arg(limit);
@@ -6,12 +6,19 @@ VALUES (?, ?);
 SELECT COUNT(*) FROM share WHERE user_id = ?;
 
 -- name: GetAll :many
-SELECT id, user_id, public_id
+SELECT id, user_id, public_id, include_archived
 FROM share
 WHERE user_id = ?;
 
--- name: GetUserIDByPublicID :one
-SELECT user_id FROM share WHERE public_id = ?;
+-- name: GetByPublicID :one
+SELECT id, user_id, public_id, include_archived
+FROM share
+WHERE public_id = ?;
+
+-- name: SetIncludeArchived :execresult
+UPDATE share
+SET include_archived = ?
+WHERE id = ? AND user_id = ?;
 
 -- name: Delete :execresult
 DELETE FROM share WHERE id = ?;
@@ -0,0 +1,8 @@
+-- name: CreateSigningKey :exec
+INSERT INTO signing_keys (name, key)
+VALUES (?, ?)
+ON CONFLICT (name) DO NOTHING;
+
+-- name: GetSigningKey :one
+SELECT key FROM signing_keys
+WHERE name = ?;
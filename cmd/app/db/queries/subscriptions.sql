@@ -0,0 +1,65 @@
+-- name: CreateSubscription :execresult
+INSERT INTO share_subscriptions (share_id, email, token)
+VALUES (?, ?, ?)
+ON CONFLICT (share_id, email) DO NOTHING;
+
+-- name: GetSubscriptionByEmail :one
+SELECT * FROM share_subscriptions
+WHERE share_id = ? AND email = ?;
+
+-- name: GetSubscriptionByToken :one
+SELECT * FROM share_subscriptions
+WHERE share_id = ? AND token = ?;
+
+-- name: ConfirmSubscription :execresult
+-- New subscribers are only notified of updates from now on.
+UPDATE share_subscriptions
+SET confirmed_at = CAST(sqlc.arg(confirmed_at) AS INTEGER),
+    last_update_id = (SELECT COALESCE(MAX(share_updates.id), 0) FROM share_updates)
+WHERE id = ? AND confirmed_at IS NULL;
+
+-- name: DeleteSubscription :exec
+DELETE FROM share_subscriptions
+WHERE id = ?;
+
+-- name: DeleteUnconfirmedSubscriptionsBefore :exec
+DELETE FROM share_subscriptions
+WHERE confirmed_at IS NULL AND created_at < ?;
+
+-- name: GetAllNotifiableSubscriptions :many
+SELECT share_subscriptions.id, share_subscriptions.email, share_subscriptions.token, share_subscriptions.last_update_id,
+       share.public_id, share.user_id, share.include_archived
+FROM share_subscriptions
+JOIN share ON share.id = share_subscriptions.share_id
+WHERE share_subscriptions.confirmed_at IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM share_updates
+    WHERE share_updates.user_id = share.user_id AND share_updates.id > share_subscriptions.last_update_id
+  )
+ORDER BY share_subscriptions.id ASC;
+
+-- name: GetLastUpdateID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) FROM share_updates
+WHERE user_id = ?;
+
+-- name: GetAllUpdatesBetween :many
+-- Updates of goals that are no longer on the timeline are left out.
+SELECT share_updates.id, share_updates.goal_id, goals.goal, share_updates.kind, share_updates.due, share_updates.previous_due
+FROM share_updates
+JOIN goals ON goals.id = share_updates.goal_id
+WHERE share_updates.user_id = ?
+  AND share_updates.id > CAST(sqlc.arg(after_id) AS INTEGER)
+  AND share_updates.id <= CAST(sqlc.arg(until_id) AS INTEGER)
+  AND goals.visible_to_public = 1
+  AND goals.deleted_at IS NULL
+  AND (goals.archived_at IS NULL OR CAST(sqlc.arg(include_archived) AS INTEGER) = 1)
+ORDER BY share_updates.id ASC;
+
+-- name: SetLastUpdateID :execresult
+UPDATE share_subscriptions
+SET last_update_id = CAST(sqlc.arg(to_id) AS INTEGER)
+WHERE id = ? AND last_update_id = CAST(sqlc.arg(from_id) AS INTEGER);
+
+-- name: DeleteUpdatesBefore :exec
+DELETE FROM share_updates
+WHERE created_at < ?;
@@ -5,13 +5,22 @@ RETURNING *;
 
 -- name: GetSuccessCriteria :one
 SELECT * FROM success_criteria
-WHERE id = ? AND user_id = ?;
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
 
 -- name: GetAllSuccessCriteriaByGoal :many
 SELECT * FROM success_criteria
-WHERE goal_id = ? AND user_id = ?
+WHERE goal_id = ? AND user_id = ? AND deleted_at IS NULL
 ORDER BY position ASC, created_at ASC;
 
+-- name: GetSuccessCriteriaCountsByUser :many
+SELECT
+    goal_id,
+    COUNT(*) AS total,
+    CAST(SUM(completed = 1) AS INTEGER) AS completed
+FROM success_criteria
+WHERE user_id = ? AND deleted_at IS NULL
+GROUP BY goal_id;
+
 -- name: UpdateSuccessCriteria :execresult
 UPDATE success_criteria
 SET description = ?, completed = ?, position = ?
@@ -24,8 +33,27 @@ WHERE id = ? AND user_id = ?;
 
 -- name: DeleteSuccessCriteria :execresult
 DELETE FROM success_criteria
-WHERE id = ? AND user_id = ?;
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL;
 
 -- name: DeleteAllSuccessCriteriaByGoal :execresult
 DELETE FROM success_criteria
 WHERE goal_id = ? AND user_id = ?;
+
+-- name: TrashSuccessCriteria :execresult
+UPDATE success_criteria
+SET deleted_at = unixepoch()
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: RestoreSuccessCriteria :execresult
+UPDATE success_criteria
+SET deleted_at = NULL
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL;
+
+-- name: GetAllTrashedSuccessCriteria :many
+SELECT * FROM success_criteria
+WHERE user_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: PurgeTrashedSuccessCriteria :execresult
+DELETE FROM success_criteria
+WHERE deleted_at IS NOT NULL AND deleted_at < ?;
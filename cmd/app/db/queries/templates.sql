@@ -0,0 +1,17 @@
+-- name: Create :one
+INSERT INTO goal_templates (user_id, name, description, goals)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: Get :one
+SELECT * FROM goal_templates
+WHERE id = ? AND user_id = ?;
+
+-- name: GetAll :many
+SELECT * FROM goal_templates
+WHERE user_id = ?
+ORDER BY name ASC, id ASC;
+
+-- name: Delete :execresult
+DELETE FROM goal_templates
+WHERE id = ? AND user_id = ?;
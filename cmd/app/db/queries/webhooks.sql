@@ -0,0 +1,59 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks (user_id, url, secret, events)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: CountWebhooksByUser :one
+SELECT COUNT(*) FROM webhooks WHERE user_id = ?;
+
+-- name: GetAllWebhooksByUser :many
+SELECT * FROM webhooks
+WHERE user_id = ?
+ORDER BY id ASC;
+
+-- name: DeleteWebhook :execresult
+DELETE FROM webhooks
+WHERE id = ? AND user_id = ?;
+
+-- name: GetRecentDeliveriesByUser :many
+SELECT webhook_deliveries.id, webhook_deliveries.webhook_id, webhooks.url, webhook_deliveries.event,
+       webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts,
+       webhook_deliveries.next_attempt_at, webhook_deliveries.response_status, webhook_deliveries.last_error,
+       webhook_deliveries.created_at
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.user_id = ?
+ORDER BY webhook_deliveries.id DESC
+LIMIT ?;
+
+-- name: Redeliver :execresult
+INSERT INTO webhook_deliveries (webhook_id, user_id, event, payload)
+SELECT webhook_id, user_id, event, payload FROM webhook_deliveries
+WHERE webhook_deliveries.id = ? AND webhook_deliveries.user_id = ?;
+
+-- name: GetAllDueDeliveries :many
+SELECT webhook_deliveries.id, webhook_deliveries.event, webhook_deliveries.payload, webhook_deliveries.attempts,
+       webhook_deliveries.created_at, webhooks.url, webhooks.secret
+FROM webhook_deliveries
+JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.status = 'pending' AND webhook_deliveries.next_attempt_at <= ?
+ORDER BY webhook_deliveries.id ASC
+LIMIT ?;
+
+-- name: ClaimDelivery :execresult
+-- The next attempt is scheduled right away, so deliveries that were cut off
+-- by a restart are retried as well.
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    last_attempt_at = CAST(sqlc.arg(attempted_at) AS INTEGER),
+    next_attempt_at = CAST(sqlc.arg(next_attempt_at) AS INTEGER)
+WHERE id = ? AND status = 'pending' AND attempts = CAST(sqlc.arg(attempts) AS INTEGER);
+
+-- name: SetDeliveryResult :exec
+UPDATE webhook_deliveries
+SET status = ?, response_status = ?, last_error = ?, delivered_at = ?
+WHERE id = ?;
+
+-- name: DeleteDeliveriesBefore :exec
+DELETE FROM webhook_deliveries
+WHERE status != 'pending' AND created_at < ?;
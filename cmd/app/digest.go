@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// digestInterval is how often users are checked for a due weekly digest.
+const digestInterval = time.Hour
+
+// DigestEmailData contains data for the weekly digest email.
+type DigestEmailData struct {
+	// From is the first day of the week the digest is about.
+	From      time.Time
+	Achieved  []EmailGoal
+	Completed []DigestEmailCriterion
+	DueSoon   []EmailGoal
+	Overdue   []EmailGoal
+	// Preview is set for digests sent from the settings.
+	Preview        bool
+	GoalsURL       string
+	SettingsURL    string
+	UnsubscribeURL string
+}
+
+// Empty reports whether there is nothing to tell in the digest.
+func (d DigestEmailData) Empty() bool {
+	return len(d.Achieved) == 0 && len(d.Completed) == 0 && len(d.DueSoon) == 0 && len(d.Overdue) == 0
+}
+
+// DigestEmailCriterion is a success criterion listed in the digest email.
+type DigestEmailCriterion struct {
+	Description string
+	Goal        EmailGoal
+}
+
+// sendDigests emails the weekly digest to every user whose digest day it is.
+// Digests are recorded before they are sent, so none is sent twice, and given
+// back when sending fails, so they are retried.
+func (app *app) sendDigests(ctx context.Context) error {
+	now := time.Now()
+
+	subscribers, err := app.services.digest.Claim(ctx, now)
+	errs := []error{err}
+
+	for _, subscriber := range subscribers {
+		sent, err := app.sendDigest(ctx, subscriber.UserID, subscriber.Email, now, false)
+		if err != nil {
+			// The context may be done already, releasing must still happen.
+			errs = append(errs, err, app.services.digest.Release(context.WithoutCancel(ctx), subscriber.UserID, now))
+			continue
+		}
+
+		if sent {
+			app.logger.Info("sent digest", "user_id", subscriber.UserID)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendDigest emails the digest of a user at now and reports whether it was
+// sent. Digests with nothing to tell are only sent as preview.
+func (app *app) sendDigest(ctx context.Context, userID int64, email string, now time.Time, preview bool) (bool, error) {
+	d, err := app.services.digest.Build(ctx, userID, now)
+	if err != nil {
+		return false, err
+	}
+	if d.Empty() && !preview {
+		return false, nil
+	}
+
+	data := DigestEmailData{
+		From:           d.From,
+		Preview:        preview,
+		GoalsURL:       app.config.BaseURL + "/goals",
+		SettingsURL:    app.config.BaseURL + "/settings",
+		UnsubscribeURL: app.unsubscribeURL(userID),
+	}
+	for _, goal := range d.Achieved {
+		data.Achieved = append(data.Achieved, app.emailGoal(goal.ID, goal.Goal, time.Time{}, now))
+	}
+	for _, c := range d.Completed {
+		data.Completed = append(data.Completed, DigestEmailCriterion{
+			Description: c.Description,
+			Goal:        app.emailGoal(c.GoalID, c.Goal, time.Time{}, now),
+		})
+	}
+	for _, goal := range d.DueSoon {
+		data.DueSoon = append(data.DueSoon, app.emailGoal(goal.ID, goal.Goal, goal.Due, now))
+	}
+	for _, goal := range d.Overdue {
+		data.Overdue = append(data.Overdue, app.emailGoal(goal.ID, goal.Goal, goal.Due, now))
+	}
+
+	msg, err := app.emails.Render(email, "digest", data)
+	if err != nil {
+		return false, err
+	}
+	msg.Unsubscribe = data.UnsubscribeURL
+
+	if err := app.mailer.Send(ctx, msg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// unsubscribeURL returns the link that stops the digest of a user without
+// signing in.
+func (app *app) unsubscribeURL(userID int64) string {
+	return app.config.BaseURL + "/digest/unsubscribe/" + app.unsubscribeSigner.Sign(strconv.FormatInt(userID, 10))
+}
+
+// digestUserID returns the user of an unsubscribe link token and whether the
+// token is valid.
+func (app *app) digestUserID(token string) (int, bool) {
+	value, ok := app.unsubscribeSigner.Verify(token)
+	if !ok {
+		return 0, false
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return userID, true
+}
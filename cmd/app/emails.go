@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+)
+
+// EmailGoal is a goal listed in an email.
+type EmailGoal struct {
+	Goal  string
+	Due   time.Time
+	Label string
+	URL   string
+}
+
+// emailGoal returns a goal for an email, linking to the goal in the app and
+// describing its due date relative to now.
+func (app *app) emailGoal(id int64, goal string, due time.Time, now time.Time) EmailGoal {
+	g := EmailGoal{
+		Goal: goal,
+		Due:  due,
+		URL:  fmt.Sprintf("%s/goals/%d", app.config.BaseURL, id),
+	}
+	if !due.IsZero() {
+		g.Label = goals.View{Due: due}.DueLabel(now)
+	}
+	return g
+}
@@ -1,40 +1,254 @@
 package main
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/attachments"
 	"github.com/bit8bytes/goalkeepr/internal/branding"
+	"github.com/bit8bytes/goalkeepr/internal/comments"
 	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/journal"
+	"github.com/bit8bytes/goalkeepr/internal/key_results"
+	"github.com/bit8bytes/goalkeepr/internal/preferences"
+	"github.com/bit8bytes/goalkeepr/internal/reactions"
+	"github.com/bit8bytes/goalkeepr/internal/roadmap"
+	"github.com/bit8bytes/goalkeepr/internal/search"
 	"github.com/bit8bytes/goalkeepr/internal/share"
+	"github.com/bit8bytes/goalkeepr/internal/subscriptions"
 	successCriteria "github.com/bit8bytes/goalkeepr/internal/success_criteria"
+	"github.com/bit8bytes/goalkeepr/internal/templates"
+	"github.com/bit8bytes/goalkeepr/internal/timeline"
+	"github.com/bit8bytes/goalkeepr/internal/webhooks"
 )
 
-// GoalGroup represents a group of goals organized by date.
-type GoalGroup struct {
-	Date  time.Time
-	Goals []goals.View
+// TimelineData holds the filter and paging of a timeline.
+type TimelineData struct {
+	Filter timeline.Filter
+	// Years are the years goals are due in, to filter by.
+	Years []int
+	Zooms []timeline.Zoom
+	// Offset is the index of the first group on this page and NextOffset
+	// the one of the following page.
+	Offset     int
+	NextOffset int
+	// Next is the cursor of the following page, or nil if there is none.
+	Next *goals.Cursor
+	// Path is the URL of the timeline without query parameters.
+	Path string
+	// Select shows checkboxes to pick goals for bulk actions.
+	Select bool
+}
+
+// URL returns the URL of the timeline with its filter, in select mode if
+// selecting is set.
+func (t TimelineData) URL(selecting bool) string {
+	values := t.Filter.Values()
+	if selecting {
+		values.Set("select", "on")
+	}
+	if len(values) == 0 {
+		return t.Path
+	}
+	return t.Path + "?" + values.Encode()
+}
+
+// MoreURL returns the URL that loads the next page of the timeline.
+func (t TimelineData) MoreURL() string {
+	values := t.Filter.Values()
+	if t.Select {
+		values.Set("select", "on")
+	}
+	values.Set("after", t.Next.String())
+	values.Set("offset", strconv.Itoa(t.NextOffset))
+	return t.Path + "?" + values.Encode()
+}
+
+// BulkURL returns the URL bulk actions are posted to. It keeps the filter,
+// so the timeline looks the same after the action.
+func (t TimelineData) BulkURL() string {
+	if values := t.Filter.Values(); len(values) > 0 {
+		return "/goals/bulk?" + values.Encode()
+	}
+	return "/goals/bulk"
 }
 
 // SharePageData contains data for the public share page.
 type SharePageData struct {
 	Goals      []goals.View
-	GoalGroups []GoalGroup
+	GoalGroups []timeline.Group
 	Branding   branding.View
+	Timeline   TimelineData
+	// Journals holds the journal entries of goals whose journal is public,
+	// by goal ID.
+	Journals map[int64][]journal.View
+	// Attachments holds the attachments shown on the share page, by goal ID.
+	Attachments map[int64][]attachments.View
+	// Comments holds the approved comments, by goal ID.
+	Comments map[int64][]comments.View
+	// CommentForm holds an invalid comment to show again.
+	CommentForm *comments.Form
+	// SubscribeForm holds an invalid subscription to show again.
+	SubscribeForm *subscriptions.Form
+	// SignedIn is set for signed in viewers, who can comment without a name.
+	SignedIn bool
+	// Reactions holds the reaction counts, by goal ID.
+	Reactions map[int64]reactions.Counts
+	// MyReactions holds the reactions the viewer gave, by goal ID.
+	MyReactions map[int64]map[string]bool
+}
+
+// CommentBoxData is the data of the comments of one goal on the share page.
+type CommentBoxData struct {
+	// Path is the path of the share page.
+	Path     string
+	GoalID   int64
+	Comments []comments.View
+	// Form is nil unless a comment on this goal failed validation.
+	Form     *comments.Form
+	SignedIn bool
+}
+
+// CommentBox returns the comment data of the goal with the given ID.
+func (d SharePageData) CommentBox(goalID int64) CommentBoxData {
+	box := CommentBoxData{
+		Path:     d.Timeline.Path,
+		GoalID:   goalID,
+		Comments: d.Comments[goalID],
+		SignedIn: d.SignedIn,
+	}
+	if d.CommentForm != nil && int64(d.CommentForm.GoalID) == goalID {
+		box.Form = d.CommentForm
+	}
+	return box
+}
+
+// ReactionBoxData is the data of the reaction buttons of one goal on the
+// share page.
+type ReactionBoxData struct {
+	// Path is the path of the share page.
+	Path      string
+	GoalID    int64
+	Reactions []reactions.View
+}
+
+// ReactionBox returns the reaction data of the goal with the given ID.
+func (d SharePageData) ReactionBox(goalID int64) ReactionBoxData {
+	return ReactionBoxData{
+		Path:      d.Timeline.Path,
+		GoalID:    goalID,
+		Reactions: reactions.Buttons(d.Reactions[goalID], d.MyReactions[goalID]),
+	}
 }
 
 // GoalsPageData contains data for the user's goals page.
 type GoalsPageData struct {
 	Goals           []goals.View
-	GoalGroups      []GoalGroup
+	GoalGroups      []timeline.Group
 	Branding        branding.View
 	Now             time.Time
 	GoalDefaultDues map[int64]string
+	Timeline        TimelineData
+	// Reactions holds the reactions viewers gave on share pages, by goal ID.
+	Reactions map[int64][]reactions.View
+	// Overview lists the goals that need attention. It is only filled when
+	// the whole page is rendered.
+	Overview goals.Overview
+}
+
+// OverviewPageData contains data for the overdue and at-risk overview.
+type OverviewPageData struct {
+	Overview   goals.Overview
+	Thresholds preferences.View
+	Now        time.Time
 }
 
 // EditGoalPageData contains data for the edit goal page.
 type EditGoalPageData struct {
 	SuccessCriteria []successCriteria.View
 	GoalID          int
+	Prerequisites   []DependencyView
+	Dependents      []goals.View
+	// Candidates are the goals that can be added as prerequisites.
+	Candidates    []goals.View
+	Statuses      []goals.Status
+	StatusHistory []goals.StatusChange
+	// Revisions are the earlier versions of the goal, newest first.
+	Revisions  []goals.Revision
+	Archived   bool
+	KeyResults []KeyResultView
+	// KeyResultForm and CheckInForm hold invalid input to show again.
+	KeyResultForm *key_results.Form
+	CheckInForm   *key_results.CheckInForm
+	// Journal holds the journal entries of the goal, newest first.
+	Journal         []journal.View
+	JournalPublic   bool
+	NewJournalEntry JournalEntryData
+	Attachments     []attachments.View
+	// AttachmentUsage tells how much of the upload quota is used.
+	AttachmentUsage string
+	// LinkForm and FileForm hold invalid attachments to show again.
+	LinkForm *attachments.LinkForm
+	FileForm *attachments.FileForm
+}
+
+// JournalEntryData is the data of the journal entry form partials. Form is
+// nil for an empty form.
+type JournalEntryData struct {
+	Entry journal.View
+	Form  *journal.Form
+	Moods []journal.Mood
+}
+
+// KeyResultView is a key result of a goal with its check-ins, newest first.
+type KeyResultView struct {
+	KeyResult key_results.View
+	CheckIns  []key_results.CheckInView
+}
+
+// DependencyView is a prerequisite of a goal. Conflict is set when the
+// prerequisite is due after the goal that depends on it.
+type DependencyView struct {
+	Goal     goals.View
+	Conflict bool
+}
+
+// TrashPageData contains data for the trash page.
+type TrashPageData struct {
+	Goals           []goals.View
+	SuccessCriteria []TrashedCriterionView
+	// RetentionDays is how long items stay in the trash before they are purged.
+	RetentionDays int
+}
+
+// TrashedCriterionView is a success criterion in the trash together with the
+// title of its goal.
+type TrashedCriterionView struct {
+	Criterion successCriteria.View
+	Goal      string
+}
+
+// ArchivePageData contains data for the archive page.
+type ArchivePageData struct {
+	Years []ArchiveYear
+}
+
+// ArchiveYear groups archived goals by the year they were due.
+type ArchiveYear struct {
+	Year  string
+	Goals []goals.View
+}
+
+// RoadmapPageData contains data for the roadmap page.
+type RoadmapPageData struct {
+	Roadmap roadmap.Roadmap
+}
+
+// SearchPageData contains data for the search page and the search results
+// in the header.
+type SearchPageData struct {
+	Query   string
+	Results []search.Result
 }
 
 // ShareGoalsPageData contains data for the share goals management page.
@@ -48,3 +262,71 @@ type ErrorPageData struct {
 	TraceID string
 	Message string
 }
+
+// ReschedulePageData contains data for rescheduling a goal and all goals
+// after it.
+type ReschedulePageData struct {
+	Goal goals.View
+	// Shifts previews the goals that move. It is nil until the form is
+	// filled in.
+	Shifts []goals.Shift
+}
+
+// DuplicatePageData contains data for duplicating a goal.
+type DuplicatePageData struct {
+	Goal goals.View
+	// SuccessCriteria is the number of success criteria that are copied.
+	SuccessCriteria int
+}
+
+// TemplatesPageData contains data for the goal templates page.
+type TemplatesPageData struct {
+	Templates []templates.Template
+	// Goals can be saved as a new template.
+	Goals []goals.View
+	Use   *templates.UseForm
+	Save  *templates.SaveForm
+	// Today is the default start date for using a template.
+	Today string
+}
+
+// CommentsPageData contains the comments on the user's shared goals.
+type CommentsPageData struct {
+	// Pending comments wait for moderation, newest first.
+	Pending  []comments.QueueView
+	Approved []comments.QueueView
+}
+
+// MarkdownPreviewData contains a description to preview, or why it can't be.
+type MarkdownPreviewData struct {
+	Description string
+	Error       string
+}
+
+// UnsubscribePageData contains data for unsubscribing from the weekly digest.
+type UnsubscribePageData struct {
+	// Done is set once the user is unsubscribed.
+	Done bool
+}
+
+// SubscriptionPageData contains data for confirming or ending a subscription
+// to a share link.
+type SubscriptionPageData struct {
+	Title     string
+	SharePath string
+	// Confirm is set for confirming, otherwise the page unsubscribes.
+	Confirm bool
+	// Done is set once the subscription is confirmed or ended.
+	Done bool
+}
+
+// WebhooksPageData contains the user's webhooks and the log of their
+// deliveries.
+type WebhooksPageData struct {
+	Webhooks []webhooks.View
+	// Deliveries are the latest deliveries, newest first.
+	Deliveries []webhooks.DeliveryView
+	// Events are the events webhooks can receive.
+	Events []string
+	Form   *webhooks.Form
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
-	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/attachments"
+	"github.com/bit8bytes/goalkeepr/internal/comments"
 	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/journal"
+	"github.com/bit8bytes/goalkeepr/internal/share"
+	"github.com/bit8bytes/goalkeepr/internal/subscriptions"
+	"github.com/bit8bytes/goalkeepr/internal/timeline"
 	"github.com/bit8bytes/goalkeepr/ui/page"
 	"github.com/bit8bytes/toolbox/vcs"
 )
@@ -31,6 +37,13 @@ func (app *app) getLanding(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *app) getShare(w http.ResponseWriter, r *http.Request) {
+	app.renderShare(w, r, http.StatusOK, nil, nil)
+}
+
+// renderShare renders the shared timeline of the link in the URL. form is
+// shown when a comment failed validation, subscribeForm when a subscription
+// did.
+func (app *app) renderShare(w http.ResponseWriter, r *http.Request, status int, form *comments.Form, subscribeForm *subscriptions.Form) {
 	publicID := r.PathValue("id")
 	if publicID == "" {
 		data := app.newTemplateData(r)
@@ -38,42 +51,92 @@ func (app *app) getShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, err := app.services.share.GetUserIDByPublicID(r.Context(), publicID)
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), publicID)
 	if err != nil {
 		data := app.newTemplateData(r)
 		app.render(w, r, http.StatusNotFound, page.NotFound, data)
 		return
 	}
+	userID := int(shareLink.UserID)
+
+	filter := timeline.ParseFilter(r.URL.Query())
+	offset, after := timelinePosition(r.URL.Query())
+	scope := goals.Scope{Shared: true, IncludeArchived: shareLink.ToView().IncludeArchived}
 
-	goalList, err := app.services.goals.GetAllShared(r.Context(), userID)
+	tl, err := app.loadTimeline(r.Context(), userID, scope, filter, after)
 	if err != nil {
 		app.renderError(w, r, err, "Error loading shared goals.")
 		return
 	}
+	goalGroups := tl.Groups
+
+	// Only the goals on this page show their journal.
+	pageGoals := []goals.View{}
+	journals := make(map[int64][]journal.View)
+	for _, group := range goalGroups {
+		for _, goal := range group.Goals {
+			pageGoals = append(pageGoals, goal)
+			if !goal.JournalPublic || goal.Upcoming {
+				continue
+			}
+
+			entries, err := app.services.journal.GetAllByGoal(r.Context(), int(goal.ID), userID)
+			if err != nil {
+				app.renderError(w, r, err, "Error loading shared goals.")
+				return
+			}
+
+			for _, e := range entries {
+				journals[goal.ID] = append(journals[goal.ID], e.ToView())
+			}
+		}
+	}
 
-	goalViews := make([]goals.View, len(goalList))
-	for i, goal := range goalList {
-		goalViews[i] = goal.ToView()
+	publicAttachments, err := app.services.attachments.GetAllPublicByGoals(r.Context(), userID, tl.GoalIDs())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading shared goals.")
+		return
+	}
+
+	attachmentViews := make(map[int64][]attachments.View)
+	for _, goal := range pageGoals {
+		if goal.Upcoming {
+			continue
+		}
+		for _, a := range publicAttachments[goal.ID] {
+			attachmentViews[goal.ID] = append(attachmentViews[goal.ID], a.ToView())
+		}
+	}
+
+	approvedComments, err := app.services.comments.GetAllApprovedByGoals(r.Context(), userID, tl.GoalIDs())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading shared goals.")
+		return
 	}
 
-	// Group goals by date for visual grouping in timeline
-	goalGroups := []GoalGroup{}
-	var currentGroup *GoalGroup
+	commentViews := make(map[int64][]comments.View)
+	for _, goal := range pageGoals {
+		if goal.Upcoming {
+			continue
+		}
+		for _, c := range approvedComments[goal.ID] {
+			commentViews[goal.ID] = append(commentViews[goal.ID], c.ToView())
+		}
+	}
 
-	for _, goalView := range goalViews {
-		// Normalize the date to start of day for comparison
-		goalDate := time.Date(goalView.Due.Year(), goalView.Due.Month(), goalView.Due.Day(), 0, 0, 0, 0, time.UTC)
+	reactionCounts, err := app.services.reactions.GetCountsByGoals(r.Context(), userID, tl.GoalIDs())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading shared goals.")
+		return
+	}
 
-		// Check if we need to start a new group
-		if currentGroup == nil || !currentGroup.Date.Equal(goalDate) {
-			goalGroups = append(goalGroups, GoalGroup{
-				Date:  goalDate,
-				Goals: []goals.View{goalView},
-			})
-			currentGroup = &goalGroups[len(goalGroups)-1]
-		} else {
-			// Add to current group
-			currentGroup.Goals = append(currentGroup.Goals, goalView)
+	// Viewers only have a cookie once they reacted, so most views skip this.
+	var myReactions map[int64]map[string]bool
+	if viewerID, ok := app.viewerID(r); ok {
+		myReactions, err = app.services.reactions.GetByViewer(r.Context(), userID, viewerID)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading shared goals.")
+			return
 		}
 	}
 
@@ -85,12 +148,50 @@ func (app *app) getShare(w http.ResponseWriter, r *http.Request) {
 
 	data := app.newTemplateData(r)
 	data.Data = SharePageData{
-		Goals:      goalViews,
-		GoalGroups: goalGroups,
-		Branding:   b.ToView(),
+		Goals:         pageGoals,
+		GoalGroups:    goalGroups,
+		Branding:      b.ToView(),
+		Journals:      journals,
+		Attachments:   attachmentViews,
+		Comments:      commentViews,
+		CommentForm:   form,
+		SubscribeForm: subscribeForm,
+		SignedIn:      data.IsAuthenticated,
+		Reactions:     reactionCounts,
+		MyReactions:   myReactions,
+		Timeline: TimelineData{
+			Filter:     filter,
+			Years:      tl.Years,
+			Zooms:      timeline.Zooms(),
+			Offset:     offset,
+			NextOffset: offset + len(goalGroups),
+			Next:       tl.Next,
+			Path:       "/s/" + publicID,
+		},
+	}
+
+	// Loading more goals only needs the next groups of the timeline.
+	if r.Header.Get("HX-Request") == "true" {
+		app.renderPartial(w, r, status, page.Share, "timeline-items", data)
+		return
+	}
+
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, status, page.Share, data)
+}
+
+// isSharedGoal reports whether the goal with goalID is on the timeline of
+// shareLink.
+func (app *app) isSharedGoal(ctx context.Context, shareLink share.Share, goalID int) (bool, error) {
+	goal, err := app.services.goals.Get(ctx, goalID, int(shareLink.UserID))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	app.render(w, r, http.StatusOK, page.Share, data)
+	return goal.VisibleToPublic.Int64 == 1 && (!goal.ArchivedAt.Valid || shareLink.ToView().IncludeArchived), nil
 }
 
 type getHealthzData struct {
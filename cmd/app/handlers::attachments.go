@@ -0,0 +1,304 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/attachments"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// maxUploadMemory is how much of an upload is kept in memory while parsing
+// the form. The rest is buffered in temporary files.
+const maxUploadMemory = 1 << 20
+
+func (app *app) postAddLinkAttachment(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &attachments.LinkForm{
+		URL:   sanitize.Text(r.PostForm.Get("url")),
+		Title: sanitize.Text(r.PostForm.Get("title")),
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.LinkForm = form
+		})
+		return
+	}
+
+	// The goal has to exist and belong to the user.
+	if _, err := app.services.goals.Get(r.Context(), goalID, getUserID(r)); err != nil {
+		if err == sql.ErrNoRows {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	if err := app.services.attachments.AddLink(r.Context(), goalID, getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error saving link.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Link attached!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	form := &attachments.FileForm{}
+
+	// Leave room for the other fields of the form.
+	r.Body = http.MaxBytesReader(w, r.Body, attachments.MaxFileSize+maxUploadMemory)
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			form.AddError("file", fileTooLargeMessage)
+			app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+				d.FileForm = form
+			})
+			return
+		}
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	form.Title = sanitize.Text(r.PostForm.Get("title"))
+
+	file, header, err := r.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+	if file != nil {
+		defer file.Close()
+		form.FileName = sanitize.Text(header.Filename)
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.FileForm = form
+		})
+		return
+	}
+
+	// The goal has to exist and belong to the user.
+	if _, err := app.services.goals.Get(r.Context(), goalID, getUserID(r)); err != nil {
+		if err == sql.ErrNoRows {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	err = app.services.attachments.AddFile(r.Context(), goalID, getUserID(r), form, file)
+	switch {
+	case errors.Is(err, attachments.ErrFileTooLarge):
+		form.AddError("file", fileTooLargeMessage)
+	case errors.Is(err, attachments.ErrQuotaExceeded):
+		form.AddError("file", "This file doesn't fit into your storage. Delete some files first.")
+	case err != nil:
+		app.renderError(w, r, err, "Error saving file.")
+		return
+	}
+
+	if !form.Valid() {
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.FileForm = form
+		})
+		return
+	}
+
+	app.putFlash(r.Context(), "File uploaded!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+var fileTooLargeMessage = fmt.Sprintf("File cannot be larger than %s", attachments.FormatSize(attachments.MaxFileSize))
+
+func (app *app) getAttachment(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(r.PathValue("attachmentId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid attachment ID.")
+		return
+	}
+
+	a, f, err := app.services.attachments.Open(r.Context(), attachmentID, goalID, getUserID(r))
+	if err != nil {
+		if err == sql.ErrNoRows || errors.Is(err, os.ErrNotExist) {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Error loading file.")
+		return
+	}
+	defer f.Close()
+
+	serveAttachment(w, r, a.ToView(), f)
+}
+
+func (app *app) getSharedAttachment(w http.ResponseWriter, r *http.Request) {
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+	userID := int(shareLink.UserID)
+
+	goalID, err := strconv.Atoi(r.PathValue("goalId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(r.PathValue("attachmentId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid attachment ID.")
+		return
+	}
+
+	// The goal has to be on the share page, too.
+	shared, err := app.isSharedGoal(r.Context(), shareLink, goalID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading file.")
+		return
+	}
+	if !shared {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	a, f, err := app.services.attachments.OpenPublic(r.Context(), attachmentID, goalID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows || errors.Is(err, os.ErrNotExist) {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Error loading file.")
+		return
+	}
+	defer f.Close()
+
+	serveAttachment(w, r, a.ToView(), f)
+}
+
+// serveAttachment writes the content of a file attachment. Only images and
+// plain text are shown inline, everything else is downloaded, and the
+// sandbox keeps the file from running scripts even if a browser renders it.
+func serveAttachment(w http.ResponseWriter, r *http.Request, a attachments.View, content io.ReadSeeker) {
+	contentType, inline := attachments.ServeType(a.ContentType)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", attachments.Disposition(a.FileName, inline))
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	http.ServeContent(w, r, "", time.Time{}, content)
+}
+
+func (app *app) postAttachmentPublic(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(r.PathValue("attachmentId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid attachment ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	public := r.PostForm.Get("public") == "1"
+	rowsAffected, err := app.services.attachments.SetPublic(r.Context(), attachmentID, goalID, getUserID(r), public)
+	if err != nil {
+		app.renderError(w, r, err, "Error updating attachment.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(r.PathValue("attachmentId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid attachment ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.attachments.Delete(r.Context(), attachmentID, goalID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting attachment.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Attachment deleted.")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
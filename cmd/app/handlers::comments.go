@@ -0,0 +1,157 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/comments"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/internal/users"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) postShareComment(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), publicID)
+	if err != nil {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	goalID, err := strconv.Atoi(r.PathValue("goalId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	// Honeypot for bot protection
+	if sanitize.Text(r.PostForm.Get("website")) != "" {
+		time.Sleep(3 * time.Second)
+		return
+	}
+
+	// Viewers don't have to be signed in, so withAuth can't tell who they are.
+	authorID := app.sessionManager.GetInt(r.Context(), string(users.Key))
+
+	form := &comments.Form{
+		GoalID:   goalID,
+		Name:     sanitize.Text(r.PostForm.Get("name")),
+		Body:     sanitize.Text(r.PostForm.Get("body")),
+		SignedIn: authorID != 0,
+	}
+	form.Validate()
+
+	shared, err := app.isSharedGoal(r.Context(), shareLink, goalID)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your comment.")
+		return
+	}
+	if !shared {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	if !form.Valid() {
+		app.renderShare(w, r, http.StatusUnprocessableEntity, form, nil)
+		return
+	}
+
+	ownerID := int(shareLink.UserID)
+	if _, err := app.services.comments.Add(r.Context(), ownerID, authorID, form, time.Now()); err != nil {
+		app.renderError(w, r, err, "Error saving your comment.")
+		return
+	}
+
+	if authorID == ownerID {
+		app.putFlash(r.Context(), "Comment posted!")
+	} else {
+		app.putFlash(r.Context(), "Thanks! Your comment will appear once it is approved.")
+	}
+	http.Redirect(w, r, "/s/"+publicID, http.StatusSeeOther)
+}
+
+func (app *app) getComments(w http.ResponseWriter, r *http.Request) {
+	rows, err := app.services.comments.GetAllByUser(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading comments.")
+		return
+	}
+
+	var pageData CommentsPageData
+	for _, row := range rows {
+		if c := row.ToView(); c.Approved {
+			pageData.Approved = append(pageData.Approved, c)
+		} else {
+			pageData.Pending = append(pageData.Pending, c)
+		}
+	}
+
+	data := app.newTemplateData(r)
+	data.Flash = app.flash(r.Context())
+	data.Data = pageData
+	app.render(w, r, http.StatusOK, page.Comments, data)
+}
+
+func (app *app) postApproveComment(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	commentID, err := strconv.Atoi(r.PostForm.Get("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid comment ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.comments.Approve(r.Context(), commentID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error approving comment.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Comment approved.")
+	http.Redirect(w, r, "/goals/comments", http.StatusSeeOther)
+}
+
+func (app *app) postDeleteComment(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	commentID, err := strconv.Atoi(r.PostForm.Get("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid comment ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.comments.Delete(r.Context(), commentID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting comment.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Comment deleted.")
+	http.Redirect(w, r, "/goals/comments", http.StatusSeeOther)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/preferences"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) postDigestSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &preferences.DigestForm{DigestDay: r.PostForm.Get("digest_day")}
+	form.Validate()
+
+	if !form.Valid() {
+		forms, err := app.settingsForms(r)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading user settings.")
+			return
+		}
+		forms["Digest"] = form
+
+		data := app.newTemplateData(r)
+		data.Form = forms
+		app.render(w, r, http.StatusUnprocessableEntity, page.Settings, data)
+		return
+	}
+
+	if err := app.services.preferences.SetDigestDay(r.Context(), getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error updating digest settings.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Digest settings saved")
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
+// postDigestPreview sends the digest of this week to the user right away,
+// even if it has nothing to tell or the user unsubscribed.
+func (app *app) postDigestPreview(w http.ResponseWriter, r *http.Request) {
+	user, err := app.services.users.GetByID(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your account.")
+		return
+	}
+	email := user.ToView().Email
+
+	if _, err := app.sendDigest(r.Context(), int64(getUserID(r)), email, time.Now(), true); err != nil {
+		app.renderError(w, r, err, "Error sending the digest preview.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Digest preview sent to "+email)
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
+func (app *app) getUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	if _, ok := app.digestUserID(r.PathValue("token")); !ok {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = UnsubscribePageData{}
+	app.render(w, r, http.StatusOK, page.Unsubscribe, data)
+}
+
+// postUnsubscribe stops the weekly digest of the user of the link. Mail
+// clients post to it for one-click unsubscribe, so it needs no session.
+func (app *app) postUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := app.digestUserID(r.PathValue("token"))
+	if !ok {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	// The user may have deleted their account since.
+	if _, err := app.services.users.GetByID(r.Context(), userID); err != nil {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	if err := app.services.preferences.UnsubscribeDigest(r.Context(), userID); err != nil {
+		app.renderError(w, r, err, "Error unsubscribing you.")
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = UnsubscribePageData{Done: true}
+	app.render(w, r, http.StatusOK, page.Unsubscribe, data)
+}
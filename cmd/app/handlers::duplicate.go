@@ -0,0 +1,112 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// getDuplicateGoal shows the form to duplicate a goal with the same title and
+// a suggested due date.
+func (app *app) getDuplicateGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goal.")
+		return
+	}
+
+	form := &goals.DuplicateForm{
+		Goal: goal.Goal.String,
+		Due:  goals.SuggestDue(goal, time.Now()).Format(HTMLDateFormat),
+	}
+	app.renderDuplicateGoal(w, r, http.StatusOK, goal, form)
+}
+
+// renderDuplicateGoal renders the duplicate page of goal with form.
+func (app *app) renderDuplicateGoal(w http.ResponseWriter, r *http.Request, status int, goal goals.Goal, form *goals.DuplicateForm) {
+	criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), int(goal.ID), getUserID(r))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		app.renderError(w, r, err, "Error loading success criteria.")
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Form = form
+	data.Data = DuplicatePageData{
+		Goal:            goal.ToView(),
+		SuccessCriteria: len(criteria),
+	}
+	app.render(w, r, status, page.Duplicate, data)
+}
+
+func (app *app) postDuplicateGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goal.")
+		return
+	}
+
+	form := &goals.DuplicateForm{
+		Goal: sanitize.Text(r.PostForm.Get("goal")),
+		Due:  sanitize.Date(r.PostForm.Get("due")),
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderDuplicateGoal(w, r, http.StatusUnprocessableEntity, goal, form)
+		return
+	}
+
+	var copyID int
+	err = database.WithTx(r.Context(), app.db, func(tx *sql.Tx) error {
+		copyID, err = app.services.goals.WithTx(tx).Duplicate(r.Context(), goalID, getUserID(r), form)
+		if err != nil {
+			return err
+		}
+		return app.services.successCriteria.WithTx(tx).CopyToGoal(r.Context(), goalID, copyID, getUserID(r))
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Error duplicating your goal.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Goal duplicated!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", copyID), http.StatusSeeOther)
+}
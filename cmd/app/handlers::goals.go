@@ -1,96 +1,121 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/attachments"
+	"github.com/bit8bytes/goalkeepr/internal/database"
 	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/journal"
+	"github.com/bit8bytes/goalkeepr/internal/key_results"
+	"github.com/bit8bytes/goalkeepr/internal/reactions"
 	"github.com/bit8bytes/goalkeepr/internal/sanitize"
 	"github.com/bit8bytes/goalkeepr/internal/share"
 	successCriteria "github.com/bit8bytes/goalkeepr/internal/success_criteria"
+	"github.com/bit8bytes/goalkeepr/internal/timeline"
 	"github.com/bit8bytes/goalkeepr/ui/page"
+	"github.com/bit8bytes/toolbox/validator"
 )
 
 func (app *app) getGoals(w http.ResponseWriter, r *http.Request) {
-	goalList, err := app.services.goals.GetAll(r.Context(), getUserID(r))
+	app.renderGoals(w, r, http.StatusOK, nil)
+}
+
+// renderGoals renders the timeline for the filter in the URL. form is shown
+// when a bulk action failed validation.
+func (app *app) renderGoals(w http.ResponseWriter, r *http.Request, status int, form *goals.BulkForm) {
+	filter := timeline.ParseFilter(r.URL.Query())
+	offset, after := timelinePosition(r.URL.Query())
+
+	tl, err := app.loadTimeline(r.Context(), getUserID(r), goals.Scope{}, filter, after)
 	if err != nil {
 		app.renderError(w, r, err, "Error loading your goals.")
 		return
 	}
+	goalGroups := tl.Groups
 
-	// TODO: Return goals with success criteria in one criteria
-	goalViews := make([]goals.View, len(goalList))
-	for i, goal := range goalList {
-		goalView := goal.ToView()
-
-		// Fetch success criteria for this goal
-		criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), int(goal.ID), getUserID(r))
-		if err != nil && err != sql.ErrNoRows {
-			app.renderError(w, r, err, "Error loading success criteria.")
-			return
-		}
-
-		// Count total and completed criteria
-		goalView.TotalCriteriaCount = len(criteria)
-		completedCount := 0
-		for _, c := range criteria {
-			if c.Completed.Valid && c.Completed.Int64 == 1 {
-				completedCount++
-			}
-		}
-		goalView.CompletedCriteriaCount = completedCount
-
-		goalViews[i] = goalView
+	conflicts, err := app.services.goals.ScheduleConflicts(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading goal dependencies.")
+		return
 	}
 
-	// Group goals by date for visual grouping in timeline
-	goalGroups := []GoalGroup{}
-	var currentGroup *GoalGroup
-
-	for _, goalView := range goalViews {
-		// Normalize the date to start of day for comparison
-		goalDate := time.Date(goalView.Due.Year(), goalView.Due.Month(), goalView.Due.Day(), 0, 0, 0, 0, time.UTC)
+	keyResults, err := app.services.keyResults.GetAllByUser(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading key results.")
+		return
+	}
 
-		// Check if we need to start a new group
-		if currentGroup == nil || !currentGroup.Date.Equal(goalDate) {
-			goalGroups = append(goalGroups, GoalGroup{
-				Date:  goalDate,
-				Goals: []goals.View{goalView},
-			})
-			currentGroup = &goalGroups[len(goalGroups)-1]
-		} else {
-			// Add to current group
-			currentGroup.Goals = append(currentGroup.Goals, goalView)
+	// Only the goals on this page need their progress.
+	// TODO: Return goals with success criteria in one criteria
+	pageGoals := []goals.View{}
+	for gi := range goalGroups {
+		for i := range goalGroups[gi].Goals {
+			goalView := &goalGroups[gi].Goals[i]
+			if !goalView.Upcoming {
+				// Fetch success criteria for this goal
+				criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), int(goalView.ID), getUserID(r))
+				if err != nil && err != sql.ErrNoRows {
+					app.renderError(w, r, err, "Error loading success criteria.")
+					return
+				}
+
+				// Count total and completed criteria
+				goalView.TotalCriteriaCount = len(criteria)
+				completedCount := 0
+				for _, c := range criteria {
+					if c.Completed.Valid && c.Completed.Int64 == 1 {
+						completedCount++
+					}
+				}
+				goalView.CompletedCriteriaCount = completedCount
+				goalView.KeyResultCount = len(keyResults[goalView.ID])
+				goalView.KeyResultProgress = key_results.AverageProgress(keyResults[goalView.ID])
+				goalView.ScheduleConflicts = conflicts[goalView.ID]
+			}
+			pageGoals = append(pageGoals, *goalView)
 		}
 	}
 
-	// Calculate default due dates for adding goals after each existing goal
+	// Calculate default due dates for adding goals after each goal on the
+	// page. Upcoming occurrences share the ID of their goal, so the last
+	// one of them wins.
 	goalDefaultDues := make(map[int64]string)
-	for i := range goalViews {
+	for i, goal := range pageGoals {
 		var nextDue time.Time
 
-		if goalList[i].Due.Valid {
-			currentDue := time.Unix(goalList[i].Due.Int64, 0)
-
-			// Check if there's a next goal with a valid due date
-			if i+1 < len(goalList) && goalList[i+1].Due.Valid {
-				nextGoalDue := time.Unix(goalList[i+1].Due.Int64, 0)
-				// Calculate midpoint between current and next goal
-				diff := nextGoalDue.Sub(currentDue)
-				nextDue = currentDue.Add(diff / 2)
-			} else {
-				// No next goal, default to 3 months after current goal
-				nextDue = currentDue.AddDate(0, 3, 0)
-			}
-		} else {
-			// Current goal has no due date, use 3 months from now
-			nextDue = time.Now().AddDate(0, 3, 0)
+		switch {
+		case i+1 < len(pageGoals):
+			// Midpoint between the goal and the next one
+			nextDue = goal.Due.Add(pageGoals[i+1].Due.Sub(goal.Due) / 2)
+		case tl.Following != nil:
+			nextDue = goal.Due.Add(time.Unix(tl.Following.Due.Int64, 0).Sub(goal.Due) / 2)
+		default:
+			// No next goal, default to 3 months after the goal
+			nextDue = goal.Due.AddDate(0, 3, 0)
 		}
 
-		goalDefaultDues[goalList[i].ID] = nextDue.Format(HTMLDateFormat)
+		goalDefaultDues[goal.ID] = nextDue.Format(HTMLDateFormat)
+	}
+
+	reactionCounts, err := app.services.reactions.GetCountsByGoals(r.Context(), getUserID(r), tl.GoalIDs())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading reactions.")
+		return
+	}
+
+	reactionViews := make(map[int64][]reactions.View)
+	for _, goal := range pageGoals {
+		if summary := reactions.Summary(reactionCounts[goal.ID]); summary != nil {
+			reactionViews[goal.ID] = summary
+		}
 	}
 
 	branding, err := app.services.branding.GetByUserID(r.Context(), getUserID(r))
@@ -99,15 +124,54 @@ func (app *app) getGoals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Loading more goals doesn't show the goals that need attention again.
+	var overview goals.Overview
+	if r.Header.Get("HX-Request") != "true" {
+		prefs, err := app.services.preferences.GetByUserID(r.Context(), getUserID(r))
+		if err != nil {
+			app.renderError(w, r, err, "Error loading your settings.")
+			return
+		}
+
+		overview, err = app.assessGoals(r.Context(), getUserID(r), prefs.ToView(), time.Now())
+		if err != nil {
+			app.renderError(w, r, err, "Error loading your goals.")
+			return
+		}
+	}
+
 	data := app.newTemplateData(r)
 	data.Data = GoalsPageData{
-		Goals:           goalViews,
+		Goals:           pageGoals,
 		GoalGroups:      goalGroups,
 		Branding:        branding.ToView(),
 		Now:             time.Now(),
 		GoalDefaultDues: goalDefaultDues,
+		Reactions:       reactionViews,
+		Overview:        overview,
+		Timeline: TimelineData{
+			Filter:     filter,
+			Years:      tl.Years,
+			Zooms:      timeline.Zooms(),
+			Offset:     offset,
+			NextOffset: offset + len(goalGroups),
+			Next:       tl.Next,
+			Path:       "/goals",
+			Select:     form != nil || r.URL.Query().Get("select") == "on",
+		},
+	}
+
+	// Loading more goals only needs the next groups of the timeline.
+	if r.Header.Get("HX-Request") == "true" {
+		app.renderPartial(w, r, status, page.Goals, "timeline-items", data)
+		return
 	}
-	app.render(w, r, http.StatusOK, page.Goals, data)
+
+	if form != nil {
+		data.Form = form
+	}
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, status, page.Goals, data)
 }
 
 func (app *app) getAddGoal(w http.ResponseWriter, r *http.Request) {
@@ -129,11 +193,19 @@ func (app *app) postAddGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	repeatInterval, _ := strconv.Atoi(r.PostForm.Get("repeat_interval"))
+	repeatCount, _ := strconv.Atoi(r.PostForm.Get("repeat_count"))
+
 	form := &goals.Form{
 		Goal:            sanitize.Text(r.PostForm.Get("goal")),
 		Description:     sanitize.Text(r.PostForm.Get("description")),
+		Start:           sanitize.Date(r.PostForm.Get("start")),
 		Due:             sanitize.Date(r.PostForm.Get("due")),
 		VisibleToPublic: r.PostForm.Get("visible") == "on",
+		Repeat:          sanitize.Text(r.PostForm.Get("repeat")),
+		RepeatInterval:  repeatInterval,
+		RepeatUntil:     sanitize.Date(r.PostForm.Get("repeat_until")),
+		RepeatCount:     repeatCount,
 	}
 	form.Validate()
 
@@ -172,22 +244,49 @@ func (app *app) getEditGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pageData, err := app.editGoalPageData(r, goal.ToView())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your goal.")
+		return
+	}
+
+	data.Form = newEditGoalForm(goal)
+	data.Data = pageData
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, http.StatusOK, page.EditGoal, data)
+}
+
+// newEditGoalForm returns the edit form filled with the saved goal.
+func newEditGoalForm(goal goals.Goal) *goals.Form {
 	goalView := goal.ToView()
 
-	editGoalForm := &goals.Form{
+	form := &goals.Form{
 		ID:              int(goalView.ID),
 		Goal:            goalView.Goal,
 		Description:     goalView.Description,
 		Due:             goalView.Due.Format(HTMLDateFormat),
-		Achieved:        goalView.Achieved,
+		Status:          string(goalView.Status),
 		VisibleToPublic: goalView.VisibleToPublic,
 	}
+	if goalView.HasSpan() {
+		form.Start = goalView.Start.UTC().Format(HTMLDateFormat)
+	}
+	if !goalView.AchievedAt.IsZero() {
+		form.AchievedAt = goalView.AchievedAt.UTC().Format(HTMLDateFormat)
+	}
+	form.SetRecurrence(goal.Recurrence.String)
 
-	// Load success criteria for this goal
-	criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), goalID, getUserID(r))
+	return form
+}
+
+// editGoalPageData loads everything shown next to the goal form on the edit page.
+func (app *app) editGoalPageData(r *http.Request, goal goals.View) (EditGoalPageData, error) {
+	userID := getUserID(r)
+	goalID := int(goal.ID)
+
+	criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), goalID, userID)
 	if err != nil && err != sql.ErrNoRows {
-		app.renderError(w, r, err, "Error loading success criteria.")
-		return
+		return EditGoalPageData{}, err
 	}
 
 	criteriaViews := make([]successCriteria.View, len(criteria))
@@ -195,13 +294,120 @@ func (app *app) getEditGoal(w http.ResponseWriter, r *http.Request) {
 		criteriaViews[i] = c.ToView()
 	}
 
-	data.Form = editGoalForm
-	data.Data = EditGoalPageData{
+	prerequisites, err := app.services.goals.GetPrerequisites(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	isPrerequisite := make(map[int64]bool, len(prerequisites))
+	prerequisiteViews := make([]DependencyView, len(prerequisites))
+	for i, p := range prerequisites {
+		view := p.ToView()
+		isPrerequisite[p.ID] = true
+		prerequisiteViews[i] = DependencyView{
+			Goal:     view,
+			Conflict: view.Due.After(goal.Due),
+		}
+	}
+
+	dependents, err := app.services.goals.GetDependents(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	dependentViews := make([]goals.View, len(dependents))
+	for i, d := range dependents {
+		dependentViews[i] = d.ToView()
+	}
+
+	all, err := app.services.goals.GetAll(r.Context(), userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	candidates := []goals.View{}
+	for _, g := range all {
+		if g.ID == goal.ID || isPrerequisite[g.ID] {
+			continue
+		}
+		candidates = append(candidates, g.ToView())
+	}
+
+	history, err := app.services.goals.GetStatusHistory(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	revisions, err := app.services.goals.GetRevisions(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	keyResults, err := app.services.keyResults.GetAllByGoal(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	keyResultViews := make([]KeyResultView, len(keyResults))
+	for i, kr := range keyResults {
+		checkIns, err := app.services.keyResults.GetCheckIns(r.Context(), int(kr.ID), userID)
+		if err != nil {
+			return EditGoalPageData{}, err
+		}
+
+		checkInViews := make([]key_results.CheckInView, len(checkIns))
+		for j, c := range checkIns {
+			checkInViews[j] = c.ToView()
+		}
+
+		keyResultViews[i] = KeyResultView{
+			KeyResult: kr.ToView(),
+			CheckIns:  checkInViews,
+		}
+	}
+
+	entries, err := app.services.journal.GetAllByGoal(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	entryViews := make([]journal.View, len(entries))
+	for i, e := range entries {
+		entryViews[i] = e.ToView()
+	}
+
+	attachmentList, err := app.services.attachments.GetAllByGoal(r.Context(), goalID, userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	attachmentViews := make([]attachments.View, len(attachmentList))
+	for i, a := range attachmentList {
+		attachmentViews[i] = a.ToView()
+	}
+
+	used, quota, err := app.services.attachments.Usage(r.Context(), userID)
+	if err != nil {
+		return EditGoalPageData{}, err
+	}
+
+	return EditGoalPageData{
 		SuccessCriteria: criteriaViews,
 		GoalID:          goalID,
-	}
-	data.Flash = app.flash(r.Context())
-	app.render(w, r, http.StatusOK, page.EditGoal, data)
+		Prerequisites:   prerequisiteViews,
+		Dependents:      dependentViews,
+		Candidates:      candidates,
+		Statuses:        goals.Statuses(),
+		StatusHistory:   history,
+		Revisions:       revisions,
+		Archived:        goal.Archived,
+		KeyResults:      keyResultViews,
+		Journal:         entryViews,
+		JournalPublic:   goal.JournalPublic,
+		NewJournalEntry: JournalEntryData{Moods: journal.Moods()},
+		Attachments:     attachmentViews,
+		AttachmentUsage: fmt.Sprintf("%s of %s used", attachments.FormatSize(used), attachments.FormatSize(quota)),
+	}, nil
 }
 
 func (app *app) postEditGoal(w http.ResponseWriter, r *http.Request) {
@@ -220,34 +426,98 @@ func (app *app) postEditGoal(w http.ResponseWriter, r *http.Request) {
 	rawDescription := r.PostForm.Get("description")
 	rawDue := r.PostForm.Get("due")
 	visibleToPublic := r.PostForm.Get("visible") == "on"
-	achieved := r.PostForm.Get("achieved") == "on"
+	shiftDependents := r.PostForm.Get("shift_dependents") == "on"
+	repeatInterval, _ := strconv.Atoi(r.PostForm.Get("repeat_interval"))
+	repeatCount, _ := strconv.Atoi(r.PostForm.Get("repeat_count"))
 
 	form := &goals.Form{
 		ID:              goalID,
 		Goal:            sanitize.Text(rawGoal),
 		Description:     sanitize.Text(rawDescription),
+		Start:           sanitize.Date(r.PostForm.Get("start")),
 		Due:             sanitize.Date(rawDue),
 		VisibleToPublic: visibleToPublic,
-		Achieved:        achieved,
+		Status:          sanitize.Text(r.PostForm.Get("status")),
+		StatusNote:      sanitize.Text(r.PostForm.Get("status_note")),
+		AchievedAt:      sanitize.Date(r.PostForm.Get("achieved_at")),
+		ShiftDependents: shiftDependents,
+		Repeat:          sanitize.Text(r.PostForm.Get("repeat")),
+		RepeatInterval:  repeatInterval,
+		RepeatUntil:     sanitize.Date(r.PostForm.Get("repeat_until")),
+		RepeatCount:     repeatCount,
 	}
 	form.Validate()
 
 	if !form.Valid() {
+		goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+		if err != nil {
+			app.renderError(w, r, err, "Couldn't get your goals.")
+			return
+		}
+
+		pageData, err := app.editGoalPageData(r, goal.ToView())
+		if err != nil {
+			app.renderError(w, r, err, "Error loading your goal.")
+			return
+		}
+
 		data := app.newTemplateData(r)
 		data.Form = form
+		data.Data = pageData
 		app.render(w, r, http.StatusUnprocessableEntity, page.EditGoal, data)
 		return
 	}
 
-	if _, err = app.services.goals.Update(r.Context(), goalID, getUserID(r), form); err != nil {
+	// Achieving a repeating goal adds its next occurrence in the same
+	// transaction, so the goal is only saved if that works too.
+	var nextID int
+	err = database.WithTx(r.Context(), app.db, func(tx *sql.Tx) error {
+		if _, err := app.services.goals.WithTx(tx).Update(r.Context(), goalID, getUserID(r), form); err != nil {
+			return err
+		}
+		if !form.Achieved() {
+			return nil
+		}
+
+		var err error
+		nextID, err = app.createNextOccurrence(r.Context(), tx, goalID, getUserID(r))
+		return err
+	})
+	if err != nil {
 		app.renderError(w, r, err, "Error updating your goal.")
 		return
 	}
 
+	if nextID != 0 {
+		app.putFlash(r.Context(), "Goal saved! The next occurrence has been added to your timeline.")
+		http.Redirect(w, r, fmt.Sprintf("/goals/%v", goalID), http.StatusSeeOther)
+		return
+	}
+
 	app.putFlash(r.Context(), "Goal saved!")
 	http.Redirect(w, r, fmt.Sprintf("/goals/%v", goalID), http.StatusSeeOther)
 }
 
+// createNextOccurrence adds the next goal of a repeating series together with
+// copies of the success criteria and key results of goalID, all in tx. It
+// returns 0 if no goal was added.
+func (app *app) createNextOccurrence(ctx context.Context, tx *sql.Tx, goalID, userID int) (int, error) {
+	nextID, err := app.services.goals.WithTx(tx).CreateNextOccurrence(ctx, goalID, userID)
+	if err != nil || nextID == 0 {
+		return 0, err
+	}
+
+	if err := app.services.successCriteria.WithTx(tx).CopyToGoal(ctx, goalID, nextID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := app.services.keyResults.WithTx(tx).CopyToGoal(ctx, goalID, nextID, userID); err != nil {
+		return 0, err
+	}
+
+	return nextID, nil
+}
+
 func (app *app) deleteEditGoal(w http.ResponseWriter, r *http.Request) {
 	goalID, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
@@ -255,7 +525,7 @@ func (app *app) deleteEditGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rowsAffected, err := app.services.goals.Delete(r.Context(), goalID, getUserID(r))
+	rowsAffected, err := app.services.goals.Trash(r.Context(), goalID, getUserID(r))
 	if err != nil {
 		app.renderError(w, r, err, "Error deleting your goal.")
 		return
@@ -267,6 +537,8 @@ func (app *app) deleteEditGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	app.putUndoFlash(r.Context(), "Goal moved to the trash.", fmt.Sprintf("/goals/%d/restore", goalID))
+
 	// TODO: Refactor and use internal/htmx
 	if r.Header.Get("HX-Request") == "true" {
 		w.Header().Set("HX-Redirect", "/goals")
@@ -277,6 +549,207 @@ func (app *app) deleteEditGoal(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/goals", http.StatusSeeOther)
 }
 
+// postBulkGoals applies a bulk action to the selected goals. All goals are
+// changed in one transaction, so either all of them change or none.
+func (app *app) postBulkGoals(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	// r.Form includes the query, which carries the goals to restore when a
+	// bulk delete is undone.
+	ids := make([]int, 0, len(r.Form["id"]))
+	for _, rawID := range r.Form["id"] {
+		id, err := strconv.Atoi(rawID)
+		if err != nil {
+			app.renderError(w, r, err, "Invalid goal ID.")
+			return
+		}
+		ids = append(ids, id)
+	}
+	days, _ := strconv.Atoi(r.Form.Get("days"))
+
+	form := &goals.BulkForm{
+		IDs:    ids,
+		Action: sanitize.Text(r.Form.Get("action")),
+		Days:   days,
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderGoals(w, r, http.StatusUnprocessableEntity, form)
+		return
+	}
+
+	userID := getUserID(r)
+	action := goals.BulkAction(form.Action)
+
+	var changed, created int
+	err := database.WithTx(r.Context(), app.db, func(tx *sql.Tx) error {
+		var err error
+		changed, err = app.services.goals.WithTx(tx).Bulk(r.Context(), userID, form)
+		if err != nil || action != goals.BulkAchieve {
+			return err
+		}
+
+		for _, id := range form.IDs {
+			nextID, err := app.createNextOccurrence(r.Context(), tx, id, userID)
+			if err != nil {
+				return err
+			}
+			if nextID != 0 {
+				created++
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+	if err != nil {
+		app.renderError(w, r, err, "Error updating your goals.")
+		return
+	}
+
+	msg := bulkMessage(action, changed, form.Days)
+	if created > 0 {
+		msg += fmt.Sprintf(" %s added to your timeline.", plural(created, "next occurrence", "next occurrences"))
+	}
+
+	if action == goals.BulkDelete && changed > 0 {
+		undo := url.Values{"action": {string(goals.BulkRestore)}, "id": r.Form["id"]}
+		app.putUndoFlash(r.Context(), msg, "/goals/bulk?"+undo.Encode())
+	} else {
+		app.putFlash(r.Context(), msg)
+	}
+
+	// Undo buttons post the action in the query and don't come from select
+	// mode.
+	redirect := TimelineData{Filter: timeline.ParseFilter(r.URL.Query()), Path: "/goals"}
+	http.Redirect(w, r, redirect.URL(r.PostForm.Has("action")), http.StatusSeeOther)
+}
+
+// bulkMessage describes the outcome of a bulk action, e.g. "3 goals made
+// public."
+func bulkMessage(action goals.BulkAction, n, days int) string {
+	goalCount := plural(n, "goal", "goals")
+	switch action {
+	case goals.BulkAchieve:
+		return goalCount + " marked as achieved."
+	case goals.BulkUnachieve:
+		return goalCount + " marked as not achieved."
+	case goals.BulkPublic:
+		return goalCount + " made public."
+	case goals.BulkPrivate:
+		return goalCount + " made private."
+	case goals.BulkShift:
+		if days < 0 {
+			return fmt.Sprintf("%s moved %s earlier.", goalCount, plural(-days, "day", "days"))
+		}
+		return fmt.Sprintf("%s moved %s later.", goalCount, plural(days, "day", "days"))
+	case goals.BulkDelete:
+		return goalCount + " moved to the trash."
+	default:
+		return goalCount + " restored."
+	}
+}
+
+// plural returns n followed by the singular or plural noun, e.g. "1 goal".
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+func (app *app) postAddDependency(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	dependsOnID, err := strconv.Atoi(r.PostForm.Get("depends_on"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	err = app.services.goals.AddDependency(r.Context(), goalID, dependsOnID, getUserID(r))
+	switch {
+	case errors.Is(err, goals.ErrDependencyCycle):
+		app.putFlash(r.Context(), "This dependency would create a cycle.")
+	case errors.Is(err, sql.ErrNoRows):
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	case err != nil:
+		app.renderError(w, r, err, "Error saving the dependency.")
+		return
+	default:
+		app.putFlash(r.Context(), "Dependency added!")
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	dependsOnID, err := strconv.Atoi(r.PathValue("dependsOnId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if _, err := app.services.goals.RemoveDependency(r.Context(), goalID, dependsOnID, getUserID(r)); err != nil {
+		app.renderError(w, r, err, "Error removing the dependency.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Dependency removed!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postRestoreRevision(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	revisionID, err := strconv.Atoi(r.PathValue("revisionId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid revision ID.")
+		return
+	}
+
+	if _, err := app.services.goals.RestoreRevision(r.Context(), goalID, revisionID, getUserID(r)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Error restoring your goal.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Version restored!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
 func (app *app) getShareGoals(w http.ResponseWriter, r *http.Request) {
 	shareLinks, err := app.services.share.GetAll(r.Context(), getUserID(r))
 	if err != nil {
@@ -312,9 +785,39 @@ func (app *app) postCreateShare(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
 		fmt.Fprintf(w, `<div class="flex gap-1 items-center">
 			<input type="text" value="%s/s/%s" readonly class="input flex-1 bg-base-200" onclick="this.select()">
+			<label class="label text-sm">
+				<input type="checkbox" name="include_archived" value="1" class="checkbox checkbox-sm" hx-patch="/goals/share/%d" hx-trigger="change" hx-swap="none">
+				Archived
+			</label>
 			<button class="btn" onclick="navigator.clipboard.writeText('%s/s/%s')">Copy</button>
-			<button class="btn btn-error" hx-delete="/goals/share/%d" hx-target="closest .flex" hx-swap="outerHTML" hx-confirm="Delete this share link?">Delete</button>
-		</div>`, r.Host, shareView.PublicID, r.Host, shareView.PublicID, shareView.ID)
+			<button class="btn btn-error" hx-delete="/goals/share/%d" hx-target="closest .flex" hx-swap="outerHTML" hx-confirm="Delete this share link? Its subscribers stop getting updates.">Delete</button>
+		</div>`, r.Host, shareView.PublicID, shareView.ID, r.Host, shareView.PublicID, shareView.ID)
+		return
+	}
+
+	http.Redirect(w, r, "/goals/share/", http.StatusSeeOther)
+}
+
+func (app *app) patchShare(w http.ResponseWriter, r *http.Request) {
+	shareID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid share ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	include := r.PostForm.Get("include_archived") == "1"
+	if err := app.services.share.SetIncludeArchived(r.Context(), shareID, getUserID(r), include); err != nil {
+		app.renderError(w, r, err, "Error updating share link.")
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
@@ -433,7 +936,7 @@ func (app *app) deleteSuccessCriteria(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := app.services.successCriteria.Delete(r.Context(), criteriaID, getUserID(r)); err != nil {
+	if _, err := app.services.successCriteria.Trash(r.Context(), criteriaID, getUserID(r)); err != nil {
 		app.renderError(w, r, err, "Error deleting success criteria.")
 		return
 	}
@@ -467,7 +970,7 @@ func (app *app) postUpdateSuccessCriteria(w http.ResponseWriter, r *http.Request
 
 		// Check if this criterion should be deleted
 		if r.PostForm.Get(fmt.Sprintf("delete_%s", criteriaIDStr)) == "1" {
-			if _, err := app.services.successCriteria.Delete(r.Context(), int(c.ID), userID); err != nil {
+			if _, err := app.services.successCriteria.Trash(r.Context(), int(c.ID), userID); err != nil {
 				app.renderError(w, r, err, "Error deleting success criteria.")
 				return
 			}
@@ -507,3 +1010,258 @@ func (app *app) postUpdateSuccessCriteria(w http.ResponseWriter, r *http.Request
 	app.putFlash(r.Context(), "Success criteria updated!")
 	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
 }
+
+func (app *app) getTrash(w http.ResponseWriter, r *http.Request) {
+	userID := getUserID(r)
+
+	trashedGoals, err := app.services.goals.GetAllTrashed(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading the trash.")
+		return
+	}
+
+	goalViews := make([]goals.View, len(trashedGoals))
+	for i, g := range trashedGoals {
+		goalViews[i] = g.ToView()
+	}
+
+	trashedCriteria, err := app.services.successCriteria.GetAllTrashed(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading the trash.")
+		return
+	}
+
+	activeGoals, err := app.services.goals.GetAll(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading the trash.")
+		return
+	}
+
+	archivedGoals, err := app.services.goals.GetAllArchived(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading the trash.")
+		return
+	}
+	activeGoals = append(activeGoals, archivedGoals...)
+
+	titles := make(map[int64]string, len(activeGoals))
+	for _, g := range activeGoals {
+		titles[g.ID] = g.Goal.String
+	}
+
+	// Success criteria of trashed goals come back with their goal, so only
+	// the ones deleted on their own are listed.
+	criteriaViews := []TrashedCriterionView{}
+	for _, c := range trashedCriteria {
+		title, ok := titles[c.GoalID]
+		if !ok {
+			continue
+		}
+		criteriaViews = append(criteriaViews, TrashedCriterionView{
+			Criterion: c.ToView(),
+			Goal:      title,
+		})
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = TrashPageData{
+		Goals:           goalViews,
+		SuccessCriteria: criteriaViews,
+		RetentionDays:   app.config.Trash.RetentionDays,
+	}
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, http.StatusOK, page.Trash, data)
+}
+
+func (app *app) postRestoreGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.goals.Restore(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error restoring your goal.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Goal restored!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postPurgeGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.goals.Delete(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting your goal.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Goal deleted permanently.")
+	http.Redirect(w, r, "/goals/trash", http.StatusSeeOther)
+}
+
+func (app *app) postRestoreSuccessCriteria(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	criteriaID, err := strconv.Atoi(r.PathValue("criteriaId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid criteria ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.successCriteria.Restore(r.Context(), criteriaID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error restoring success criteria.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Success criterion restored!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postPurgeSuccessCriteria(w http.ResponseWriter, r *http.Request) {
+	criteriaID, err := strconv.Atoi(r.PathValue("criteriaId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid criteria ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.successCriteria.Delete(r.Context(), criteriaID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting success criteria.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Success criterion deleted permanently.")
+	http.Redirect(w, r, "/goals/trash", http.StatusSeeOther)
+}
+
+func (app *app) getArchive(w http.ResponseWriter, r *http.Request) {
+	archivedGoals, err := app.services.goals.GetAllArchived(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading the archive.")
+		return
+	}
+
+	// Goals come latest due first, so each year is one run of goals.
+	years := []ArchiveYear{}
+	for _, g := range archivedGoals {
+		view := g.ToView()
+		if len(years) == 0 || years[len(years)-1].Year != view.Year {
+			years = append(years, ArchiveYear{Year: view.Year})
+		}
+		last := &years[len(years)-1]
+		last.Goals = append(last.Goals, view)
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = ArchivePageData{Years: years}
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, http.StatusOK, page.Archive, data)
+}
+
+func (app *app) postArchiveGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.goals.Archive(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error archiving your goal.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putUndoFlash(r.Context(), "Goal archived.", fmt.Sprintf("/goals/%d/unarchive", goalID))
+	http.Redirect(w, r, "/goals", http.StatusSeeOther)
+}
+
+func (app *app) postUnarchiveGoal(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.goals.Unarchive(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error unarchiving your goal.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Goal moved back to the timeline!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+// postPreviewDescription renders a goal description as it will be shown on
+// the timeline. It answers the preview of the edit form.
+// Previews are rendered without the cache, so they are held to the limit of
+// saved descriptions.
+func (app *app) postPreviewDescription(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, previewMaxBytes)
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	preview := MarkdownPreviewData{Description: sanitize.Text(r.PostForm.Get("description"))}
+	if !validator.MaxChars(preview.Description, goals.MaxDescriptionChars) {
+		preview = MarkdownPreviewData{Error: goals.DescriptionTooLongMessage()}
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = preview
+	app.renderPartial(w, r, http.StatusOK, page.EditGoal, "markdown-preview", data)
+}
+
+// previewMaxBytes is enough for descriptions of goals.MaxDescriptionChars
+// characters, URL-encoded.
+const previewMaxBytes = 64 << 10
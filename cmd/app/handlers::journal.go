@@ -0,0 +1,224 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bit8bytes/goalkeepr/internal/journal"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// journalForm reads a journal entry from the posted form.
+func journalForm(r *http.Request) *journal.Form {
+	confidence, _ := strconv.Atoi(r.PostForm.Get("confidence"))
+
+	form := &journal.Form{
+		Body:       sanitize.Text(r.PostForm.Get("body")),
+		Mood:       sanitize.Text(r.PostForm.Get("mood")),
+		Confidence: confidence,
+	}
+	form.Validate()
+
+	return form
+}
+
+func (app *app) postAddJournalEntry(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := journalForm(r)
+
+	if !form.Valid() {
+		if r.Header.Get("HX-Request") == "true" {
+			// htmx does not swap error responses, so the errors are sent
+			// with 200 and swapped into the error list of the form.
+			w.Header().Set("HX-Retarget", "#journal-errors")
+			w.Header().Set("HX-Reswap", "innerHTML")
+			app.renderPartial(w, r, http.StatusOK, page.EditGoal, "journal-errors", form)
+			return
+		}
+
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.NewJournalEntry.Form = form
+		})
+		return
+	}
+
+	if _, err := app.services.goals.Get(r.Context(), goalID, getUserID(r)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	entry, err := app.services.journal.Add(r.Context(), goalID, getUserID(r), form)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your journal entry.")
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		app.renderPartial(w, r, http.StatusCreated, page.EditGoal, "journal-entry", entry.ToView())
+		return
+	}
+
+	app.putFlash(r.Context(), "Journal entry added!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+// journalEntry returns the entry from the request path. It renders the not
+// found page and returns false if the entry does not exist or does not
+// belong to the goal in the path.
+func (app *app) journalEntry(w http.ResponseWriter, r *http.Request) (journal.JournalEntry, bool) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return journal.JournalEntry{}, false
+	}
+
+	entryID, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid journal entry ID.")
+		return journal.JournalEntry{}, false
+	}
+
+	entry, err := app.services.journal.Get(r.Context(), entryID, getUserID(r))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && entry.GoalID != int64(goalID)) {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return journal.JournalEntry{}, false
+	}
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your journal entry.")
+		return journal.JournalEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (app *app) getJournalEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := app.journalEntry(w, r)
+	if !ok {
+		return
+	}
+
+	app.renderPartial(w, r, http.StatusOK, page.EditGoal, "journal-entry", entry.ToView())
+}
+
+func (app *app) getEditJournalEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := app.journalEntry(w, r)
+	if !ok {
+		return
+	}
+
+	view := entry.ToView()
+	app.renderPartial(w, r, http.StatusOK, page.EditGoal, "journal-entry-form", JournalEntryData{
+		Entry: view,
+		Form: &journal.Form{
+			Body:       view.Body,
+			Mood:       string(view.Mood),
+			Confidence: view.Confidence,
+		},
+		Moods: journal.Moods(),
+	})
+}
+
+func (app *app) putJournalEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := app.journalEntry(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := journalForm(r)
+
+	if !form.Valid() {
+		// Sent with 200 so that htmx swaps the form with its errors in.
+		app.renderPartial(w, r, http.StatusOK, page.EditGoal, "journal-entry-form", JournalEntryData{
+			Entry: entry.ToView(),
+			Form:  form,
+			Moods: journal.Moods(),
+		})
+		return
+	}
+
+	updated, err := app.services.journal.Update(r.Context(), int(entry.ID), getUserID(r), form)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your journal entry.")
+		return
+	}
+
+	app.renderPartial(w, r, http.StatusOK, page.EditGoal, "journal-entry", updated.ToView())
+}
+
+func (app *app) deleteJournalEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := app.journalEntry(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := app.services.journal.Delete(r.Context(), int(entry.ID), getUserID(r)); err != nil {
+		app.renderError(w, r, err, "Error deleting your journal entry.")
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	app.putFlash(r.Context(), "Journal entry deleted.")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", entry.GoalID), http.StatusSeeOther)
+}
+
+func (app *app) postJournalPublic(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	public := r.PostForm.Get("journal_public") == "1"
+	rowsAffected, err := app.services.goals.SetJournalPublic(r.Context(), goalID, getUserID(r), public)
+	if err != nil {
+		app.renderError(w, r, err, "Error updating your goal.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bit8bytes/goalkeepr/internal/key_results"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+func (app *app) postAddKeyResult(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &key_results.Form{
+		Description: sanitize.Text(r.PostForm.Get("description")),
+		Unit:        sanitize.Text(r.PostForm.Get("unit")),
+	}
+	form.StartValue = parseNumber(&form.Validator, r.PostForm.Get("start_value"), "start_value")
+	form.TargetValue = parseNumber(&form.Validator, r.PostForm.Get("target_value"), "target_value")
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.KeyResultForm = form
+		})
+		return
+	}
+
+	// The goal has to exist and belong to the user.
+	if _, err := app.services.goals.Get(r.Context(), goalID, getUserID(r)); err != nil {
+		if err == sql.ErrNoRows {
+			data := app.newTemplateData(r)
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	if err := app.services.keyResults.Add(r.Context(), goalID, getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error saving key result.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Key result added!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postCheckIn(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	keyResultID, err := strconv.Atoi(r.PathValue("keyResultId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid key result ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &key_results.CheckInForm{
+		KeyResultID: keyResultID,
+		Note:        sanitize.Text(r.PostForm.Get("note")),
+	}
+	form.Value = parseNumber(&form.Validator, r.PostForm.Get("value"), "value")
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderEditGoalWith(w, r, goalID, func(d *EditGoalPageData) {
+			d.CheckInForm = form
+		})
+		return
+	}
+
+	rowsAffected, err := app.services.keyResults.CheckIn(r.Context(), keyResultID, getUserID(r), form)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your check-in.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Check-in saved!")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+func (app *app) postDeleteKeyResult(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	keyResultID, err := strconv.Atoi(r.PathValue("keyResultId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid key result ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.keyResults.Delete(r.Context(), keyResultID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting key result.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Key result deleted.")
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+// renderEditGoalWith renders the edit page of goalID with a 422 status after
+// set has put an invalid form into the page data.
+func (app *app) renderEditGoalWith(w http.ResponseWriter, r *http.Request, goalID int, set func(*EditGoalPageData)) {
+	data := app.newTemplateData(r)
+
+	goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			app.render(w, r, http.StatusNotFound, page.NotFound, data)
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	pageData, err := app.editGoalPageData(r, goal.ToView())
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your goal.")
+		return
+	}
+	set(&pageData)
+
+	data.Form = newEditGoalForm(goal)
+	data.Data = pageData
+	app.render(w, r, http.StatusUnprocessableEntity, page.EditGoal, data)
+}
+
+// parseNumber parses a number from a form field. An invalid number is
+// recorded as an error on key and parsed as 0.
+func parseNumber(v *validator.Validator, raw, key string) float64 {
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		v.AddError(key, "Enter a number")
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/preferences"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) getOverview(w http.ResponseWriter, r *http.Request) {
+	prefs, err := app.services.preferences.GetByUserID(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your settings.")
+		return
+	}
+
+	now := time.Now()
+	overview, err := app.assessGoals(r.Context(), getUserID(r), prefs.ToView(), now)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your goals.")
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = OverviewPageData{
+		Overview:   overview,
+		Thresholds: prefs.ToView(),
+		Now:        now,
+	}
+	app.render(w, r, http.StatusOK, page.Overview, data)
+}
+
+// assessGoals sorts the open goals of a user into overdue, due this week and
+// at risk, using the risk thresholds of the user.
+func (app *app) assessGoals(ctx context.Context, userID int, prefs preferences.View, now time.Time) (goals.Overview, error) {
+	thresholds := goals.Thresholds{
+		Days:     prefs.RiskDays,
+		Progress: prefs.RiskProgress,
+	}
+
+	goalList, err := app.services.goals.GetAllNeedingAttention(ctx, userID, now, thresholds)
+	if err != nil {
+		return goals.Overview{}, err
+	}
+
+	counts, err := app.services.successCriteria.GetCountsByUser(ctx, userID)
+	if err != nil {
+		return goals.Overview{}, err
+	}
+
+	goalViews := make([]goals.View, len(goalList))
+	for i, goal := range goalList {
+		goalViews[i] = goal.ToView()
+		goalViews[i].TotalCriteriaCount = counts[goal.ID].Total
+		goalViews[i].CompletedCriteriaCount = counts[goal.ID].Completed
+	}
+
+	return goals.Assess(goalViews, now, thresholds), nil
+}
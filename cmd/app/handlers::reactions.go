@@ -0,0 +1,108 @@
+package main
+
+import (
+	"crypto/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/reactions"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// viewerCookieAge is how long a viewer is recognized after their last
+// reaction.
+const viewerCookieAge = 365 * 24 * time.Hour
+
+func (app *app) postShareReaction(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), publicID)
+	if err != nil {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+	userID := int(shareLink.UserID)
+
+	goalID, err := strconv.Atoi(r.PathValue("goalId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	reaction := r.PostForm.Get("reaction")
+	if !reactions.Valid(reaction) {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	shared, err := app.isSharedGoal(r.Context(), shareLink, goalID)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your reaction.")
+		return
+	}
+	if !shared {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	viewerID, ok := app.viewerID(r)
+	if !ok {
+		viewerID = rand.Text()
+	}
+	// Setting the cookie on every reaction keeps regular viewers recognized.
+	http.SetCookie(w, &http.Cookie{
+		Name:     ViewerCookie,
+		Value:    app.viewerSigner.Sign(viewerID),
+		Path:     "/s/",
+		MaxAge:   int(viewerCookieAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if _, err := app.services.reactions.Toggle(r.Context(), goalID, userID, viewerID, reaction); err != nil {
+		app.renderError(w, r, err, "Error saving your reaction.")
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/s/"+publicID, http.StatusSeeOther)
+		return
+	}
+
+	counts, err := app.services.reactions.GetCountsByGoals(r.Context(), userID, []int64{int64(goalID)})
+	if err != nil {
+		app.renderError(w, r, err, "Error loading reactions.")
+		return
+	}
+
+	mine, err := app.services.reactions.GetByViewer(r.Context(), userID, viewerID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading reactions.")
+		return
+	}
+
+	app.renderPartial(w, r, http.StatusOK, page.Share, "goal-reactions", ReactionBoxData{
+		Path:      "/s/" + publicID,
+		GoalID:    int64(goalID),
+		Reactions: reactions.Buttons(counts[int64(goalID)], mine[int64(goalID)]),
+	})
+}
+
+// viewerID returns the ID in the viewer cookie of the request and whether
+// there is one with a valid signature.
+func (app *app) viewerID(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(ViewerCookie)
+	if err != nil {
+		return "", false
+	}
+
+	return app.viewerSigner.Verify(cookie.Value)
+}
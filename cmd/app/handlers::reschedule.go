@@ -0,0 +1,153 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// getReschedule shows the reschedule form of a goal. Once days or a date are
+// given, it previews the goals that would move. htmx requests only get the
+// preview.
+func (app *app) getReschedule(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goal.")
+		return
+	}
+
+	view := goal.ToView()
+	form := rescheduleForm(r.URL.Query())
+	pageData := ReschedulePageData{Goal: view}
+
+	if form.Days != 0 || form.Date != "" {
+		form.Validate(view.Due)
+		if form.Valid() {
+			pageData.Shifts, err = app.services.goals.PreviewReschedule(r.Context(), goalID, getUserID(r), form)
+			if err != nil {
+				app.renderError(w, r, err, "Error previewing the new schedule.")
+				return
+			}
+		}
+	}
+
+	data := app.newTemplateData(r)
+	data.Form = form
+	data.Data = pageData
+
+	if r.Header.Get("HX-Request") == "true" {
+		app.renderPartial(w, r, http.StatusOK, page.Reschedule, "reschedule-preview", data)
+		return
+	}
+
+	app.render(w, r, http.StatusOK, page.Reschedule, data)
+}
+
+func (app *app) postReschedule(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	goal, err := app.services.goals.Get(r.Context(), goalID, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Couldn't get your goal.")
+		return
+	}
+
+	view := goal.ToView()
+	form := rescheduleForm(r.PostForm)
+	form.Validate(view.Due)
+
+	if !form.Valid() {
+		data := app.newTemplateData(r)
+		data.Form = form
+		data.Data = ReschedulePageData{Goal: view}
+		app.render(w, r, http.StatusUnprocessableEntity, page.Reschedule, data)
+		return
+	}
+
+	rescheduleID, moved, err := app.services.goals.Reschedule(r.Context(), goalID, getUserID(r), form)
+	if err != nil {
+		app.renderError(w, r, err, "Error rescheduling your goals.")
+		return
+	}
+
+	days := form.Offset(view.Due)
+	msg := fmt.Sprintf("%s moved %s later.", plural(moved, "goal", "goals"), plural(days, "day", "days"))
+	if days < 0 {
+		msg = fmt.Sprintf("%s moved %s earlier.", plural(moved, "goal", "goals"), plural(-days, "day", "days"))
+	}
+
+	app.putUndoFlash(r.Context(), msg, fmt.Sprintf("/goals/%d/reschedule/%d/undo", goalID, rescheduleID))
+	http.Redirect(w, r, "/goals", http.StatusSeeOther)
+}
+
+func (app *app) postUndoReschedule(w http.ResponseWriter, r *http.Request) {
+	goalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid goal ID.")
+		return
+	}
+
+	rescheduleID, err := strconv.Atoi(r.PathValue("rescheduleId"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid reschedule ID.")
+		return
+	}
+
+	moved, skipped, err := app.services.goals.UndoReschedule(r.Context(), rescheduleID, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Error undoing the reschedule.")
+		return
+	}
+
+	msg := fmt.Sprintf("%s moved back.", plural(moved, "goal", "goals"))
+	if skipped > 0 {
+		msg += fmt.Sprintf(" %s changed since and kept the new due date.", plural(skipped, "goal was", "goals were"))
+	}
+	app.putFlash(r.Context(), msg)
+	http.Redirect(w, r, fmt.Sprintf("/goals/%d", goalID), http.StatusSeeOther)
+}
+
+// rescheduleForm reads a reschedule form from the query or the posted form.
+func rescheduleForm(values url.Values) *goals.RescheduleForm {
+	days, _ := strconv.Atoi(values.Get("days"))
+
+	return &goals.RescheduleForm{
+		Days:         days,
+		Date:         sanitize.Date(values.Get("date")),
+		SkipAchieved: values.Get("skip_achieved") == "on",
+	}
+}
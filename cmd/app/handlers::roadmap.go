@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/roadmap"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) getRoadmap(w http.ResponseWriter, r *http.Request) {
+	goalList, err := app.services.goals.GetAll(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Couldn't get your goals.")
+		return
+	}
+
+	items := make([]roadmap.Item, 0, len(goalList))
+	for _, g := range goalList {
+		view := g.ToView()
+		if view.Due.IsZero() {
+			continue
+		}
+
+		items = append(items, roadmap.Item{
+			ID:     view.ID,
+			Title:  view.Goal,
+			Status: string(view.Status),
+			Start:  view.Start,
+			End:    view.Due,
+		})
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = RoadmapPageData{Roadmap: roadmap.New(items, time.Now())}
+	app.render(w, r, http.StatusOK, page.Roadmap, data)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) getSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	results, err := app.services.search.Search(r.Context(), getUserID(r), query)
+	if err != nil {
+		app.renderError(w, r, err, "Error searching your goals.")
+		return
+	}
+
+	pageData := SearchPageData{Query: query, Results: results}
+
+	// The search box in the header only needs the results.
+	if r.Header.Get("HX-Request") == "true" {
+		app.renderPartial(w, r, http.StatusOK, page.Search, "search-results", pageData)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = pageData
+	app.render(w, r, http.StatusOK, page.Search, data)
+}
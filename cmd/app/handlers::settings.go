@@ -4,37 +4,62 @@ import (
 	"database/sql"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/bit8bytes/goalkeepr/internal/branding"
+	"github.com/bit8bytes/goalkeepr/internal/preferences"
 	"github.com/bit8bytes/goalkeepr/internal/sanitize"
 	"github.com/bit8bytes/goalkeepr/internal/users"
 	"github.com/bit8bytes/goalkeepr/ui/page"
 )
 
 func (app *app) getSettings(w http.ResponseWriter, r *http.Request) {
+	forms, err := app.settingsForms(r)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading user settings.")
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Form = forms
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, http.StatusOK, page.Settings, data)
+}
+
+// settingsForms returns the forms shown on the settings page, filled with the
+// user's current settings.
+func (app *app) settingsForms(r *http.Request) (map[string]any, error) {
 	userID := getUserID(r)
 
 	user, err := app.services.users.GetByID(r.Context(), userID)
 	if err != nil {
-		app.renderError(w, r, err, "Error loading user settings.")
-		return
+		return nil, err
 	}
 
-	branding, err := app.services.branding.GetByUserID(r.Context(), userID)
+	b, err := app.services.branding.GetByUserID(r.Context(), userID)
 	if err != nil && err != sql.ErrNoRows {
-		app.renderError(w, r, err, "Error loading branding settings.")
-		return
+		return nil, err
 	}
 
-	forms := map[string]any{
-		"Account":  users.UpdateUserForm{Email: user.ToView().Email},
-		"Branding": branding.ToView(),
+	prefs, err := app.services.preferences.GetByUserID(r.Context(), userID)
+	if err != nil {
+		return nil, err
 	}
 
-	data := app.newTemplateData(r)
-	data.Form = forms
-	data.Flash = app.flash(r.Context())
-	app.render(w, r, http.StatusOK, page.Settings, data)
+	return map[string]any{
+		"Account": users.UpdateUserForm{Email: user.ToView().Email},
+		"Branding": &branding.Form{
+			Title:       b.ToView().Title,
+			Description: b.ToView().Description,
+		},
+		"Preferences": &preferences.Form{AutoArchiveDays: prefs.ToView().AutoArchiveDays},
+		"Risk": &preferences.RiskForm{
+			RiskDays:     prefs.ToView().RiskDays,
+			RiskProgress: prefs.ToView().RiskProgress,
+		},
+		"Reminders": &preferences.ReminderForm{ReminderDays: prefs.ToView().ReminderDays},
+		"Digest":    &preferences.DigestForm{DigestDay: prefs.ToView().DigestDay},
+	}, nil
 }
 
 func (app *app) postBranding(w http.ResponseWriter, r *http.Request) {
@@ -47,10 +72,18 @@ func (app *app) postBranding(w http.ResponseWriter, r *http.Request) {
 		Title:       sanitize.Text(r.PostForm.Get("title")),
 		Description: sanitize.Text(r.PostForm.Get("description")),
 	}
+	form.Validate()
 
 	if !form.Valid() {
+		forms, err := app.settingsForms(r)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading user settings.")
+			return
+		}
+		forms["Branding"] = form
+
 		data := app.newTemplateData(r)
-		data.Form = form
+		data.Form = forms
 		app.render(w, r, http.StatusUnprocessableEntity, page.Settings, data)
 		return
 	}
@@ -64,6 +97,120 @@ func (app *app) postBranding(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/settings", http.StatusSeeOther)
 }
 
+func (app *app) postArchiveSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	days, err := strconv.Atoi(r.PostForm.Get("auto_archive_days"))
+	if err != nil && r.PostForm.Get("auto_archive_days") != "" {
+		app.renderError(w, r, err, "Invalid number of days.")
+		return
+	}
+
+	form := &preferences.Form{AutoArchiveDays: days}
+	form.Validate()
+
+	if !form.Valid() {
+		forms, err := app.settingsForms(r)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading user settings.")
+			return
+		}
+		forms["Preferences"] = form
+
+		data := app.newTemplateData(r)
+		data.Form = forms
+		app.render(w, r, http.StatusUnprocessableEntity, page.Settings, data)
+		return
+	}
+
+	if err := app.services.preferences.SetAutoArchiveDays(r.Context(), getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error updating archive settings.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Archive settings saved")
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
+func (app *app) postRiskSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	days, err := strconv.Atoi(r.PostForm.Get("risk_days"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid number of days.")
+		return
+	}
+
+	progress, err := strconv.Atoi(r.PostForm.Get("risk_progress"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid progress.")
+		return
+	}
+
+	form := &preferences.RiskForm{RiskDays: days, RiskProgress: progress}
+	form.Validate()
+
+	if !form.Valid() {
+		forms, err := app.settingsForms(r)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading user settings.")
+			return
+		}
+		forms["Risk"] = form
+
+		data := app.newTemplateData(r)
+		data.Form = forms
+		app.render(w, r, http.StatusUnprocessableEntity, page.Settings, data)
+		return
+	}
+
+	if err := app.services.preferences.SetRiskThresholds(r.Context(), getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error updating risk settings.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Risk settings saved")
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
+func (app *app) postReminderSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &preferences.ReminderForm{ReminderDays: r.PostForm.Get("reminder_days")}
+	form.Validate()
+
+	if !form.Valid() {
+		forms, err := app.settingsForms(r)
+		if err != nil {
+			app.renderError(w, r, err, "Error loading user settings.")
+			return
+		}
+		forms["Reminders"] = form
+
+		data := app.newTemplateData(r)
+		data.Form = forms
+		app.render(w, r, http.StatusUnprocessableEntity, page.Settings, data)
+		return
+	}
+
+	if err := app.services.preferences.SetReminderDays(r.Context(), getUserID(r), form); err != nil {
+		app.renderError(w, r, err, "Error updating reminder settings.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Reminder settings saved")
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
 func (app *app) deleteUser(w http.ResponseWriter, r *http.Request) {
 	if err := app.services.users.DeleteByID(r.Context(), getUserID(r)); err != nil {
 		app.renderError(w, r, err, "Error deleting your account.")
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/internal/share"
+	"github.com/bit8bytes/goalkeepr/internal/subscriptions"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+// postShareSubscribe subscribes a viewer to the updates of a share link and
+// emails them the link that confirms it.
+func (app *app) postShareSubscribe(w http.ResponseWriter, r *http.Request) {
+	publicID := r.PathValue("id")
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), publicID)
+	if err != nil {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	// Honeypot for bot protection
+	if sanitize.Text(r.PostForm.Get("website")) != "" {
+		time.Sleep(3 * time.Second)
+		return
+	}
+
+	form := &subscriptions.Form{Email: sanitize.Text(r.PostForm.Get("email"))}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderShare(w, r, http.StatusUnprocessableEntity, nil, form)
+		return
+	}
+
+	sub, err := app.services.subscriptions.Subscribe(r.Context(), shareLink.ID, form)
+	if err != nil {
+		app.renderError(w, r, err, "Error saving your subscription.")
+		return
+	}
+
+	// Confirmed subscribers are told the same, so the form doesn't reveal
+	// who is subscribed.
+	if !sub.Confirmed {
+		if err := app.sendShareConfirmation(r.Context(), shareLink.UserID, publicID, sub); err != nil {
+			app.renderError(w, r, err, "Error sending the confirmation email.")
+			return
+		}
+	}
+
+	app.putFlash(r.Context(), "Check your inbox to confirm your subscription.")
+	http.Redirect(w, r, "/s/"+publicID, http.StatusSeeOther)
+}
+
+func (app *app) getConfirmSubscription(w http.ResponseWriter, r *http.Request) {
+	app.renderSubscription(w, r, true, false)
+}
+
+func (app *app) postConfirmSubscription(w http.ResponseWriter, r *http.Request) {
+	app.renderSubscription(w, r, true, true)
+}
+
+func (app *app) getShareUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	app.renderSubscription(w, r, false, false)
+}
+
+// postShareUnsubscribe ends a subscription. Mail clients post to it for
+// one-click unsubscribe.
+func (app *app) postShareUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	app.renderSubscription(w, r, false, true)
+}
+
+// renderSubscription renders the page that confirms or ends the subscription
+// in the URL. With submit set, it confirms or ends it first.
+func (app *app) renderSubscription(w http.ResponseWriter, r *http.Request, confirm, submit bool) {
+	shareLink, sub, err := app.shareSubscription(r)
+	if errors.Is(err, sql.ErrNoRows) {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your subscription.")
+		return
+	}
+
+	title, err := app.shareTitle(r.Context(), shareLink.UserID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your subscription.")
+		return
+	}
+
+	done := confirm && sub.Confirmed
+	if submit {
+		if confirm {
+			err = app.services.subscriptions.Confirm(r.Context(), sub.ID, time.Now())
+		} else {
+			err = app.services.subscriptions.Unsubscribe(r.Context(), sub.ID)
+		}
+		if err != nil {
+			app.renderError(w, r, err, "Error updating your subscription.")
+			return
+		}
+		done = true
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = SubscriptionPageData{
+		Title:     title,
+		SharePath: "/s/" + shareLink.PublicID,
+		Confirm:   confirm,
+		Done:      done,
+	}
+	app.render(w, r, http.StatusOK, page.Subscription, data)
+}
+
+// shareSubscription returns the share link and the subscription in the URL.
+// It returns sql.ErrNoRows if either doesn't exist.
+func (app *app) shareSubscription(r *http.Request) (share.Share, subscriptions.Subscription, error) {
+	shareLink, err := app.services.share.GetByPublicID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return share.Share{}, subscriptions.Subscription{}, err
+	}
+
+	sub, err := app.services.subscriptions.GetByToken(r.Context(), shareLink.ID, r.PathValue("token"))
+	if err != nil {
+		return share.Share{}, subscriptions.Subscription{}, err
+	}
+
+	return shareLink, sub, nil
+}
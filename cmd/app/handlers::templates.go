@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	successCriteria "github.com/bit8bytes/goalkeepr/internal/success_criteria"
+	"github.com/bit8bytes/goalkeepr/internal/templates"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) getTemplates(w http.ResponseWriter, r *http.Request) {
+	app.renderTemplates(w, r, http.StatusOK, &templates.UseForm{}, &templates.SaveForm{})
+}
+
+// renderTemplates renders the templates page with the forms to use and save
+// templates.
+func (app *app) renderTemplates(w http.ResponseWriter, r *http.Request, status int, use *templates.UseForm, save *templates.SaveForm) {
+	templateList, err := app.services.templates.GetAll(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your templates.")
+		return
+	}
+
+	goalList, err := app.services.goals.GetAll(r.Context(), getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error loading your goals.")
+		return
+	}
+
+	goalViews := make([]goals.View, len(goalList))
+	for i, g := range goalList {
+		goalViews[i] = g.ToView()
+	}
+
+	data := app.newTemplateData(r)
+	data.Data = TemplatesPageData{
+		Templates: templateList,
+		Goals:     goalViews,
+		Use:       use,
+		Save:      save,
+		Today:     time.Now().Format(HTMLDateFormat),
+	}
+	data.Flash = app.flash(r.Context())
+	app.render(w, r, status, page.Templates, data)
+}
+
+func (app *app) postUseTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &templates.UseForm{
+		Template: sanitize.Text(r.PostForm.Get("template")),
+		Start:    sanitize.Date(r.PostForm.Get("start")),
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderTemplates(w, r, http.StatusUnprocessableEntity, form, &templates.SaveForm{})
+		return
+	}
+
+	template, err := app.services.templates.Get(r.Context(), form.Template, getUserID(r))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+			return
+		}
+		app.renderError(w, r, err, "Error loading the template.")
+		return
+	}
+
+	start, _ := time.Parse(HTMLDateFormat, form.Start)
+	planned, err := template.Plan(start)
+	if err != nil {
+		app.renderError(w, r, err, "Error planning the goals of the template.")
+		return
+	}
+
+	goalIDs, err := app.addPlannedGoals(r.Context(), getUserID(r), planned)
+	if err != nil {
+		app.renderError(w, r, err, "Error adding the goals of the template.")
+		return
+	}
+
+	undo := url.Values{"action": {string(goals.BulkDelete)}}
+	for _, id := range goalIDs {
+		undo.Add("id", strconv.Itoa(id))
+	}
+
+	msg := fmt.Sprintf("%s added from %s.", plural(len(goalIDs), "goal", "goals"), template.Name)
+	app.putUndoFlash(r.Context(), msg, "/goals/bulk?"+undo.Encode())
+	http.Redirect(w, r, "/goals", http.StatusSeeOther)
+}
+
+// addPlannedGoals adds the goals of a template together with their success
+// criteria in one transaction and returns the IDs of the new goals.
+func (app *app) addPlannedGoals(ctx context.Context, userID int, planned []templates.Planned) ([]int, error) {
+	goalIDs := make([]int, 0, len(planned))
+	err := database.WithTx(ctx, app.db, func(tx *sql.Tx) error {
+		for _, p := range planned {
+			form := &goals.Form{
+				Goal:        p.Goal,
+				Description: p.Description,
+				Due:         p.DueDate.Format(HTMLDateFormat),
+			}
+			if !p.StartDate.IsZero() {
+				form.Start = p.StartDate.Format(HTMLDateFormat)
+			}
+
+			goalID, err := app.services.goals.WithTx(tx).Add(ctx, userID, form)
+			if err != nil {
+				return err
+			}
+
+			for i, description := range p.SuccessCriteria {
+				if err := app.services.successCriteria.WithTx(tx).Add(ctx, goalID, userID, &successCriteria.Form{
+					Description: description,
+					Position:    i + 1,
+				}); err != nil {
+					return err
+				}
+			}
+
+			goalIDs = append(goalIDs, goalID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return goalIDs, nil
+}
+
+func (app *app) postSaveTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	ids := make([]int, 0, len(r.PostForm["id"]))
+	for _, rawID := range r.PostForm["id"] {
+		id, err := strconv.Atoi(rawID)
+		if err != nil {
+			app.renderError(w, r, err, "Invalid goal ID.")
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	form := &templates.SaveForm{
+		Name:        sanitize.Text(r.PostForm.Get("name")),
+		Description: sanitize.Text(r.PostForm.Get("description")),
+		IDs:         ids,
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderTemplates(w, r, http.StatusUnprocessableEntity, &templates.UseForm{}, form)
+		return
+	}
+
+	sources := make([]templates.Source, 0, len(form.IDs))
+	for _, id := range form.IDs {
+		goal, err := app.services.goals.Get(r.Context(), id, getUserID(r))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+				return
+			}
+			app.renderError(w, r, err, "Couldn't get your goals.")
+			return
+		}
+
+		criteria, err := app.services.successCriteria.GetAllByGoal(r.Context(), id, getUserID(r))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			app.renderError(w, r, err, "Error loading success criteria.")
+			return
+		}
+
+		view := goal.ToView()
+		source := templates.Source{
+			Goal:        view.Goal,
+			Description: view.Description,
+			Start:       view.Start,
+			Due:         view.Due,
+		}
+		for _, c := range criteria {
+			source.SuccessCriteria = append(source.SuccessCriteria, c.Description)
+		}
+		sources = append(sources, source)
+	}
+
+	form.CheckSpan(sources)
+	if !form.Valid() {
+		app.renderTemplates(w, r, http.StatusUnprocessableEntity, &templates.UseForm{}, form)
+		return
+	}
+
+	template := templates.FromGoals(form.Name, form.Description, sources)
+	if _, err := app.services.templates.Save(r.Context(), getUserID(r), template); err != nil {
+		app.renderError(w, r, err, "Error saving your template.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Template saved!")
+	http.Redirect(w, r, "/goals/templates", http.StatusSeeOther)
+}
+
+func (app *app) postDeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	templateID, err := strconv.Atoi(r.PostForm.Get("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid template ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.templates.Delete(r.Context(), templateID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting your template.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		app.render(w, r, http.StatusNotFound, page.NotFound, app.newTemplateData(r))
+		return
+	}
+
+	app.putFlash(r.Context(), "Template deleted.")
+	http.Redirect(w, r, "/goals/templates", http.StatusSeeOther)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bit8bytes/goalkeepr/internal/sanitize"
+	"github.com/bit8bytes/goalkeepr/internal/webhooks"
+	"github.com/bit8bytes/goalkeepr/ui/page"
+)
+
+func (app *app) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	// New webhooks receive all events unless some are unchecked.
+	app.renderWebhooks(w, r, http.StatusOK, &webhooks.Form{Events: webhooks.Events})
+}
+
+// renderWebhooks renders the webhooks page with form as the form that adds a
+// webhook.
+func (app *app) renderWebhooks(w http.ResponseWriter, r *http.Request, status int, form *webhooks.Form) {
+	userID := getUserID(r)
+
+	hooks, err := app.services.webhooks.GetAll(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading webhooks.")
+		return
+	}
+
+	deliveries, err := app.services.webhooks.GetRecentDeliveries(r.Context(), userID)
+	if err != nil {
+		app.renderError(w, r, err, "Error loading webhooks.")
+		return
+	}
+
+	pageData := WebhooksPageData{
+		Events: webhooks.Events,
+		Form:   form,
+	}
+	for _, hook := range hooks {
+		pageData.Webhooks = append(pageData.Webhooks, hook.ToView())
+	}
+	for _, d := range deliveries {
+		pageData.Deliveries = append(pageData.Deliveries, d.ToView())
+	}
+
+	data := app.newTemplateData(r)
+	data.Flash = app.flash(r.Context())
+	data.Data = pageData
+	app.render(w, r, status, page.Webhooks, data)
+}
+
+func (app *app) postWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	form := &webhooks.Form{
+		URL:    sanitize.Text(r.PostForm.Get("url")),
+		Events: r.PostForm["events"],
+	}
+	form.Validate()
+
+	if !form.Valid() {
+		app.renderWebhooks(w, r, http.StatusUnprocessableEntity, form)
+		return
+	}
+
+	_, err := app.services.webhooks.Add(r.Context(), getUserID(r), form)
+	if errors.Is(err, webhooks.ErrTooManyWebhooks) {
+		form.AddError("url", fmt.Sprintf("You can add up to %d webhooks. Delete one first.", webhooks.MaxWebhooks))
+		app.renderWebhooks(w, r, http.StatusUnprocessableEntity, form)
+		return
+	}
+	if err != nil {
+		app.renderError(w, r, err, "Error saving webhook.")
+		return
+	}
+
+	app.putFlash(r.Context(), "Webhook added!")
+	http.Redirect(w, r, "/settings/webhooks", http.StatusSeeOther)
+}
+
+func (app *app) postDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	webhookID, err := strconv.Atoi(r.PostForm.Get("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid webhook ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.webhooks.Delete(r.Context(), webhookID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error deleting webhook.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Webhook deleted.")
+	http.Redirect(w, r, "/settings/webhooks", http.StatusSeeOther)
+}
+
+// postRedeliverWebhook queues the event of a delivery again, so it is sent
+// with the next deliveries.
+func (app *app) postRedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderError(w, r, err, "Error processing form data.")
+		return
+	}
+
+	deliveryID, err := strconv.Atoi(r.PostForm.Get("id"))
+	if err != nil {
+		app.renderError(w, r, err, "Invalid delivery ID.")
+		return
+	}
+
+	rowsAffected, err := app.services.webhooks.Redeliver(r.Context(), deliveryID, getUserID(r))
+	if err != nil {
+		app.renderError(w, r, err, "Error queueing the delivery.")
+		return
+	}
+
+	if rowsAffected == 0 {
+		data := app.newTemplateData(r)
+		app.render(w, r, http.StatusNotFound, page.NotFound, data)
+		return
+	}
+
+	app.putFlash(r.Context(), "Delivery queued.")
+	http.Redirect(w, r, "/settings/webhooks", http.StatusSeeOther)
+}
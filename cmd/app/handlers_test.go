@@ -1,11 +1,27 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/digest"
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/webhooks"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
 )
 
 func TestPublicPages(t *testing.T) {
@@ -78,11 +94,61 @@ func TestAuthRequiredPages(t *testing.T) {
 			urlPath:  "/goals/share/",
 			wantCode: http.StatusSeeOther,
 		},
+		{
+			name:     "goals trash page redirects to signin",
+			urlPath:  "/goals/trash",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals archive page redirects to signin",
+			urlPath:  "/goals/archive",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals roadmap page redirects to signin",
+			urlPath:  "/goals/roadmap",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals search page redirects to signin",
+			urlPath:  "/goals/search",
+			wantCode: http.StatusSeeOther,
+		},
 		{
 			name:     "goals detail page redirects to signin",
 			urlPath:  "/goals/1",
 			wantCode: http.StatusSeeOther,
 		},
+		{
+			name:     "goals reschedule page redirects to signin",
+			urlPath:  "/goals/1/reschedule",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals duplicate page redirects to signin",
+			urlPath:  "/goals/1/duplicate",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goal attachment redirects to signin",
+			urlPath:  "/goals/1/attachments/1",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals templates page redirects to signin",
+			urlPath:  "/goals/templates",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals comments page redirects to signin",
+			urlPath:  "/goals/comments",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "goals overview page redirects to signin",
+			urlPath:  "/goals/overview",
+			wantCode: http.StatusSeeOther,
+		},
 		{
 			name:     "settings page redirects to signin",
 			urlPath:  "/settings",
@@ -355,3 +421,2886 @@ func TestSigninHandler(t *testing.T) {
 		assert.Contains(t, body, "This field cannot be blank")
 	})
 }
+
+func TestGoalDependencies(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "deps@example.com", "12345678", "12345678")
+
+	design := ts.addGoal(t, "Finish design", "2026-03-01")
+	launch := ts.addGoal(t, "Launch", "2026-02-01")
+
+	t.Run("adding a prerequisite due later shows a warning", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("depends_on", strconv.Itoa(design))
+
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/dependencies", launch), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Due before")
+
+		_, _, body = ts.get(t, fmt.Sprintf("/goals/%d", launch))
+		assert.Contains(t, body, "Due after this goal")
+	})
+
+	t.Run("cycles are rejected", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("depends_on", strconv.Itoa(launch))
+
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/dependencies", design), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", design))
+		assert.Contains(t, body, "This dependency would create a cycle.")
+		assert.NotContains(t, body, fmt.Sprintf(`action="/goals/%d/dependencies/%d/delete"`, design, launch))
+	})
+
+	t.Run("moving a prerequisite shifts its dependents", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("goal", "Finish design")
+		form.Add("due", "2026-03-11")
+		form.Add("shift_dependents", "on")
+
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", design), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", launch))
+		assert.Contains(t, body, `value="2026-02-11"`)
+	})
+}
+
+func TestRecurringGoals(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "repeat@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Quarterly review")
+	form.Add("due", "2026-01-15")
+	form.Add("repeat", "MONTHLY")
+	form.Add("repeat_interval", "3")
+	form.Add("repeat_count", "4")
+
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalPath := headers.Get("Location")
+
+	criteria := url.Values{}
+	criteria.Add("new_criterion", "Review budget")
+	code, _, _ = ts.postForm(t, goalPath+"/criteria/update", criteria)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	t.Run("timeline shows upcoming occurrences", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Every 3 months, 4 times")
+		assert.Contains(t, body, "April 15, 2026")
+		assert.Contains(t, body, "Upcoming")
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		invalid := url.Values{}
+		invalid.Add("goal", "Quarterly review")
+		invalid.Add("due", "2026-01-15")
+		invalid.Add("repeat", "HOURLY")
+
+		code, _, body := ts.postForm(t, goalPath, invalid)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a valid repetition")
+	})
+
+	t.Run("achieving creates the next occurrence", func(t *testing.T) {
+		id, err := strconv.Atoi(goalPath[len("/goals/"):])
+		assert.NoError(t, err)
+
+		criteriaList, err := app.services.successCriteria.GetAllByGoal(context.Background(), id, 1)
+		assert.NoError(t, err)
+		assert.Len(t, criteriaList, 1)
+		checked := url.Values{}
+		checked.Add(fmt.Sprintf("criteria_%d", criteriaList[0].ID), "on")
+		code, _, _ := ts.postForm(t, goalPath+"/criteria/update", checked)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		form.Set("status", "achieved")
+
+		code, headers, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, headers.Get("Location"))
+		assert.Contains(t, body, "The next occurrence has been added")
+		assert.Regexp(t, fmt.Sprintf(`name="criteria_%d"\s+class="checkbox checkbox-sm"\s+checked`, criteriaList[0].ID), body)
+
+		goal, err := app.services.goals.Get(context.Background(), id, 1)
+		assert.NoError(t, err)
+		assert.True(t, goal.NextOccurrenceID.Valid)
+		next := int(goal.NextOccurrenceID.Int64)
+
+		nextCriteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), next, 1)
+		assert.NoError(t, err)
+		assert.Len(t, nextCriteria, 1)
+		assert.Equal(t, "Review budget", nextCriteria[0].Description)
+
+		_, _, body = ts.get(t, fmt.Sprintf("/goals/%d", next))
+		assert.Contains(t, body, `value="2026-04-15"`)
+		assert.Contains(t, body, `value="3"`)
+		// The copied criterion starts out open again.
+		assert.Regexp(t, fmt.Sprintf(`name="criteria_%d"\s+class="checkbox checkbox-sm"\s+/>`, nextCriteria[0].ID), body)
+	})
+
+	t.Run("achieving again does not duplicate the next occurrence", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, headers.Get("Location"))
+		assert.Contains(t, body, "Goal saved!")
+		assert.NotContains(t, body, "The next occurrence has been added")
+	})
+}
+
+func TestGoalStatus(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "status@example.com", "12345678", "12345678")
+
+	goalPath := fmt.Sprintf("/goals/%d", ts.addGoal(t, "Run a marathon", "2026-09-01"))
+
+	form := url.Values{}
+	form.Add("goal", "Run a marathon")
+	form.Add("due", "2026-09-01")
+
+	t.Run("new goals are not started", func(t *testing.T) {
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, `<option value="not_started" selected>Not started</option>`)
+		assert.NotContains(t, body, "Status History")
+	})
+
+	t.Run("unknown statuses are rejected", func(t *testing.T) {
+		form.Set("status", "done")
+
+		code, _, body := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a valid status")
+	})
+
+	t.Run("transitions are recorded with their note", func(t *testing.T) {
+		form.Set("status", "at_risk")
+		form.Set("status_note", "Knee injury")
+
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, `<option value="at_risk" selected>At risk</option>`)
+		assert.Contains(t, body, "Not started &rarr; At risk")
+		assert.Contains(t, body, "Knee injury")
+
+		_, _, body = ts.get(t, "/goals")
+		assert.Contains(t, body, "border-warning")
+		assert.Contains(t, body, "At risk")
+	})
+
+	t.Run("saving without a status keeps the current one", func(t *testing.T) {
+		form.Del("status")
+		form.Del("status_note")
+
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, `<option value="at_risk" selected>At risk</option>`)
+		assert.Equal(t, 1, strings.Count(body, "&rarr;"))
+	})
+
+	t.Run("achieved goals record when they were achieved", func(t *testing.T) {
+		form.Set("status", "achieved")
+
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, fmt.Sprintf(`value="%s"`, time.Now().UTC().Format(HTMLDateFormat)))
+
+		form.Set("achieved_at", "2026-08-20")
+		code, _, _ = ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/goals")
+		assert.Contains(t, body, "Achieved 12 days early")
+
+		form.Set("achieved_at", "2026-09-04")
+		code, _, _ = ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/goals")
+		assert.Contains(t, body, "Achieved 3 days late")
+
+		form.Set("achieved_at", time.Now().AddDate(0, 0, 2).Format(HTMLDateFormat))
+		code, _, body = ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Achieved on cannot be in the future")
+	})
+
+	t.Run("achieved date is cleared when the goal is reopened", func(t *testing.T) {
+		form.Set("status", "in_progress")
+		form.Set("achieved_at", "2026-09-04")
+
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.NotContains(t, body, "days late")
+
+		goal, err := app.services.goals.Get(context.Background(), 1, 1)
+		assert.NoError(t, err)
+		assert.False(t, goal.AchievedAt.Valid)
+	})
+}
+
+func TestGoalRevisions(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "revisions@example.com", "12345678", "12345678")
+
+	goalID := ts.addGoal(t, "Run a marathon", "2026-09-01")
+	goalPath := fmt.Sprintf("/goals/%d", goalID)
+
+	form := url.Values{}
+	form.Add("goal", "Run a half marathon")
+	form.Add("due", "2026-10-01")
+
+	code, _, _ := ts.postForm(t, goalPath, form)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	t.Run("edits are shown as a diff", func(t *testing.T) {
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, "Run a <ins class=\"text-success\">half</ins> marathon")
+		assert.Contains(t, body, "<del class=\"text-error\">September 1, 2026</del>")
+		assert.Contains(t, body, "<ins class=\"text-success\">October 1, 2026</ins>")
+	})
+
+	t.Run("saving without changes adds no revision", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Equal(t, 1, strings.Count(body, "Restore this version"))
+	})
+
+	t.Run("restoring a version", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath+"/revisions/1/restore", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, `value="Run a marathon"`)
+		assert.Contains(t, body, `value="2026-09-01"`)
+		assert.Contains(t, body, "Version restored!")
+		assert.Equal(t, 2, strings.Count(body, "Restore this version"))
+	})
+
+	t.Run("unknown revisions are not found", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath+"/revisions/999/restore", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestTrash(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "trash@example.com", "12345678", "12345678")
+
+	goalID := ts.addGoal(t, "Learn to juggle", "2026-06-01")
+	goalPath := fmt.Sprintf("/goals/%d", goalID)
+
+	t.Run("deleting moves the goal to the trash", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, goalPath+"/delete", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals", headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Goal moved to the trash.")
+		assert.Contains(t, body, fmt.Sprintf(`action="%s/restore"`, goalPath))
+		assert.NotContains(t, body, "Learn to juggle")
+
+		code, _, _ = ts.get(t, goalPath)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		_, _, body = ts.get(t, "/goals/trash")
+		assert.Contains(t, body, "Learn to juggle")
+		assert.Contains(t, body, "deleted permanently after 30 days")
+	})
+
+	t.Run("undo restores the goal", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, goalPath+"/restore", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, goalPath, headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Learn to juggle")
+
+		code, _, _ = ts.postForm(t, goalPath+"/restore", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("success criteria can be restored", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("new_criterion", "Three balls for a minute")
+		code, _, _ := ts.postForm(t, goalPath+"/criteria/update", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		form = url.Values{}
+		form.Add("delete_1", "1")
+		code, _, _ = ts.postForm(t, goalPath+"/criteria/update", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.NotContains(t, body, "Three balls for a minute")
+
+		_, _, body = ts.get(t, "/goals/trash")
+		assert.Contains(t, body, "Three balls for a minute")
+
+		code, _, _ = ts.postForm(t, goalPath+"/criteria/1/restore", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, goalPath)
+		assert.Contains(t, body, "Three balls for a minute")
+	})
+
+	t.Run("only trashed goals can be deleted permanently", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath+"/purge", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = ts.postForm(t, goalPath+"/delete", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, goalPath+"/purge", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals/trash")
+		assert.NotContains(t, body, "Learn to juggle")
+	})
+
+	t.Run("expired items are purged", func(t *testing.T) {
+		id := ts.addGoal(t, "Old goal", "2026-06-01")
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/delete", id), url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		n, err := app.services.goals.PurgeTrash(context.Background(), time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		n, err = app.services.goals.PurgeTrash(context.Background(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestArchive(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "archive@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Run a marathon")
+	form.Add("due", "2025-04-12")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalPath := headers.Get("Location")
+
+	t.Run("archived goals leave the timeline", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, goalPath+"/archive", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals", headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Goal archived.")
+		assert.Contains(t, body, fmt.Sprintf(`action="%s/unarchive"`, goalPath))
+		assert.NotContains(t, body, "Run a marathon")
+
+		_, _, body = ts.get(t, "/goals/archive")
+		assert.Contains(t, body, "Run a marathon")
+		assert.Contains(t, body, "2025")
+
+		_, _, body = ts.get(t, goalPath)
+		assert.Contains(t, body, "This goal is archived")
+	})
+
+	t.Run("share links can include archived goals", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		links, err := app.services.share.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Len(t, links, 1)
+		sharePath := "/s/" + links[0].PublicID
+
+		_, _, body := ts.get(t, sharePath)
+		assert.NotContains(t, body, "Run a marathon")
+
+		form := url.Values{}
+		form.Add("include_archived", "1")
+		code, _, _ = ts.htmx(t, http.MethodPatch, fmt.Sprintf("/goals/share/%d", links[0].ID), form)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		_, _, body = ts.get(t, sharePath)
+		assert.Contains(t, body, "Run a marathon")
+	})
+
+	t.Run("unarchive moves the goal back", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, goalPath+"/unarchive", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, goalPath, headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Run a marathon")
+
+		_, _, body = ts.get(t, "/goals/archive")
+		assert.Contains(t, body, "No archived goals.")
+
+		code, _, _ = ts.postForm(t, goalPath+"/unarchive", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("achieved goals are archived automatically", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("auto_archive_days", "-1")
+		code, _, body := ts.postForm(t, "/settings/archive", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Days cannot be negative")
+
+		form.Set("auto_archive_days", "7")
+		code, _, _ = ts.postForm(t, "/settings/archive", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/settings")
+		assert.Contains(t, body, `value="7"`)
+
+		form = url.Values{}
+		form.Add("goal", "Run a marathon")
+		form.Add("due", "2025-04-12")
+		form.Add("status", "achieved")
+		code, _, _ = ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		// Just achieved, so it stays on the timeline for now.
+		assert.NoError(t, app.autoArchive(context.Background()))
+		_, _, body = ts.get(t, "/goals")
+		assert.Contains(t, body, "Run a marathon")
+
+		n, err := app.services.goals.AutoArchive(context.Background(), 1, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		_, _, body = ts.get(t, "/goals/archive")
+		assert.Contains(t, body, "Run a marathon")
+	})
+}
+
+func TestKeyResults(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "keyresults@example.com", "12345678", "12345678")
+
+	goalID := ts.addGoal(t, "Get fit", "2026-12-31")
+	goalPath := fmt.Sprintf("/goals/%d", goalID)
+
+	t.Run("target has to differ from start", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("description", "Run 1000 km")
+		form.Add("start_value", "0")
+		form.Add("target_value", "0")
+		code, _, body := ts.postForm(t, goalPath+"/key-results", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Target must differ from the start value")
+	})
+
+	t.Run("add key result", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("description", "Run 1000 km")
+		form.Add("start_value", "0")
+		form.Add("target_value", "1000")
+		form.Add("unit", "km")
+		code, headers, _ := ts.postForm(t, goalPath+"/key-results", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, goalPath, headers.Get("Location"))
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, "Run 1000 km")
+		assert.Contains(t, body, "0 / 1000 km")
+	})
+
+	t.Run("check-ins update the current value", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("value", "abc")
+		code, _, body := ts.postForm(t, goalPath+"/key-results/1/check-ins", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Enter a number")
+
+		form.Set("value", "250")
+		form.Add("note", "First month done")
+		code, _, _ = ts.postForm(t, goalPath+"/key-results/1/check-ins", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, goalPath)
+		assert.Contains(t, body, "250 / 1000 km")
+		assert.Contains(t, body, "25%")
+		assert.Contains(t, body, "First month done")
+
+		code, _, _ = ts.postForm(t, goalPath+"/key-results/99/check-ins", form)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("progress shows in the timeline tooltip", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("new_criterion", "Buy running shoes")
+		code, _, _ := ts.postForm(t, goalPath+"/criteria/update", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "0 of 1 success criteria achieved, 25% of key results reached")
+	})
+
+	t.Run("delete key result", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath+"/key-results/1/delete", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.NotContains(t, body, "Run 1000 km")
+
+		code, _, _ = ts.postForm(t, goalPath+"/key-results/1/delete", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestJournal(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "journal@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Write a novel")
+	form.Add("due", "2026-11-30")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalPath := headers.Get("Location")
+
+	t.Run("add entry", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("body", "Outlined the first three chapters")
+		form.Add("mood", "great")
+		form.Add("confidence", "4")
+		code, _, body := ts.htmx(t, http.MethodPost, goalPath+"/journal", form)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Contains(t, body, "Outlined the first three chapters")
+		assert.Contains(t, body, "Confidence 4/5")
+		assert.NotContains(t, body, "<html")
+
+		form = url.Values{}
+		form.Add("body", "Stuck on chapter two")
+		code, _, _ = ts.postForm(t, goalPath+"/journal", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, goalPath)
+		assert.Regexp(t, `(?s)Stuck on chapter two.*Outlined the first three chapters`, body)
+	})
+
+	t.Run("invalid entries show errors", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("body", "")
+		code, headers, body := ts.htmx(t, http.MethodPost, goalPath+"/journal", form)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "#journal-errors", headers.Get("HX-Retarget"))
+		assert.Contains(t, body, "Entry cannot be blank")
+
+		form.Set("body", "Fine")
+		form.Add("mood", "ecstatic")
+		code, _, body = ts.postForm(t, goalPath+"/journal", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a valid mood")
+	})
+
+	t.Run("edit entry", func(t *testing.T) {
+		code, _, body := ts.htmx(t, http.MethodGet, goalPath+"/journal/1/edit", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `hx-put="`+goalPath+`/journal/1"`)
+		assert.Contains(t, body, `<option value="great" selected>`)
+
+		form := url.Values{}
+		form.Add("body", "Outlined the first four chapters")
+		code, _, body = ts.htmx(t, http.MethodPut, goalPath+"/journal/1", form)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Outlined the first four chapters")
+		assert.Contains(t, body, "edited")
+
+		code, _, _ = ts.htmx(t, http.MethodPut, "/goals/99/journal/1", form)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("journal can be shown on the share page", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		links, err := app.services.share.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		sharePath := "/s/" + links[0].PublicID
+
+		_, _, body := ts.get(t, sharePath)
+		assert.Contains(t, body, "Write a novel")
+		assert.NotContains(t, body, "Stuck on chapter two")
+
+		form := url.Values{}
+		form.Add("journal_public", "1")
+		code, _, _ = ts.htmx(t, http.MethodPost, goalPath+"/journal/public", form)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		_, _, body = ts.get(t, sharePath)
+		assert.Contains(t, body, "Journal (2)")
+		assert.Contains(t, body, "Stuck on chapter two")
+	})
+
+	t.Run("delete entry", func(t *testing.T) {
+		code, _, _ := ts.htmx(t, http.MethodDelete, goalPath+"/journal/2", nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.NotContains(t, body, "Stuck on chapter two")
+
+		code, _, _ = ts.htmx(t, http.MethodDelete, goalPath+"/journal/2", nil)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestRoadmap(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "roadmap@example.com", "12345678", "12345678")
+
+	t.Run("start date cannot be after the due date", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("goal", "Winter training")
+		form.Add("start", "2027-02-02")
+		form.Add("due", "2027-02-01")
+		code, _, body := ts.postForm(t, "/goals/add/", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Start date cannot be after the due date")
+	})
+
+	form := url.Values{}
+	form.Add("goal", "Winter training")
+	form.Add("start", "2026-11-15")
+	form.Add("due", "2027-02-01")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalPath := headers.Get("Location")
+
+	ts.addGoal(t, "Spring race", "2027-03-14")
+
+	t.Run("spans are shown on the timeline", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Nov 15, 2026 – Feb 1, 2027")
+
+		_, _, body = ts.get(t, goalPath)
+		assert.Contains(t, body, `value="2026-11-15"`)
+	})
+
+	t.Run("roadmap shows bars across quarters", func(t *testing.T) {
+		code, _, body := ts.get(t, "/goals/roadmap")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Winter training")
+		assert.Contains(t, body, "Spring race")
+		assert.Contains(t, body, "Q4 2026")
+		assert.Contains(t, body, "Q1 2027")
+		assert.Contains(t, body, "rotate-45")
+		assert.Contains(t, body, `title="Today"`)
+	})
+
+	t.Run("start date can be removed", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("goal", "Winter training")
+		form.Add("due", "2027-02-01")
+		code, _, _ := ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.NotContains(t, body, "Nov 15, 2026")
+	})
+}
+
+func TestSearch(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "search@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Run a marathon")
+	form.Add("description", "Finish the Berlin marathon below four hours")
+	form.Add("due", "2026-09-27")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	marathonPath := headers.Get("Location")
+
+	learnPath := fmt.Sprintf("/goals/%d", ts.addGoal(t, "Learn <b>Spanish</b>", "2026-12-31"))
+	criteria := url.Values{}
+	criteria.Add("new_criterion", "Hold a conversation about running")
+	code, _, _ = ts.postForm(t, learnPath+"/criteria/update", criteria)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	t.Run("matches are ranked and highlighted", func(t *testing.T) {
+		code, _, body := ts.get(t, "/goals/search?q=marathon")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Run a <mark>marathon</mark>")
+		assert.Contains(t, body, "Berlin <mark>marathon</mark>")
+		assert.NotContains(t, body, "Spanish")
+	})
+
+	t.Run("prefixes and success criteria match", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals/search?q=run")
+		assert.Regexp(t, `(?s)<mark>Run</mark> a marathon.*Learn &lt;b&gt;Spanish&lt;/b&gt;`, body)
+		assert.Contains(t, body, "Hold a conversation about <mark>running</mark>")
+	})
+
+	t.Run("htmx gets only the results", func(t *testing.T) {
+		code, _, body := ts.htmx(t, http.MethodGet, "/goals/search?q=span", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Learn &lt;b&gt;<mark>Spanish</mark>&lt;/b&gt;")
+		assert.NotContains(t, body, "<html")
+
+		_, _, body = ts.htmx(t, http.MethodGet, "/goals/search?q=+", nil)
+		assert.Empty(t, body)
+
+		code, _, body = ts.htmx(t, http.MethodGet, "/goals/search?q=%22(*", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "No goals match")
+
+		_, _, body = ts.htmx(t, http.MethodGet, "/goals/search?q=swimming", nil)
+		assert.Contains(t, body, "No goals match")
+	})
+
+	t.Run("index follows edits and the trash", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("goal", "Run a half marathon")
+		form.Add("description", "Any city will do")
+		form.Add("due", "2026-09-27")
+		code, _, _ := ts.postForm(t, marathonPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals/search?q=berlin")
+		assert.Contains(t, body, "No goals match")
+
+		_, _, body = ts.get(t, "/goals/search?q=city")
+		assert.Contains(t, body, "Run a half marathon")
+
+		code, _, _ = ts.postForm(t, marathonPath+"/delete", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/goals/search?q=city")
+		assert.Contains(t, body, "No goals match")
+	})
+
+	t.Run("control characters cannot fake highlights", func(t *testing.T) {
+		ts.addGoal(t, "Swim\x02 across\x03 the lake", "2026-08-01")
+
+		criteria := url.Values{}
+		criteria.Add("new_criterion", "Practice \x02breathing\x03 drills")
+		code, _, _ := ts.postForm(t, learnPath+"/criteria/update", criteria)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals/search?q=lake")
+		assert.Contains(t, body, "Swim across the <mark>lake</mark>")
+		assert.NotContains(t, body, "<mark>across")
+
+		_, _, body = ts.get(t, "/goals/search?q=drills")
+		assert.Contains(t, body, "Practice breathing <mark>drills</mark>")
+	})
+}
+
+func TestTimelineFilters(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "timeline@example.com", "12345678", "12345678")
+
+	addGoal := func(goal, due string, visible bool, status string) string {
+		form := url.Values{}
+		form.Add("goal", goal)
+		form.Add("due", due)
+		if visible {
+			form.Add("visible", "on")
+		}
+		code, headers, _ := ts.postForm(t, "/goals/add/", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		if status != "" {
+			form.Add("status", status)
+			code, _, _ = ts.postForm(t, headers.Get("Location"), form)
+			assert.Equal(t, http.StatusSeeOther, code)
+		}
+		return headers.Get("Location")
+	}
+
+	addGoal("Launch the beta", "2026-11-20", true, "achieved")
+	addGoal("Hire a designer", "2026-12-31", false, "")
+	addGoal("Open a second office", "2027-01-15", true, "")
+
+	t.Run("filters by year and date range", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals?year=2027")
+		assert.Contains(t, body, "Open a second office")
+		assert.NotContains(t, body, "Launch the beta")
+		assert.Contains(t, body, `<option value="2027" selected>2027</option>`)
+
+		_, _, body = ts.get(t, "/goals?from=2026-12-01&to=2026-12-31")
+		assert.Contains(t, body, "Hire a designer")
+		assert.NotContains(t, body, "Launch the beta")
+		assert.NotContains(t, body, "Open a second office")
+
+		_, _, body = ts.get(t, "/goals?year=2030")
+		assert.Contains(t, body, "No goals match these filters.")
+	})
+
+	t.Run("filters by visibility and state", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals?visibility=private")
+		assert.Contains(t, body, "Hire a designer")
+		assert.NotContains(t, body, "Open a second office")
+
+		_, _, body = ts.get(t, "/goals?state=achieved")
+		assert.Contains(t, body, "Launch the beta")
+		assert.NotContains(t, body, "Hire a designer")
+
+		_, _, body = ts.get(t, "/goals?state=open&visibility=public")
+		assert.Contains(t, body, "Open a second office")
+		assert.NotContains(t, body, "Launch the beta")
+		assert.NotContains(t, body, "Hire a designer")
+	})
+
+	t.Run("invalid filters are ignored", func(t *testing.T) {
+		code, _, body := ts.get(t, "/goals?year=soon&state=done&zoom=decade&offset=x")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Launch the beta")
+		assert.Contains(t, body, "Open a second office")
+	})
+
+	t.Run("zoom groups goals", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals?zoom=quarter")
+		assert.Contains(t, body, "Q4 2026")
+		assert.Contains(t, body, "Q1 2027")
+		assert.NotContains(t, body, "November 20, 2026")
+
+		_, _, body = ts.get(t, "/goals?zoom=month")
+		assert.Contains(t, body, "November 2026")
+		assert.Contains(t, body, "January 2027")
+	})
+
+	t.Run("share page has the same filters", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		links, err := app.services.share.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		sharePath := "/s/" + links[0].PublicID
+
+		_, _, body := ts.get(t, sharePath+"?state=open&zoom=year")
+		assert.Contains(t, body, "Open a second office")
+		assert.NotContains(t, body, "Launch the beta")
+		assert.NotContains(t, body, "Hire a designer")
+		assert.Contains(t, body, ">2027<")
+	})
+
+	t.Run("large timelines load more goals", func(t *testing.T) {
+		due := time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := range 55 {
+			form := &goals.Form{
+				Goal: fmt.Sprintf("Chapter %d", i+1),
+				Due:  due.AddDate(0, 0, i).Format(HTMLDateFormat),
+			}
+			_, err := app.services.goals.Add(context.Background(), 1, form)
+			assert.NoError(t, err)
+		}
+
+		_, _, body := ts.get(t, "/goals?year=2028")
+		assert.Contains(t, body, "Chapter 50<")
+		assert.NotContains(t, body, "Chapter 51<")
+
+		goalList, err := app.services.goals.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		var after goals.Cursor
+		for _, goal := range goalList {
+			if goal.Goal.String == "Chapter 50" {
+				after = goal.Cursor()
+			}
+		}
+		assert.Contains(t, body, `hx-get="/goals?after=`+after.String()+`&amp;offset=50&amp;year=2028"`)
+
+		code, _, body := ts.htmx(t, http.MethodGet, "/goals?after="+after.String()+"&offset=50&year=2028", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, body, "<html")
+		assert.NotContains(t, body, "Chapter 50<")
+		assert.Contains(t, body, "Chapter 51<")
+		assert.Contains(t, body, "Chapter 55<")
+		assert.NotContains(t, body, "timeline-more")
+		assert.Contains(t, body, `data-tip="Add Goal"`)
+
+		_, _, body = ts.get(t, "/goals?year=2028&zoom=year")
+		assert.Contains(t, body, "Chapter 55<")
+		assert.NotContains(t, body, "timeline-more")
+	})
+}
+
+func TestBulkActions(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "bulk@example.com", "12345678", "12345678")
+
+	first := ts.addGoal(t, "Write the proposal", "2026-11-01")
+	second := ts.addGoal(t, "Present the proposal", "2026-11-15")
+	third := ts.addGoal(t, "Celebrate", "2026-12-01")
+
+	bulk := func(action string, days string, ids ...int) (int, http.Header, string) {
+		form := url.Values{}
+		form.Add("action", action)
+		form.Add("days", days)
+		for _, id := range ids {
+			form.Add("id", strconv.Itoa(id))
+		}
+		return ts.postForm(t, "/goals/bulk", form)
+	}
+
+	get := func(id int) goals.Goal {
+		goal, err := app.services.goals.Get(context.Background(), id, 1)
+		assert.NoError(t, err)
+		return goal
+	}
+
+	t.Run("select mode shows checkboxes", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, `href="/goals?select=on"`)
+		assert.NotContains(t, body, `form="bulk-form"`)
+
+		_, _, body = ts.get(t, "/goals?select=on&year=2026")
+		assert.Contains(t, body, `action="/goals/bulk?year=2026"`)
+		assert.Contains(t, body, fmt.Sprintf(`value="%d"`, first))
+		assert.Contains(t, body, `form="bulk-form"`)
+	})
+
+	t.Run("make public and private", func(t *testing.T) {
+		code, headers, _ := bulk("public", "", first, second)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals?select=on", headers.Get("Location"))
+		assert.Equal(t, int64(1), get(first).VisibleToPublic.Int64)
+		assert.Equal(t, int64(1), get(second).VisibleToPublic.Int64)
+		assert.Equal(t, int64(0), get(third).VisibleToPublic.Int64)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "2 goals made public.")
+
+		code, _, _ = bulk("private", "", second)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, int64(0), get(second).VisibleToPublic.Int64)
+	})
+
+	t.Run("mark achieved and not achieved", func(t *testing.T) {
+		code, _, _ := bulk("achieve", "", first, third)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, string(goals.Achieved), get(first).Status)
+		assert.True(t, get(first).AchievedAt.Valid)
+		assert.Equal(t, string(goals.NotStarted), get(second).Status)
+
+		history, err := app.services.goals.GetStatusHistory(context.Background(), first, 1)
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+
+		code, _, _ = bulk("unachieve", "", first, second)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, string(goals.NotStarted), get(first).Status)
+		assert.False(t, get(first).AchievedAt.Valid)
+		assert.Equal(t, string(goals.Achieved), get(third).Status)
+	})
+
+	t.Run("shift due dates", func(t *testing.T) {
+		before := get(second).Due.Int64
+
+		code, _, _ := bulk("shift", "-3", second)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, before-3*24*60*60, get(second).Due.Int64)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "1 goal moved 3 days earlier.")
+
+		revisions, err := app.services.goals.GetRevisions(context.Background(), second, 1)
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+	})
+
+	t.Run("invalid actions change nothing", func(t *testing.T) {
+		code, _, body := bulk("shift", "0", first)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Days cannot be zero")
+
+		code, _, body = bulk("explode", "", first)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a valid action")
+
+		code, _, body = bulk("public", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Select at least one goal")
+	})
+
+	t.Run("goals of other users are rejected", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+		other.signup(t, "other@example.com", "12345678", "12345678")
+		foreign := other.addGoal(t, "Someone else's goal", "2026-11-01")
+
+		code, _, _ := bulk("public", "", third, foreign)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, int64(0), get(third).VisibleToPublic.Int64)
+
+		goal, err := app.services.goals.Get(context.Background(), foreign, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), goal.VisibleToPublic.Int64)
+	})
+
+	t.Run("delete and undo", func(t *testing.T) {
+		code, _, _ := bulk("delete", "", first, second)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "2 goals moved to the trash.")
+		assert.Contains(t, body, "/goals/bulk?action=restore")
+		assert.NotContains(t, body, "Write the proposal")
+
+		code, _, _ = bulk("achieve", "", first)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		undo := fmt.Sprintf("/goals/bulk?action=restore&id=%d&id=%d", first, second)
+		code, headers, _ := ts.postForm(t, undo, url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals", headers.Get("Location"))
+
+		_, _, body = ts.get(t, "/goals")
+		assert.Contains(t, body, "2 goals restored.")
+		assert.Contains(t, body, "Write the proposal")
+		assert.Contains(t, body, "Present the proposal")
+	})
+}
+
+func TestReschedule(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "reschedule@example.com", "12345678", "12345678")
+
+	before := ts.addGoal(t, "Kick-off", "2026-10-01")
+	slipped := ts.addGoal(t, "Build the prototype", "2026-11-01")
+	achieved := ts.addGoal(t, "Book the venue", "2026-11-15")
+	later := ts.addGoal(t, "Launch", "2026-12-01")
+
+	form := url.Values{}
+	form.Add("goal", "Book the venue")
+	form.Add("due", "2026-11-15")
+	form.Add("status", "achieved")
+	code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", achieved), form)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	due := func(id int) string {
+		goal, err := app.services.goals.Get(context.Background(), id, 1)
+		assert.NoError(t, err)
+		return time.Unix(goal.Due.Int64, 0).UTC().Format(HTMLDateFormat)
+	}
+
+	reschedulePath := fmt.Sprintf("/goals/%d/reschedule", slipped)
+
+	t.Run("edit page links to reschedule", func(t *testing.T) {
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", slipped))
+		assert.Contains(t, body, `href="`+reschedulePath+`"`)
+	})
+
+	t.Run("preview lists the goals that move", func(t *testing.T) {
+		code, _, body := ts.get(t, reschedulePath+"?days=14")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "3 goals move")
+		assert.Contains(t, body, "November 15, 2026")
+		assert.NotContains(t, body, "Kick-off")
+
+		code, _, body = ts.htmx(t, http.MethodGet, reschedulePath+"?date=2026-11-08&skip_achieved=on", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, body, "<html")
+		assert.Contains(t, body, "2 goals move")
+		assert.Contains(t, body, "December 8, 2026")
+		assert.NotContains(t, body, "Book the venue")
+
+		assert.Equal(t, "2026-11-01", due(slipped))
+	})
+
+	t.Run("invalid offsets are rejected", func(t *testing.T) {
+		code, _, body := ts.postForm(t, reschedulePath, url.Values{})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Enter a number of days or a new due date")
+
+		code, _, body = ts.postForm(t, reschedulePath, url.Values{"date": {"2026-11-01"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "New due date is the current due date")
+
+		code, _, body = ts.postForm(t, reschedulePath, url.Values{"date": {"2099-01-01"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "New due date must be within 3650 days of the current due date")
+	})
+
+	t.Run("reschedule and undo", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, reschedulePath, url.Values{
+			"date":          {"2026-11-08"},
+			"skip_achieved": {"on"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals", headers.Get("Location"))
+
+		assert.Equal(t, "2026-10-01", due(before))
+		assert.Equal(t, "2026-11-08", due(slipped))
+		assert.Equal(t, "2026-11-15", due(achieved))
+		assert.Equal(t, "2026-12-08", due(later))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "2 goals moved 7 days later.")
+		undo := fmt.Sprintf("/goals/%d/reschedule/", slipped)
+		assert.Contains(t, body, undo)
+
+		start := strings.Index(body, undo)
+		undoPath := body[start : start+strings.Index(body[start:], `"`)]
+
+		code, _, _ = ts.postForm(t, undoPath, url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "2026-11-01", due(slipped))
+		assert.Equal(t, "2026-12-01", due(later))
+
+		code, _, _ = ts.postForm(t, undoPath, url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	undoPath := func() string {
+		_, _, body := ts.get(t, "/goals")
+		undo := fmt.Sprintf("/goals/%d/reschedule/", slipped)
+		start := strings.Index(body, undo)
+		return body[start : start+strings.Index(body[start:], `"`)]
+	}
+
+	t.Run("undo keeps goals changed since", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, reschedulePath, url.Values{"days": {"7"}, "skip_achieved": {"on"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		undo := undoPath()
+
+		form := url.Values{}
+		form.Add("goal", "Launch")
+		form.Add("due", "2027-01-15")
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d", later), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, undo, url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "2026-11-01", due(slipped))
+		assert.Equal(t, "2027-01-15", due(later))
+
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", slipped))
+		assert.Contains(t, body, "1 goal moved back. 1 goal was changed since and kept the new due date.")
+
+		form.Set("due", "2026-12-01")
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d", later), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+	})
+
+	t.Run("expired reschedules cannot be undone", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, reschedulePath, url.Values{"days": {"7"}, "skip_achieved": {"on"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		undo := undoPath()
+
+		_, err := app.db.Exec("UPDATE goal_reschedules SET created_at = ?", time.Now().Add(-goals.RescheduleUndoWindow-time.Minute).Unix())
+		assert.NoError(t, err)
+
+		code, _, _ = ts.postForm(t, undo, url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "2026-11-08", due(slipped))
+
+		assert.NoError(t, app.services.goals.PruneReschedules(context.Background(), time.Now()))
+		var count int
+		assert.NoError(t, app.db.QueryRow("SELECT COUNT(*) FROM goal_reschedules").Scan(&count))
+		assert.Equal(t, 0, count)
+
+		code, _, _ = ts.postForm(t, reschedulePath, url.Values{"days": {"-7"}, "skip_achieved": {"on"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+	})
+
+	t.Run("moving earlier includes achieved goals by default", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, reschedulePath, url.Values{"days": {"-3"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "2026-10-29", due(slipped))
+		assert.Equal(t, "2026-11-12", due(achieved))
+		assert.Equal(t, "2026-11-28", due(later))
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "templates@example.com", "12345678", "12345678")
+
+	byTitle := func(title string) (goals.Goal, bool) {
+		goalList, err := app.services.goals.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		for _, goal := range goalList {
+			if goal.Goal.String == title {
+				return goal, true
+			}
+		}
+		return goals.Goal{}, false
+	}
+	due := func(goal goals.Goal) string {
+		return time.Unix(goal.Due.Int64, 0).UTC().Format(HTMLDateFormat)
+	}
+
+	t.Run("built-in templates are listed", func(t *testing.T) {
+		code, _, body := ts.get(t, "/goals/templates")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Onboarding plan")
+		assert.Contains(t, body, "90 days")
+		assert.Contains(t, body, `value="onboarding"`)
+		assert.Contains(t, body, `href="/goals/templates"`)
+	})
+
+	t.Run("use a built-in template", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, "/goals/templates/use", url.Values{
+			"template": {"onboarding"},
+			"start":    {"2026-11-02"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals", headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "4 goals added from Onboarding plan.")
+		assert.Contains(t, body, "/goals/bulk?action=delete")
+
+		first, ok := byTitle("Get set up and meet the team")
+		assert.True(t, ok)
+		assert.Equal(t, "2026-11-09", due(first))
+
+		last, ok := byTitle("Work independently")
+		assert.True(t, ok)
+		assert.Equal(t, "2027-01-31", due(last))
+		assert.Equal(t, "2027-01-01", time.Unix(last.StartDate.Int64, 0).UTC().Format(HTMLDateFormat))
+
+		criteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), int(first.ID), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(criteria))
+	})
+
+	t.Run("invalid forms are rejected", func(t *testing.T) {
+		code, _, body := ts.postForm(t, "/goals/templates/use", url.Values{"template": {"onboarding"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Start date cannot be blank")
+
+		code, _, _ = ts.postForm(t, "/goals/templates/use", url.Values{
+			"template": {"unknown"},
+			"start":    {"2026-11-02"},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, body = ts.postForm(t, "/goals/templates", url.Values{})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Name cannot be blank")
+		assert.Contains(t, body, "Select at least one goal")
+	})
+
+	t.Run("save goals as a template and use it", func(t *testing.T) {
+		kickoff := ts.addGoal(t, "Sprint kick-off", "2026-12-01")
+		review := ts.addGoal(t, "Sprint review", "2026-12-15")
+
+		code, headers, _ := ts.postForm(t, "/goals/templates", url.Values{
+			"name":        {"Sprint"},
+			"description": {"Two weeks of work"},
+			"id":          {strconv.Itoa(review), strconv.Itoa(kickoff)},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals/templates", headers.Get("Location"))
+
+		templateList, err := app.services.templates.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		saved := templateList[len(templateList)-1]
+		assert.Equal(t, "Sprint", saved.Name)
+		assert.Equal(t, "+0 days", saved.Goals[0].Due)
+		assert.Equal(t, "+14 days", saved.Goals[1].Due)
+
+		_, _, body := ts.get(t, "/goals/templates")
+		assert.Contains(t, body, "Template saved!")
+		assert.Contains(t, body, "Two weeks of work")
+
+		code, _, _ = ts.postForm(t, "/goals/templates/use", url.Values{
+			"template": {saved.Key()},
+			"start":    {"2027-01-04"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		goalList, err := app.services.goals.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		var dues []string
+		for _, goal := range goalList {
+			if goal.Goal.String == "Sprint review" {
+				dues = append(dues, due(goal))
+			}
+		}
+		assert.Contains(t, dues, "2026-12-15")
+		assert.Contains(t, dues, "2027-01-18")
+	})
+
+	t.Run("goals too far apart are rejected", func(t *testing.T) {
+		first := ts.addGoal(t, "Found the company", "2026-01-01")
+		last := ts.addGoal(t, "Retire", "2046-01-01")
+
+		code, _, body := ts.postForm(t, "/goals/templates", url.Values{
+			"name": {"Career"},
+			"id":   {strconv.Itoa(first), strconv.Itoa(last)},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Selected goals must start and be due within 3650 days of each other")
+	})
+
+	t.Run("goals and templates of other users are rejected", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+		other.signup(t, "other@example.com", "12345678", "12345678")
+		foreign := other.addGoal(t, "Someone else's goal", "2026-11-01")
+
+		code, _, _ := ts.postForm(t, "/goals/templates", url.Values{
+			"name": {"Stolen"},
+			"id":   {strconv.Itoa(foreign)},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		templateList, err := app.services.templates.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		saved := templateList[len(templateList)-1]
+
+		code, _, _ = other.postForm(t, "/goals/templates/use", url.Values{
+			"template": {saved.Key()},
+			"start":    {"2027-01-04"},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = other.postForm(t, "/goals/templates/delete", url.Values{"id": {saved.Key()}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("delete a saved template", func(t *testing.T) {
+		templateList, err := app.services.templates.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		saved := templateList[len(templateList)-1]
+
+		code, headers, _ := ts.postForm(t, "/goals/templates/delete", url.Values{"id": {saved.Key()}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, "/goals/templates", headers.Get("Location"))
+
+		_, _, body := ts.get(t, "/goals/templates")
+		assert.Contains(t, body, "Template deleted.")
+		assert.NotContains(t, body, "Two weeks of work")
+
+		code, _, _ = ts.postForm(t, "/goals/templates/delete", url.Values{"id": {saved.Key()}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestDuplicateGoal(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "duplicate@example.com", "12345678", "12345678")
+
+	goalID := ts.addGoal(t, "Run a half marathon", "2026-11-30")
+
+	form := url.Values{}
+	form.Add("goal", "Run a half marathon")
+	form.Add("description", "Under two hours")
+	form.Add("start", "2026-11-16")
+	form.Add("due", "2026-11-30")
+	form.Add("visible", "on")
+	code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), form)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	for _, description := range []string{"Run 15 km in training", "Register for the race"} {
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/criteria", goalID), url.Values{"description": {description}})
+		assert.Equal(t, http.StatusSeeOther, code)
+	}
+
+	criteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), goalID, 1)
+	assert.NoError(t, err)
+	code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/criteria/%d/toggle", goalID, criteria[0].ID), url.Values{})
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	duplicatePath := fmt.Sprintf("/goals/%d/duplicate", goalID)
+
+	t.Run("edit page links to duplicate", func(t *testing.T) {
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", goalID))
+		assert.Contains(t, body, `href="`+duplicatePath+`"`)
+	})
+
+	t.Run("form suggests a new due date", func(t *testing.T) {
+		code, _, body := ts.get(t, duplicatePath)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, `value="Run a half marathon"`)
+		assert.Contains(t, body, `value="2026-12-14"`)
+		assert.Contains(t, body, "all 2 success criteria")
+	})
+
+	t.Run("invalid forms are rejected", func(t *testing.T) {
+		code, _, body := ts.postForm(t, duplicatePath, url.Values{"due": {"soon"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Goal cannot be blank")
+		assert.Contains(t, body, "Due date must be a valid date")
+	})
+
+	t.Run("duplicate with success criteria", func(t *testing.T) {
+		code, headers, _ := ts.postForm(t, duplicatePath, url.Values{
+			"goal": {"Run a marathon"},
+			"due":  {"2027-04-30"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		var copyID int
+		_, err := fmt.Sscanf(headers.Get("Location"), "/goals/%d", &copyID)
+		assert.NoError(t, err)
+		assert.NotEqual(t, goalID, copyID)
+
+		_, _, body := ts.get(t, headers.Get("Location"))
+		assert.Contains(t, body, "Goal duplicated!")
+
+		copied, err := app.services.goals.Get(context.Background(), copyID, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Run a marathon", copied.Goal.String)
+		assert.Equal(t, "Under two hours", copied.Description.String)
+		assert.Equal(t, int64(1), copied.VisibleToPublic.Int64)
+		assert.Equal(t, string(goals.NotStarted), copied.Status)
+		assert.Equal(t, "2027-04-30", time.Unix(copied.Due.Int64, 0).UTC().Format(HTMLDateFormat))
+		assert.Equal(t, "2027-04-16", time.Unix(copied.StartDate.Int64, 0).UTC().Format(HTMLDateFormat))
+
+		criteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), copyID, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(criteria))
+		for _, c := range criteria {
+			assert.Equal(t, int64(0), c.Completed.Int64)
+		}
+
+		original, err := app.services.successCriteria.GetAllByGoal(context.Background(), goalID, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(original))
+	})
+
+	t.Run("goals of other users are rejected", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+		other.signup(t, "other@example.com", "12345678", "12345678")
+
+		code, _, _ := other.get(t, duplicatePath)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = other.postForm(t, duplicatePath, url.Values{
+			"goal": {"Stolen"},
+			"due":  {"2027-04-30"},
+		})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+func TestMarkdownDescriptions(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "markdown@example.com", "12345678", "12345678")
+
+	goalID := ts.addGoal(t, "Learn Go", "2026-12-31")
+	form := url.Values{}
+	form.Add("goal", "Learn Go")
+	form.Add("description", "Read **the book** and [the tour](https://go.dev/tour).\n\n- `go test`\n- <script>alert(1)</script>")
+	form.Add("due", "2026-12-31")
+	form.Add("visible", "on")
+	code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), form)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	code, _, _ = ts.postForm(t, "/settings/branding", url.Values{
+		"title":       {"My goals"},
+		"description": {"Follow me on [my blog](javascript:alert(1))"},
+	})
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	t.Run("share page renders markdown", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		links, err := app.services.share.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+
+		for range 2 {
+			_, _, body := ts.get(t, "/s/"+links[0].PublicID)
+			assert.Contains(t, body, "<strong>the book</strong>")
+			assert.Contains(t, body, `<a href="https://go.dev/tour" rel="nofollow noopener">the tour</a>`)
+			assert.Contains(t, body, "<li><code>go test</code></li>")
+			assert.Contains(t, body, "&lt;script&gt;alert(1)&lt;/script&gt;")
+			assert.Contains(t, body, "Follow me on my blog")
+			assert.NotContains(t, body, "javascript:")
+		}
+	})
+
+	t.Run("timeline renders the branding description", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "Follow me on my blog")
+	})
+
+	t.Run("edit form previews the description", func(t *testing.T) {
+		_, _, body := ts.get(t, fmt.Sprintf("/goals/%d", goalID))
+		assert.Contains(t, body, `hx-post="/goals/preview"`)
+
+		code, _, body := ts.htmx(t, http.MethodPost, "/goals/preview", url.Values{"description": {"*draft* <b>"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, body, "<html")
+		assert.Contains(t, body, "<em>draft</em> &lt;b&gt;")
+
+		_, _, body = ts.htmx(t, http.MethodPost, "/goals/preview", url.Values{})
+		assert.Contains(t, body, "Nothing to preview.")
+	})
+
+	t.Run("long descriptions are rejected", func(t *testing.T) {
+		long := strings.Repeat("*a", goals.MaxDescriptionChars)
+
+		code, _, body := ts.htmx(t, http.MethodPost, "/goals/preview", url.Values{"description": {long}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, goals.DescriptionTooLongMessage())
+		assert.NotContains(t, body, "<em>")
+
+		code, _, body = ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), url.Values{
+			"goal":        {"Learn Go"},
+			"description": {long},
+			"due":         {"2026-12-31"},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, goals.DescriptionTooLongMessage())
+
+		code, _, body = ts.postForm(t, "/settings/branding", url.Values{
+			"title":       {"My goals"},
+			"description": {long},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Description cannot be more than")
+	})
+}
+
+func TestAttachments(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "attachments@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Get certified")
+	form.Add("due", "2026-11-30")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalPath := headers.Get("Location")
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	t.Run("add link", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("url", "https://www.example.com/docs/design/")
+		code, _, _ := ts.postForm(t, goalPath+"/attachments/link", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, "example.com/docs/design")
+		assert.Contains(t, body, `href="https://www.example.com/docs/design/"`)
+
+		form.Set("url", "javascript:alert(1)")
+		code, _, body = ts.postForm(t, goalPath+"/attachments/link", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "URL must start with http:// or https://")
+	})
+
+	t.Run("upload files", func(t *testing.T) {
+		fields := url.Values{}
+		fields.Add("title", "Certificate photo")
+		code, _, _ := ts.upload(t, goalPath+"/attachments/file", fields, "photo.png", png)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		// The browser claims a PDF, the content is HTML.
+		code, _, _ = ts.upload(t, goalPath+"/attachments/file", nil, "evil.pdf", []byte("<html><script>alert(1)</script></html>"))
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.Contains(t, body, "Certificate photo")
+		assert.Contains(t, body, "photo.png")
+		assert.Contains(t, body, "evil.pdf")
+
+		code, _, body = ts.upload(t, goalPath+"/attachments/file", nil, "", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a file to upload")
+	})
+
+	t.Run("files are served with safe headers", func(t *testing.T) {
+		code, headers, body := ts.get(t, goalPath+"/attachments/2")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "image/png", headers.Get("Content-Type"))
+		assert.Equal(t, "inline; filename=photo.png", headers.Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", headers.Get("X-Content-Type-Options"))
+		assert.Equal(t, "default-src 'none'; sandbox", headers.Get("Content-Security-Policy"))
+		assert.Equal(t, string(bytes.TrimSpace(png)), body)
+
+		code, headers, _ = ts.get(t, goalPath+"/attachments/3")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "application/octet-stream", headers.Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=evil.pdf", headers.Get("Content-Disposition"))
+
+		// Links have no file to serve.
+		code, _, _ = ts.get(t, goalPath+"/attachments/1")
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("uploads are limited by the quota", func(t *testing.T) {
+		code, _, body := ts.upload(t, goalPath+"/attachments/file", nil, "big.bin", make([]byte, 1<<20))
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "doesn&#39;t fit into your storage")
+
+		_, _, body = ts.get(t, goalPath)
+		assert.NotContains(t, body, "big.bin")
+	})
+
+	t.Run("attachments can be shown on the share page", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		links, err := app.services.share.GetAll(context.Background(), 1)
+		assert.NoError(t, err)
+		sharePath := "/s/" + links[0].PublicID
+		filePath := sharePath + goalPath + "/attachments/2"
+
+		_, _, body := ts.get(t, sharePath)
+		assert.Contains(t, body, "Get certified")
+		assert.NotContains(t, body, "Certificate photo")
+
+		code, _, _ = ts.get(t, filePath)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		form := url.Values{}
+		form.Add("public", "1")
+		code, _, _ = ts.htmx(t, http.MethodPost, goalPath+"/attachments/2/public", form)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		_, _, body = ts.get(t, sharePath)
+		assert.Contains(t, body, "Certificate photo")
+		assert.NotContains(t, body, "evil.pdf")
+
+		code, headers, _ := ts.get(t, filePath)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "image/png", headers.Get("Content-Type"))
+
+		// Hiding the goal hides its attachments.
+		form = url.Values{}
+		form.Add("goal", "Get certified")
+		form.Add("due", "2026-11-30")
+		code, _, _ = ts.postForm(t, goalPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.get(t, filePath)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("other users cannot see attachments", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+		other.signup(t, "other@example.com", "12345678", "12345678")
+
+		code, _, _ := other.get(t, goalPath+"/attachments/2")
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = other.postForm(t, goalPath+"/attachments/2/delete", nil)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = other.upload(t, goalPath+"/attachments/file", nil, "photo.png", png)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("delete attachment", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, goalPath+"/attachments/2/delete", nil)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.get(t, goalPath+"/attachments/2")
+		assert.Equal(t, http.StatusNotFound, code)
+
+		_, _, body := ts.get(t, goalPath)
+		assert.NotContains(t, body, "Certificate photo")
+	})
+}
+
+func TestComments(t *testing.T) {
+	app := newTestApplication(t)
+	// The rate limit is tested on its own below.
+	app.commentLimiters = newLimitersWithRate(rate.Inf, 0)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "owner@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Launch the beta")
+	form.Add("due", "2026-11-30")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalID := strings.TrimPrefix(headers.Get("Location"), "/goals/")
+	hiddenID := ts.addGoal(t, "Secret plan", "2026-12-31")
+
+	code, _, _ = ts.postForm(t, "/goals/share/create", url.Values{})
+	assert.Equal(t, http.StatusSeeOther, code)
+	links, err := app.services.share.GetAll(context.Background(), 1)
+	assert.NoError(t, err)
+	sharePath := "/s/" + links[0].PublicID
+	commentPath := sharePath + "/goals/" + goalID + "/comments"
+
+	viewer := newTestServer(t, app.routes())
+	defer viewer.Close()
+
+	t.Run("anonymous viewers comment with a name", func(t *testing.T) {
+		_, _, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, `action="`+commentPath+`"`)
+
+		form := url.Values{}
+		form.Add("body", "Can't wait to try it!")
+		code, _, body := viewer.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Name cannot be blank")
+		assert.Contains(t, body, "Launch the beta")
+
+		form.Add("name", "Alex")
+		code, headers, _ := viewer.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, sharePath, headers.Get("Location"))
+
+		_, _, body = viewer.get(t, sharePath)
+		assert.Contains(t, body, "will appear once it is approved")
+		assert.NotContains(t, body, "try it!")
+	})
+
+	t.Run("honeypot drops comments", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("name", "Bot")
+		form.Add("body", "Buy now")
+		form.Add("website", "https://spam.example.com")
+		code, _, _ := viewer.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("signed in users comment without a name", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+		other.signup(t, "stakeholder@example.com", "12345678", "12345678")
+
+		form := url.Values{}
+		form.Add("body", "Looks good to me")
+		code, _, _ := other.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		// Other users cannot moderate the comments.
+		code, _, _ = other.postForm(t, "/goals/comments/approve", url.Values{"id": {"1"}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("only shared goals can be commented on", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("name", "Alex")
+		form.Add("body", "What is this?")
+		code, _, _ := viewer.postForm(t, fmt.Sprintf("%s/goals/%d/comments", sharePath, hiddenID), form)
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = viewer.postForm(t, "/s/unknown/goals/"+goalID+"/comments", form)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("owner moderates comments", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals/comments")
+		assert.Contains(t, body, "Can&#39;t wait to try it!")
+		assert.Contains(t, body, "Alex\n")
+		assert.Contains(t, body, "Goalkeepr user\n")
+		assert.Equal(t, 1, strings.Count(body, "Signed in</span>"))
+		assert.NotContains(t, body, "stakeholder@example.com")
+		assert.NotContains(t, body, "Buy now")
+
+		code, _, _ := ts.postForm(t, "/goals/comments/approve", url.Values{"id": {"1"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, "/goals/comments/delete", url.Values{"id": {"2"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = viewer.get(t, sharePath)
+		assert.Contains(t, body, "Can&#39;t wait to try it!")
+		assert.Contains(t, body, "Comments (1)")
+		assert.NotContains(t, body, "Looks good to me")
+	})
+
+	t.Run("owner comments are approved right away", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("name", "Sam")
+		form.Add("body", "Thanks, Alex!")
+		code, _, _ := ts.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, "Thanks, Alex!")
+	})
+
+	t.Run("comments are rate limited", func(t *testing.T) {
+		app.commentLimiters = newLimitersWithRate(rate.Every(time.Minute), 1)
+		limited := newTestServer(t, app.routes())
+		defer limited.Close()
+
+		form := url.Values{}
+		form.Add("name", "Alex")
+		form.Add("body", "One more thing")
+		code, _, _ := limited.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = limited.postForm(t, commentPath, form)
+		assert.Equal(t, http.StatusTooManyRequests, code)
+	})
+}
+
+func TestReactions(t *testing.T) {
+	app := newTestApplication(t)
+	// The rate limit is tested on its own below.
+	app.reactionLimiters = newLimitersWithRate(rate.Inf, 0)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "owner@example.com", "12345678", "12345678")
+
+	form := url.Values{}
+	form.Add("goal", "Run a marathon")
+	form.Add("due", "2026-11-30")
+	form.Add("visible", "on")
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	assert.Equal(t, http.StatusSeeOther, code)
+	goalID := strings.TrimPrefix(headers.Get("Location"), "/goals/")
+	hiddenID := ts.addGoal(t, "Secret plan", "2026-12-31")
+
+	code, _, _ = ts.postForm(t, "/goals/share/create", url.Values{})
+	assert.Equal(t, http.StatusSeeOther, code)
+	links, err := app.services.share.GetAll(context.Background(), 1)
+	assert.NoError(t, err)
+	sharePath := "/s/" + links[0].PublicID
+	reactionPath := sharePath + "/goals/" + goalID + "/reactions"
+
+	viewer := newTestServer(t, app.routes())
+	defer viewer.Close()
+
+	t.Run("viewers react once per reaction", func(t *testing.T) {
+		_, headers, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, `action="`+reactionPath+`"`)
+		// Viewing alone doesn't hand out a cookie.
+		assert.Empty(t, headers.Values("Set-Cookie"))
+
+		code, headers, _ := viewer.postForm(t, reactionPath, url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, sharePath, headers.Get("Location"))
+
+		_, _, body = viewer.get(t, sharePath)
+		assert.Contains(t, body, "👏 1")
+		assert.Contains(t, body, `aria-pressed="true"`)
+
+		// Reacting again takes the reaction back.
+		code, _, body = viewer.htmx(t, http.MethodPost, reactionPath, url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, body, "👏 1")
+		assert.NotContains(t, body, `aria-pressed="true"`)
+		assert.NotContains(t, body, "Run a marathon")
+
+		code, _, body = viewer.htmx(t, http.MethodPost, reactionPath, url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "👏 1")
+	})
+
+	t.Run("reactions add up across viewers", func(t *testing.T) {
+		other := newTestServer(t, app.routes())
+		defer other.Close()
+
+		code, _, _ := other.postForm(t, reactionPath, url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		code, _, _ = other.postForm(t, reactionPath, url.Values{"reaction": {"fire"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, "👏 2")
+		assert.Contains(t, body, "🔥 1")
+	})
+
+	t.Run("forged cookies count as new viewers", func(t *testing.T) {
+		forged := newTestServer(t, app.routes())
+		defer forged.Close()
+
+		// Reuse the ID of the first viewer without knowing the key.
+		u, err := url.Parse(viewer.URL + sharePath)
+		assert.NoError(t, err)
+		var viewerID string
+		for _, c := range viewer.Client().Jar.Cookies(u) {
+			if c.Name == ViewerCookie {
+				viewerID, _, _ = strings.Cut(c.Value, ".")
+			}
+		}
+		assert.NotEmpty(t, viewerID)
+
+		u, err = url.Parse(forged.URL + sharePath)
+		assert.NoError(t, err)
+		forged.Client().Jar.SetCookies(u, []*http.Cookie{{Name: ViewerCookie, Value: viewerID + ".forged", Path: "/s/"}})
+
+		code, _, _ := forged.postForm(t, reactionPath, url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, "👏 3")
+	})
+
+	t.Run("only shared goals get reactions", func(t *testing.T) {
+		code, _, _ := viewer.postForm(t, fmt.Sprintf("%s/goals/%d/reactions", sharePath, hiddenID), url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = viewer.postForm(t, reactionPath, url.Values{"reaction": {"thumbsdown"}})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		code, _, _ = viewer.postForm(t, "/s/unknown/goals/"+goalID+"/reactions", url.Values{"reaction": {"clap"}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("owner sees reactions on the timeline", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "👏 3")
+		assert.Contains(t, body, "🔥 1")
+		assert.NotContains(t, body, "🎉")
+	})
+
+	t.Run("reactions are rate limited", func(t *testing.T) {
+		app.reactionLimiters = newLimitersWithRate(rate.Every(time.Minute), 1)
+		limited := newTestServer(t, app.routes())
+		defer limited.Close()
+
+		code, _, _ := limited.postForm(t, reactionPath, url.Values{"reaction": {"party"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = limited.postForm(t, reactionPath, url.Values{"reaction": {"party"}})
+		assert.Equal(t, http.StatusTooManyRequests, code)
+	})
+}
+
+// BenchmarkSharePage measures views of a share page whose goals got
+// reactions, by a viewer who reacted, too.
+func BenchmarkSharePage(b *testing.B) {
+	app := newTestApplication(b)
+	app.reactionLimiters = newLimitersWithRate(rate.Inf, 0)
+	ts := newTestServer(b, app.routes())
+	defer ts.Close()
+
+	form := url.Values{}
+	form.Add("email", "owner@example.com")
+	form.Add("password", "12345678")
+	form.Add("repeat_password", "12345678")
+	ts.postForm(b, "/signup", form)
+
+	for i := range 10 {
+		form := url.Values{}
+		form.Add("goal", fmt.Sprintf("Goal %d", i))
+		form.Add("due", fmt.Sprintf("2026-%02d-01", i+1))
+		form.Add("visible", "on")
+		ts.postForm(b, "/goals/add/", form)
+	}
+
+	ts.postForm(b, "/goals/share/create", url.Values{})
+	links, err := app.services.share.GetAll(context.Background(), 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	sharePath := "/s/" + links[0].PublicID
+
+	for i := range 10 {
+		ts.postForm(b, fmt.Sprintf("%s/goals/%d/reactions", sharePath, i+1), url.Values{"reaction": {"clap"}})
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rs, err := ts.Client().Get(ts.URL + sharePath)
+			if err != nil {
+				b.Fatal(err)
+			}
+			io.Copy(io.Discard, rs.Body)
+			rs.Body.Close()
+		}
+	})
+}
+
+func TestOverview(t *testing.T) {
+	app := newTestApplication(t)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "overview@example.com", "12345678", "12345678")
+
+	day := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, days).Format(HTMLDateFormat)
+	}
+	addCriteria := func(goalID, total, completed int) {
+		for i := range total {
+			code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/criteria", goalID), url.Values{"description": {fmt.Sprintf("Step %d", i+1)}})
+			assert.Equal(t, http.StatusSeeOther, code)
+		}
+		criteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), goalID, 1)
+		assert.NoError(t, err)
+		for _, c := range criteria[:completed] {
+			code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d/criteria/%d/toggle", goalID, c.ID), url.Values{})
+			assert.Equal(t, http.StatusSeeOther, code)
+		}
+	}
+
+	t.Run("nothing needs attention without goals", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals/overview")
+		assert.Contains(t, body, "Nothing is overdue, due this week or at risk.")
+
+		_, _, body = ts.get(t, "/goals")
+		assert.NotContains(t, body, "View overview")
+	})
+
+	ts.addGoal(t, "Submit the tax return", day(-3))
+	ts.addGoal(t, "Book the venue", day(2))
+	behindID := ts.addGoal(t, "Finish the thesis", day(10))
+	addCriteria(behindID, 4, 1)
+	onTrackID := ts.addGoal(t, "Ship the app", day(10))
+	addCriteria(onTrackID, 2, 1)
+	ts.addGoal(t, "Learn Spanish", day(60))
+
+	t.Run("goals are sorted by urgency", func(t *testing.T) {
+		code, _, body := ts.get(t, "/goals/overview")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Overdue (1)")
+		assert.Contains(t, body, "3 days overdue")
+		assert.Contains(t, body, "Due this week (1)")
+		assert.Contains(t, body, "Due in 2 days")
+		assert.Contains(t, body, "At risk (1)")
+		assert.Contains(t, body, "1 of 4 success criteria")
+		assert.NotContains(t, body, "Ship the app")
+		assert.NotContains(t, body, "Learn Spanish")
+		assert.Contains(t, body, "due within 14 days")
+	})
+
+	t.Run("goals page shows the goals that need attention", func(t *testing.T) {
+		_, _, body := ts.get(t, "/goals")
+		assert.Contains(t, body, "View overview")
+		assert.Contains(t, body, "Overdue (1)")
+	})
+
+	t.Run("thresholds are configurable", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("risk_days", "0")
+		form.Add("risk_progress", "101")
+		code, _, body := ts.postForm(t, "/settings/risk", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Days must be at least 1")
+		assert.Contains(t, body, "Progress cannot be more than 100%")
+
+		form.Set("risk_days", "30")
+		form.Set("risk_progress", "75")
+		code, _, _ = ts.postForm(t, "/settings/risk", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/settings")
+		assert.Contains(t, body, `value="30"`)
+		assert.Contains(t, body, `value="75"`)
+
+		_, _, body = ts.get(t, "/goals/overview")
+		assert.Contains(t, body, "At risk (2)")
+		assert.Contains(t, body, "Ship the app")
+
+		form.Set("risk_days", "5")
+		code, _, _ = ts.postForm(t, "/settings/risk", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/goals/overview")
+		assert.NotContains(t, body, "At risk (")
+	})
+
+	t.Run("achieved goals are not overdue", func(t *testing.T) {
+		goalID := ts.addGoal(t, "Renew the passport", day(-1))
+		_, _, body := ts.get(t, "/goals/overview")
+		assert.Contains(t, body, "Overdue (2)")
+
+		form := url.Values{}
+		form.Add("goal", "Renew the passport")
+		form.Add("due", day(-1))
+		form.Add("status", "achieved")
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/goals/overview")
+		assert.Contains(t, body, "Overdue (1)")
+	})
+}
+
+func TestReminders(t *testing.T) {
+	app := newTestApplication(t)
+	mail := &testMailer{}
+	app.mailer = mail
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "reminders@example.com", "12345678", "12345678")
+
+	day := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, days).Format(HTMLDateFormat)
+	}
+
+	t.Run("reminder days are validated", func(t *testing.T) {
+		_, _, body := ts.get(t, "/settings")
+		assert.Contains(t, body, `value="7, 1"`)
+
+		form := url.Values{}
+		form.Add("reminder_days", "7, soon")
+		code, _, body := ts.postForm(t, "/settings/reminders", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Days must be numbers from 0 to 90, separated by commas")
+
+		form.Set("reminder_days", "1, 2, 3, 4, 5, 6")
+		code, _, body = ts.postForm(t, "/settings/reminders", form)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose at most 5 days")
+
+		form.Set("reminder_days", "1,7, 7")
+		code, _, _ = ts.postForm(t, "/settings/reminders", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/settings")
+		assert.Contains(t, body, `value="7, 1"`)
+	})
+
+	venueID := ts.addGoal(t, "Book the venue", day(3))
+	reportID := ts.addGoal(t, "Submit the report", day(1))
+	ts.addGoal(t, "Learn Spanish", day(20))
+	achievedID := ts.addGoal(t, "Renew the passport", day(1))
+	form := url.Values{}
+	form.Add("goal", "Renew the passport")
+	form.Add("due", day(1))
+	form.Add("status", "achieved")
+	code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", achievedID), form)
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	t.Run("due goals are reminded in one email", func(t *testing.T) {
+		assert.NoError(t, app.sendReminders(context.Background()))
+
+		sent := mail.take()
+		if assert.Len(t, sent, 1) {
+			assert.Equal(t, "reminders@example.com", sent[0].To)
+			assert.Equal(t, "Reminder: 2 goals are due soon", sent[0].Subject)
+			assert.Contains(t, sent[0].Text, "Due in 3 days")
+			assert.Contains(t, sent[0].Text, "Due tomorrow")
+			assert.Contains(t, sent[0].Text, fmt.Sprintf("https://goalkeepr.test/goals/%d", venueID))
+			assert.Contains(t, sent[0].HTML, fmt.Sprintf(`href="https://goalkeepr.test/goals/%d"`, reportID))
+			assert.NotContains(t, sent[0].Text, "Learn Spanish")
+			assert.NotContains(t, sent[0].Text, "Renew the passport")
+		}
+	})
+
+	t.Run("reminders are sent once", func(t *testing.T) {
+		assert.NoError(t, app.sendReminders(context.Background()))
+		assert.Empty(t, mail.take())
+	})
+
+	t.Run("moved goals are reminded again", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("goal", "Book the venue")
+		form.Add("due", day(2))
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", venueID), form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		assert.NoError(t, app.sendReminders(context.Background()))
+		sent := mail.take()
+		if assert.Len(t, sent, 1) {
+			assert.Equal(t, "Reminder: Book the venue", sent[0].Subject)
+		}
+	})
+
+	t.Run("failed reminders are retried", func(t *testing.T) {
+		ts.addGoal(t, "Call the bank", day(0))
+
+		mail.fail(errors.New("connection refused"))
+		assert.Error(t, app.sendReminders(context.Background()))
+
+		mail.fail(nil)
+		assert.NoError(t, app.sendReminders(context.Background()))
+		sent := mail.take()
+		if assert.Len(t, sent, 1) {
+			assert.Contains(t, sent[0].Text, "Due today")
+		}
+	})
+
+	t.Run("reminders can be turned off", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("reminder_days", "")
+		code, _, _ := ts.postForm(t, "/settings/reminders", form)
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		ts.addGoal(t, "Water the plants", day(1))
+		assert.NoError(t, app.sendReminders(context.Background()))
+		assert.Empty(t, mail.take())
+	})
+}
+
+func TestRemindersSurviveRestart(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Database.Dsn = filepath.Join(t.TempDir(), "goalkeepr.db")
+
+	app := newTestApplicationWithConfig(t, cfg)
+	mail := &testMailer{}
+	app.mailer = mail
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "restart@example.com", "12345678", "12345678")
+	ts.addGoal(t, "Book the venue", time.Now().UTC().AddDate(0, 0, 1).Format(HTMLDateFormat))
+
+	assert.NoError(t, app.sendReminders(context.Background()))
+	assert.Len(t, mail.take(), 1)
+
+	restarted := newTestApplicationWithConfig(t, cfg)
+	restartedMail := &testMailer{}
+	restarted.mailer = restartedMail
+
+	assert.NoError(t, restarted.sendReminders(context.Background()))
+	assert.Empty(t, restartedMail.take())
+}
+
+func TestDigest(t *testing.T) {
+	app := newTestApplication(t)
+	mail := &testMailer{}
+	app.mailer = mail
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "digest@example.com", "12345678", "12345678")
+
+	now := time.Now().UTC()
+	day := func(days int) string {
+		return now.AddDate(0, 0, days).Format(HTMLDateFormat)
+	}
+	today := digest.DayName(now.Weekday())
+	tomorrow := digest.DayName(now.AddDate(0, 0, 1).Weekday())
+	setDay := func(t *testing.T, name string) {
+		code, _, _ := ts.postForm(t, "/settings/digest", url.Values{"digest_day": {name}})
+		assert.Equal(t, http.StatusSeeOther, code)
+	}
+
+	t.Run("digest day is validated", func(t *testing.T) {
+		_, _, body := ts.get(t, "/settings")
+		assert.Contains(t, body, `<option value="monday" selected>Monday</option>`)
+
+		code, _, body := ts.postForm(t, "/settings/digest", url.Values{"digest_day": {"someday"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose a day of the week or off")
+	})
+
+	t.Run("empty digests are only sent as preview", func(t *testing.T) {
+		sent, err := app.sendDigest(context.Background(), 1, "digest@example.com", now, false)
+		assert.NoError(t, err)
+		assert.False(t, sent)
+		assert.Empty(t, mail.take())
+
+		code, _, _ := ts.postForm(t, "/settings/digest/preview", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		previews := mail.take()
+		if assert.Len(t, previews, 1) {
+			assert.Equal(t, "Preview: Your week on Goalkeepr", previews[0].Subject)
+			assert.Contains(t, previews[0].Text, "Nothing happened and nothing is coming up.")
+		}
+
+		_, _, body := ts.get(t, "/settings")
+		assert.Contains(t, body, "Digest preview sent to digest@example.com")
+	})
+
+	achievedID := ts.addGoal(t, "Run a half marathon", day(-2))
+	code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", achievedID), url.Values{
+		"goal":   {"Run a half marathon"},
+		"due":    {day(-2)},
+		"status": {"achieved"},
+	})
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	thesisID := ts.addGoal(t, "Finish the thesis", day(10))
+	code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/criteria", thesisID), url.Values{"description": {"Write the introduction"}})
+	assert.Equal(t, http.StatusSeeOther, code)
+	criteria, err := app.services.successCriteria.GetAllByGoal(context.Background(), thesisID, 1)
+	assert.NoError(t, err)
+	code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/criteria/%d/toggle", thesisID, criteria[0].ID), url.Values{})
+	assert.Equal(t, http.StatusSeeOther, code)
+
+	ts.addGoal(t, "Submit the tax return", day(-3))
+	ts.addGoal(t, "Learn Spanish", day(60))
+
+	t.Run("preview lists the week", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/settings/digest/preview", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		sent := mail.take()
+		if !assert.Len(t, sent, 1) {
+			return
+		}
+		for _, body := range []string{sent[0].Text, sent[0].HTML} {
+			assert.Contains(t, body, "Achieved (1)")
+			assert.Contains(t, body, "Run a half marathon")
+			assert.Contains(t, body, "Success criteria completed (1)")
+			assert.Contains(t, body, "Write the introduction")
+			assert.Contains(t, body, "Overdue (1)")
+			assert.Contains(t, body, "3 days overdue")
+			assert.Contains(t, body, "Due in the next two weeks (1)")
+			assert.Contains(t, body, "Due in 10 days")
+			assert.NotContains(t, body, "Learn Spanish")
+		}
+		assert.Contains(t, sent[0].Text, fmt.Sprintf("https://goalkeepr.test/goals/%d", thesisID))
+		assert.True(t, strings.HasPrefix(sent[0].Unsubscribe, "https://goalkeepr.test/digest/unsubscribe/"))
+		assert.Contains(t, sent[0].Text, sent[0].Unsubscribe)
+	})
+
+	t.Run("digests are sent on the chosen day once", func(t *testing.T) {
+		setDay(t, tomorrow)
+		assert.NoError(t, app.sendDigests(context.Background()))
+		assert.Empty(t, mail.take())
+
+		setDay(t, today)
+		assert.NoError(t, app.sendDigests(context.Background()))
+		sent := mail.take()
+		if assert.Len(t, sent, 1) {
+			assert.Equal(t, "digest@example.com", sent[0].To)
+			assert.Equal(t, "Your week on Goalkeepr", sent[0].Subject)
+		}
+
+		assert.NoError(t, app.sendDigests(context.Background()))
+		assert.Empty(t, mail.take())
+	})
+
+	t.Run("unsubscribe link needs no login", func(t *testing.T) {
+		code, _, _ := ts.postForm(t, "/settings/digest/preview", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+		sent := mail.take()
+		if !assert.Len(t, sent, 1) {
+			return
+		}
+		unsubscribePath := strings.TrimPrefix(sent[0].Unsubscribe, "https://goalkeepr.test")
+
+		anonymous := newTestServer(t, app.routes())
+		defer anonymous.Close()
+
+		code, _, body := anonymous.get(t, unsubscribePath)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Do you want to stop getting the weekly digest?")
+
+		code, _, _ = anonymous.get(t, unsubscribePath+"x")
+		assert.Equal(t, http.StatusNotFound, code)
+		code, _, _ = anonymous.postForm(t, "/digest/unsubscribe/1.forged", url.Values{})
+		assert.Equal(t, http.StatusNotFound, code)
+
+		// Mail clients unsubscribe with a one-click POST.
+		code, _, body = anonymous.postForm(t, unsubscribePath, url.Values{"List-Unsubscribe": {"One-Click"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "You are unsubscribed")
+
+		_, _, body = ts.get(t, "/settings")
+		assert.Contains(t, body, `<option value="off" selected>Off</option>`)
+	})
+}
+
+func TestShareSubscriptions(t *testing.T) {
+	app := newTestApplication(t)
+	mail := &testMailer{}
+	app.mailer = mail
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	ts.signup(t, "owner@example.com", "12345678", "12345678")
+
+	day := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, days).Format(HTMLDateFormat)
+	}
+	addPublicGoal := func(t *testing.T, goal, due string) int {
+		code, headers, _ := ts.postForm(t, "/goals/add/", url.Values{"goal": {goal}, "due": {due}, "visible": {"on"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		id, err := strconv.Atoi(strings.TrimPrefix(headers.Get("Location"), "/goals/"))
+		assert.NoError(t, err)
+		return id
+	}
+	updateGoal := func(t *testing.T, id int, goal, due, status string) {
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", id), url.Values{
+			"goal":    {goal},
+			"due":     {due},
+			"status":  {status},
+			"visible": {"on"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+	}
+	// link returns the path of the link to action in an email.
+	link := func(t *testing.T, text, action string) string {
+		for _, field := range strings.Fields(text) {
+			if strings.HasPrefix(field, "https://goalkeepr.test/s/") && strings.HasSuffix(field, "/"+action) {
+				return strings.TrimPrefix(field, "https://goalkeepr.test")
+			}
+		}
+		t.Fatalf("no %s link in %q", action, text)
+		return ""
+	}
+
+	marathonID := addPublicGoal(t, "Run a marathon", day(30))
+	bookID := addPublicGoal(t, "Write a book", day(60))
+
+	code, _, _ := ts.postForm(t, "/goals/share/create", url.Values{})
+	assert.Equal(t, http.StatusSeeOther, code)
+	links, err := app.services.share.GetAll(context.Background(), 1)
+	assert.NoError(t, err)
+	sharePath := "/s/" + links[0].PublicID
+
+	viewer := newTestServer(t, app.routes())
+	defer viewer.Close()
+
+	var unsubscribePath string
+
+	t.Run("subscribers confirm their email", func(t *testing.T) {
+		_, _, body := viewer.get(t, sharePath)
+		assert.Contains(t, body, `action="`+sharePath+`/subscribe"`)
+
+		code, _, body := viewer.postForm(t, sharePath+"/subscribe", url.Values{"email": {"not an email"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "This field must be a valid email address")
+		assert.Empty(t, mail.take())
+
+		code, headers, _ := viewer.postForm(t, sharePath+"/subscribe", url.Values{"email": {"Fan@Example.com"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		assert.Equal(t, sharePath, headers.Get("Location"))
+
+		sent := mail.take()
+		if !assert.Len(t, sent, 1) {
+			return
+		}
+		assert.Equal(t, "fan@example.com", sent[0].To)
+		assert.Equal(t, "Confirm your subscription to a shared Goalkeepr timeline", sent[0].Subject)
+		confirmPath := link(t, sent[0].Text, "confirm")
+
+		// Unconfirmed subscribers get no updates.
+		updateGoal(t, marathonID, "Run a marathon", day(31), "in_progress")
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		assert.Empty(t, mail.take())
+
+		code, _, body = viewer.get(t, confirmPath)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Do you want to get an email when goals on this timeline change?")
+
+		code, _, body = viewer.postForm(t, confirmPath, url.Values{})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "You are subscribed.")
+
+		// Changes from before the confirmation are not sent.
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		assert.Empty(t, mail.take())
+
+		code, _, _ = viewer.get(t, strings.Replace(confirmPath, "/subscriptions/", "/subscriptions/x", 1))
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("subscribers are notified of public changes", func(t *testing.T) {
+		addPublicGoal(t, "Learn to sail", day(90))
+		ts.addGoal(t, "Secret plan", day(10))
+		updateGoal(t, marathonID, "Run a marathon", day(31), "achieved")
+		updateGoal(t, bookID, "Write a book", day(75), "in_progress")
+
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		sent := mail.take()
+		if !assert.Len(t, sent, 1) {
+			return
+		}
+		assert.Equal(t, "fan@example.com", sent[0].To)
+		assert.Equal(t, "New updates on a shared Goalkeepr timeline", sent[0].Subject)
+		for _, body := range []string{sent[0].Text, sent[0].HTML} {
+			assert.Contains(t, body, "Learn to sail")
+			assert.Contains(t, body, "New goal, due "+time.Now().UTC().AddDate(0, 0, 90).Format("Jan 2, 2006"))
+			assert.Contains(t, body, "Run a marathon")
+			assert.Contains(t, body, "Achieved")
+			assert.Contains(t, body, "Moved from "+time.Now().UTC().AddDate(0, 0, 60).Format("Jan 2, 2006"))
+			assert.NotContains(t, body, "Secret plan")
+		}
+		unsubscribePath = link(t, sent[0].Text, "unsubscribe")
+		assert.Equal(t, "https://goalkeepr.test"+unsubscribePath, sent[0].Unsubscribe)
+
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		assert.Empty(t, mail.take())
+	})
+
+	t.Run("failed updates are sent again", func(t *testing.T) {
+		updateGoal(t, bookID, "Write a book", day(80), "in_progress")
+
+		mail.fail(errors.New("connection refused"))
+		assert.Error(t, app.sendShareUpdates(context.Background()))
+		mail.fail(nil)
+
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		if sent := mail.take(); assert.Len(t, sent, 1) {
+			assert.Contains(t, sent[0].Text, "Write a book")
+		}
+	})
+
+	t.Run("subscribers unsubscribe with one click", func(t *testing.T) {
+		code, _, body := viewer.get(t, unsubscribePath)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "Do you want to stop getting updates of this timeline?")
+
+		code, _, body = viewer.postForm(t, unsubscribePath, url.Values{"List-Unsubscribe": {"One-Click"}})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "You are unsubscribed")
+
+		updateGoal(t, bookID, "Write a book", day(85), "in_progress")
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		assert.Empty(t, mail.take())
+	})
+
+	t.Run("subscriptions end with the share link", func(t *testing.T) {
+		code, _, _ := viewer.postForm(t, sharePath+"/subscribe", url.Values{"email": {"second@example.com"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		sent := mail.take()
+		if !assert.Len(t, sent, 1) {
+			return
+		}
+		confirmPath := link(t, sent[0].Text, "confirm")
+		code, _, _ = viewer.postForm(t, confirmPath, url.Values{})
+		assert.Equal(t, http.StatusOK, code)
+
+		code, _, _ = ts.htmx(t, http.MethodDelete, fmt.Sprintf("/goals/share/%d", links[0].ID), url.Values{})
+		assert.Equal(t, http.StatusOK, code)
+
+		updateGoal(t, bookID, "Write a book", day(95), "in_progress")
+		assert.NoError(t, app.sendShareUpdates(context.Background()))
+		assert.Empty(t, mail.take())
+
+		code, _, _ = viewer.get(t, confirmPath)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
+
+// webhookReceiver records the webhook requests it receives.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []webhookRequest
+}
+
+type webhookRequest struct {
+	path     string
+	header   http.Header
+	body     []byte
+	envelope webhooks.Envelope
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var envelope webhooks.Envelope
+	json.Unmarshal(body, &envelope)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, webhookRequest{path: r.URL.Path, header: r.Header, body: body, envelope: envelope})
+	if rc.status != 0 {
+		w.WriteHeader(rc.status)
+	}
+}
+
+// take returns the requests received since the last call.
+func (rc *webhookReceiver) take() []webhookRequest {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	requests := rc.requests
+	rc.requests = nil
+	return requests
+}
+
+func (rc *webhookReceiver) respond(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.status = status
+}
+
+func TestWebhooks(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Webhooks.AllowPrivate = true
+	app := newTestApplicationWithConfig(t, cfg)
+	ts := newTestServer(t, app.routes())
+	defer ts.Close()
+
+	receiver := &webhookReceiver{}
+	hooks := httptest.NewServer(receiver)
+	defer hooks.Close()
+
+	ts.signup(t, "hooks@example.com", "12345678", "12345678")
+	ctx := context.Background()
+
+	day := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, days).Format(HTMLDateFormat)
+	}
+	events := func(requests []webhookRequest, path string) []string {
+		var events []string
+		for _, req := range requests {
+			if req.path == path {
+				events = append(events, req.envelope.Event)
+			}
+		}
+		return events
+	}
+
+	t.Run("webhooks are added in the settings", func(t *testing.T) {
+		code, _, body := ts.get(t, "/settings/webhooks")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "No webhooks yet.")
+
+		code, _, body = ts.postForm(t, "/settings/webhooks", url.Values{"url": {hooks.URL + "/all"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "Choose at least one event")
+
+		code, _, body = ts.postForm(t, "/settings/webhooks", url.Values{"url": {"ftp://example.com"}, "events": {"goal.created"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "URL must start with http:// or https://")
+
+		code, _, _ = ts.postForm(t, "/settings/webhooks", url.Values{"url": {hooks.URL + "/all"}, "events": webhooks.Events})
+		assert.Equal(t, http.StatusSeeOther, code)
+		code, _, _ = ts.postForm(t, "/settings/webhooks", url.Values{"url": {hooks.URL + "/achieved"}, "events": {"goal.achieved"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		_, _, body = ts.get(t, "/settings/webhooks")
+		assert.Contains(t, body, hooks.URL+"/all")
+		assert.Contains(t, body, "goal.achieved, goal.deleted")
+	})
+
+	t.Run("events are sent signed", func(t *testing.T) {
+		goalID := ts.addGoal(t, "Run a marathon", day(30))
+		code, _, _ := ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), url.Values{
+			"goal":   {"Run a marathon"},
+			"due":    {day(40)},
+			"status": {"in_progress"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/criteria", goalID), url.Values{"description": {"Run 30 km"}})
+		assert.Equal(t, http.StatusSeeOther, code)
+		criteria, err := app.services.successCriteria.GetAllByGoal(ctx, goalID, 1)
+		assert.NoError(t, err)
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/criteria/%d/toggle", goalID, criteria[0].ID), url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d", goalID), url.Values{
+			"goal":   {"Run a marathon"},
+			"due":    {day(40)},
+			"status": {"achieved"},
+		})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, "/goals/share/create", url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		code, _, _ = ts.postForm(t, fmt.Sprintf("/goals/%d/delete", goalID), url.Values{})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		received := receiver.take()
+
+		assert.ElementsMatch(t, []string{
+			"goal.created",
+			"goal.updated",
+			"criterion.toggled",
+			"goal.updated",
+			"goal.achieved",
+			"share.created",
+			"goal.deleted",
+		}, events(received, "/all"))
+		// Webhooks receive only the events they are subscribed to.
+		assert.Equal(t, []string{"goal.achieved"}, events(received, "/achieved"))
+
+		all, err := app.services.webhooks.GetAll(ctx, 1)
+		assert.NoError(t, err)
+		secrets := map[string]string{}
+		for _, hook := range all {
+			secrets[strings.TrimPrefix(hook.Url, hooks.URL)] = hook.Secret
+		}
+
+		for _, req := range received {
+			assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+			assert.Equal(t, req.envelope.Event, req.header.Get(webhooks.EventHeader))
+			assert.Equal(t, strconv.FormatInt(req.envelope.ID, 10), req.header.Get(webhooks.DeliveryHeader))
+
+			var timestamp, signature string
+			for _, part := range strings.Split(req.header.Get(webhooks.SignatureHeader), ",") {
+				key, value, _ := strings.Cut(part, "=")
+				switch key {
+				case "t":
+					timestamp = value
+				case "v1":
+					signature = value
+				}
+			}
+			assert.Equal(t, webhooks.Sign(secrets[req.path], timestamp, req.body), signature)
+		}
+
+		for _, req := range received {
+			if req.envelope.Event != "goal.created" {
+				continue
+			}
+			var data struct {
+				ID     int    `json:"id"`
+				Goal   string `json:"goal"`
+				Due    string `json:"due"`
+				Public bool   `json:"public"`
+			}
+			assert.NoError(t, json.Unmarshal(req.envelope.Data, &data))
+			assert.Equal(t, goalID, data.ID)
+			assert.Equal(t, "Run a marathon", data.Goal)
+			assert.Equal(t, day(30), data.Due)
+		}
+
+		// Delivered events are not sent again.
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		assert.Empty(t, receiver.take())
+
+		_, _, body := ts.get(t, "/settings/webhooks")
+		assert.Contains(t, body, "Delivered")
+		assert.Contains(t, body, "HTTP 200")
+	})
+
+	t.Run("failed deliveries are retried with backoff", func(t *testing.T) {
+		receiver.respond(http.StatusInternalServerError)
+		ts.addGoal(t, "Learn Spanish", day(60))
+
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		assert.Equal(t, []string{"goal.created"}, events(receiver.take(), "/all"))
+
+		_, _, body := ts.get(t, "/settings/webhooks")
+		assert.Contains(t, body, "HTTP 500")
+		assert.Contains(t, body, "Next attempt")
+
+		// The next attempt waits for the backoff.
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		assert.Empty(t, receiver.take())
+
+		_, err := app.db.Exec("UPDATE webhook_deliveries SET next_attempt_at = 0 WHERE status = 'pending'")
+		assert.NoError(t, err)
+		receiver.respond(http.StatusOK)
+
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		received := receiver.take()
+		if assert.Len(t, received, 1) {
+			assert.Equal(t, "goal.created", received[0].envelope.Event)
+		}
+
+		var status string
+		var attempts int
+		err = app.db.QueryRow("SELECT status, attempts FROM webhook_deliveries WHERE id = ?", received[0].envelope.ID).Scan(&status, &attempts)
+		assert.NoError(t, err)
+		assert.Equal(t, "delivered", status)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("deliveries fail after the last attempt", func(t *testing.T) {
+		receiver.respond(http.StatusGone)
+		ts.addGoal(t, "Read 20 books", day(90))
+
+		_, err := app.db.Exec("UPDATE webhook_deliveries SET attempts = ? WHERE status = 'pending'", webhooks.MaxAttempts-1)
+		assert.NoError(t, err)
+
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		assert.Len(t, receiver.take(), 1)
+
+		_, err = app.db.Exec("UPDATE webhook_deliveries SET next_attempt_at = 0")
+		assert.NoError(t, err)
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		assert.Empty(t, receiver.take())
+
+		_, _, body := ts.get(t, "/settings/webhooks")
+		assert.Contains(t, body, "Failed")
+		assert.Contains(t, body, "unexpected response 410 Gone")
+		receiver.respond(http.StatusOK)
+	})
+
+	t.Run("deliveries are redelivered", func(t *testing.T) {
+		deliveries, err := app.services.webhooks.GetRecentDeliveries(ctx, 1)
+		assert.NoError(t, err)
+		failed := deliveries[0]
+		assert.Equal(t, "failed", failed.Status)
+
+		code, _, _ := ts.postForm(t, "/settings/webhooks/redeliver", url.Values{"id": {strconv.FormatInt(failed.ID, 10)}})
+		assert.Equal(t, http.StatusSeeOther, code)
+
+		assert.NoError(t, app.deliverWebhooks(ctx))
+		received := receiver.take()
+		if assert.Len(t, received, 1) {
+			assert.Equal(t, "goal.created", received[0].envelope.Event)
+			assert.Equal(t, failed.Payload, string(received[0].envelope.Data))
+			assert.NotEqual(t, failed.ID, received[0].envelope.ID)
+		}
+
+		code, _, _ = ts.postForm(t, "/settings/webhooks/redeliver", url.Values{"id": {"9999"}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("webhooks are limited and deleted", func(t *testing.T) {
+		for i := 2; i < webhooks.MaxWebhooks; i++ {
+			code, _, _ := ts.postForm(t, "/settings/webhooks", url.Values{"url": {fmt.Sprintf("%s/%d", hooks.URL, i)}, "events": {"share.created"}})
+			assert.Equal(t, http.StatusSeeOther, code)
+		}
+		code, _, body := ts.postForm(t, "/settings/webhooks", url.Values{"url": {hooks.URL + "/more"}, "events": {"share.created"}})
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, body, "You can add up to 5 webhooks.")
+
+		all, err := app.services.webhooks.GetAll(ctx, 1)
+		assert.NoError(t, err)
+		for _, hook := range all {
+			code, _, _ := ts.postForm(t, "/settings/webhooks/delete", url.Values{"id": {strconv.FormatInt(hook.ID, 10)}})
+			assert.Equal(t, http.StatusSeeOther, code)
+		}
+
+		code, _, body = ts.get(t, "/settings/webhooks")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "No webhooks yet.")
+		assert.Contains(t, body, "No deliveries yet.")
+
+		code, _, _ = ts.postForm(t, "/settings/webhooks/delete", url.Values{"id": {strconv.FormatInt(all[0].ID, 10)}})
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+}
@@ -20,6 +20,9 @@ import (
 
 type flash struct {
 	Content string
+	// UndoURL is set when the flash offers to undo the action. The undo
+	// button posts to it.
+	UndoURL string
 }
 
 func (app *app) render(w http.ResponseWriter, r *http.Request, status int, page page.Page, data any) {
@@ -47,6 +50,30 @@ func (app *app) render(w http.ResponseWriter, r *http.Request, status int, page
 	}
 }
 
+// renderPartial renders a single named template of a page without its
+// layout. It answers htmx requests that swap only a part of the page.
+func (app *app) renderPartial(w http.ResponseWriter, r *http.Request, status int, page page.Page, name string, data any) {
+	ts, ok := app.templateCache[page.Name()]
+	if !ok {
+		err := fmt.Errorf("template not found in cache; page: %s", page.Name())
+		app.renderError(w, r, err, "Error loading this page.")
+		return
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := ts.ExecuteTemplate(buf, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+
+	if _, err := buf.WriteTo(w); err != nil {
+		app.logger.ErrorContext(r.Context(), "failed to write response", slog.String("msg", err.Error()))
+	}
+}
+
 func (app *app) renderError(w http.ResponseWriter, r *http.Request, err error, userMessage string) {
 	app.logger.ErrorContext(r.Context(), "error occured", slog.String("msg", err.Error()))
 
@@ -140,7 +167,10 @@ func generateTraceID() string {
 func (app *app) flash(ctx context.Context) *flash {
 	if flashMsg := app.sessionManager.PopString(ctx,
 		"flash"); flashMsg != "" {
-		return &flash{Content: flashMsg}
+		return &flash{
+			Content: flashMsg,
+			UndoURL: app.sessionManager.PopString(ctx, "flash_undo"),
+		}
 	}
 	return nil
 }
@@ -149,6 +179,13 @@ func (app *app) putFlash(ctx context.Context, msg string) {
 	app.sessionManager.Put(ctx, "flash", msg)
 }
 
+// putUndoFlash is like putFlash but the message offers an undo button that
+// posts to undoURL.
+func (app *app) putUndoFlash(ctx context.Context, msg, undoURL string) {
+	app.sessionManager.Put(ctx, "flash", msg)
+	app.sessionManager.Put(ctx, "flash_undo", undoURL)
+}
+
 func commonHeaders(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Referrer-Policy", "origin-when-cross-origin")
@@ -174,13 +211,25 @@ func (app *app) logRequest(next http.Handler) http.Handler {
 }
 
 type limiters struct {
-	mu sync.Mutex
-	m  map[string]*rate.Limiter
+	mu    sync.Mutex
+	m     map[string]*rate.Limiter
+	limit rate.Limit
+	burst int
 }
 
+// newLimiters returns limiters that allow 60 requests per minute (1 request
+// per second with burst of 5) for each IP.
 func newLimiters() *limiters {
+	return newLimitersWithRate(rate.Every(time.Second), 5)
+}
+
+// newLimitersWithRate returns limiters that allow limit requests per second
+// with the given burst for each IP.
+func newLimitersWithRate(limit rate.Limit, burst int) *limiters {
 	return &limiters{
-		m: make(map[string]*rate.Limiter),
+		m:     make(map[string]*rate.Limiter),
+		limit: limit,
+		burst: burst,
 	}
 }
 
@@ -190,8 +239,7 @@ func (l *limiters) get(ip string) *rate.Limiter {
 
 	limiter, exists := l.m[ip]
 	if !exists {
-		// Allow 60 requests per minute (1 request per second with burst of 5)
-		limiter = rate.NewLimiter(rate.Every(time.Second), 5)
+		limiter = rate.NewLimiter(l.limit, l.burst)
 
 		l.m[ip] = limiter
 	}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// startJobs starts the background jobs. They run until stopJobs is called and
+// are tracked by app.wg, so shutdown can wait for them to finish.
+func (app *app) startJobs() {
+	ctx, stop := context.WithCancel(context.Background())
+	app.stopJobs = stop
+
+	app.schedule(ctx, "purge trash", trashPurgeInterval, app.purgeTrash)
+	app.schedule(ctx, "auto archive", autoArchiveInterval, app.autoArchive)
+	app.schedule(ctx, "prune reschedules", reschedulePruneInterval, app.pruneReschedules)
+	app.schedule(ctx, "send reminders", reminderInterval, app.sendReminders)
+	app.schedule(ctx, "send digests", digestInterval, app.sendDigests)
+	app.schedule(ctx, "send share updates", shareUpdateInterval, app.sendShareUpdates)
+	app.schedule(ctx, "deliver webhooks", webhookInterval, app.deliverWebhooks)
+}
+
+// schedule runs job in the background with runJob.
+func (app *app) schedule(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+		app.runJob(ctx, name, interval, job)
+	}()
+}
+
+// runJob calls job right away and then every interval until ctx is done.
+// Failures are logged and the job is retried on the next tick.
+func (app *app) runJob(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			app.logger.Error(name+" failure", slog.String("msg", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"html/template"
 	"log"
 	"log/slog"
@@ -8,18 +10,35 @@ import (
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/bit8bytes/goalkeepr/internal/flags"
+	"github.com/bit8bytes/goalkeepr/internal/mailer"
+	"github.com/bit8bytes/goalkeepr/internal/signing"
 	_ "modernc.org/sqlite"
 )
 
 type app struct {
 	config         *flags.Options
 	logger         *slog.Logger
+	db             *sql.DB
 	templateCache  map[string]*template.Template
 	sessionManager *scs.SessionManager
 	services       *services
 	limiters       *limiters
+	// commentLimiters limit comments on share pages.
+	commentLimiters *limiters
+	// reactionLimiters limit reactions on share pages.
+	reactionLimiters *limiters
+	// subscribeLimiters limit subscriptions to share links.
+	subscribeLimiters *limiters
+	// viewerSigner signs the cookie that tells share page viewers apart.
+	viewerSigner *signing.Signer
+	// unsubscribeSigner signs the user IDs in unsubscribe links.
+	unsubscribeSigner *signing.Signer
+	mailer            mailer.Sender
+	emails            *mailer.Templates
 
 	wg sync.WaitGroup
+	// stopJobs stops the background jobs started by startJobs.
+	stopJobs context.CancelFunc
 }
 
 func main() {
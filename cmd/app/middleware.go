@@ -32,13 +32,34 @@ func (app *app) withAuth(next http.HandlerFunc) http.Handler {
 }
 
 func (app *app) withRate(next http.Handler) http.Handler {
+	return app.withLimiters(app.limiters, next)
+}
+
+// withCommentRate limits how often a visitor can comment on shared goals,
+// which is much less often than they can sign in.
+func (app *app) withCommentRate(next http.Handler) http.Handler {
+	return app.withLimiters(app.commentLimiters, next)
+}
+
+// withSubscribeRate limits how often a visitor can subscribe to share links,
+// as every subscription sends an email.
+func (app *app) withSubscribeRate(next http.Handler) http.Handler {
+	return app.withLimiters(app.subscribeLimiters, next)
+}
+
+// withReactionRate limits how often a visitor can react to shared goals.
+func (app *app) withReactionRate(next http.Handler) http.Handler {
+	return app.withLimiters(app.reactionLimiters, next)
+}
+
+func (app *app) withLimiters(l *limiters, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ip, _, err := net.SplitHostPort(r.RemoteAddr)
 		if err != nil {
 			ip = r.RemoteAddr // fallback
 		}
 
-		limiter := app.limiters.get(ip)
+		limiter := l.get(ip)
 
 		if !limiter.Allow() {
 			app.render(w, r, http.StatusTooManyRequests, page.RateLimitExceeded, nil)
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// reminderInterval is how often due goals are checked for reminders.
+const reminderInterval = 15 * time.Minute
+
+// ReminderEmailData contains data for the reminder email.
+type ReminderEmailData struct {
+	Goals       []EmailGoal
+	SettingsURL string
+}
+
+// sendReminders emails every user about their goals that reached one of their
+// reminder days. Reminders are recorded before they are sent, so none is sent
+// twice, and given back when sending fails, so they are retried.
+func (app *app) sendReminders(ctx context.Context) error {
+	now := time.Now()
+
+	batches, err := app.services.reminders.Claim(ctx, now)
+	errs := []error{err}
+
+	for _, batch := range batches {
+		data := ReminderEmailData{SettingsURL: app.config.BaseURL + "/settings"}
+		for _, goal := range batch.Goals {
+			data.Goals = append(data.Goals, app.emailGoal(goal.ID, goal.Goal, goal.Due, now))
+		}
+
+		msg, err := app.emails.Render(batch.Email, "reminder", data)
+		if err == nil {
+			err = app.mailer.Send(ctx, msg)
+		}
+		if err != nil {
+			// The context may be done already, releasing must still happen.
+			errs = append(errs, err, app.services.reminders.Release(context.WithoutCancel(ctx), batch))
+			continue
+		}
+
+		app.logger.Info("sent reminders", "user_id", batch.UserID, "goals", len(batch.Goals))
+	}
+
+	return errors.Join(errs...)
+}
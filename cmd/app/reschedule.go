@@ -0,0 +1,15 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// reschedulePruneInterval is how often expired reschedules are removed.
+const reschedulePruneInterval = time.Hour
+
+// pruneReschedules removes reschedules older than goals.RescheduleUndoWindow.
+// They can no longer be undone, so they only take up space.
+func (app *app) pruneReschedules(ctx context.Context) error {
+	return app.services.goals.PruneReschedules(ctx, time.Now())
+}
@@ -22,7 +22,18 @@ func (app *app) routes() http.Handler {
 	mux.Handle("POST /signin", app.withRate(http.HandlerFunc(app.postSignIn)))
 	mux.HandleFunc("POST /signout", app.postSignOut)
 
+	mux.HandleFunc("GET /digest/unsubscribe/{token}", app.getUnsubscribe)
+	mux.HandleFunc("POST /digest/unsubscribe/{token}", app.postUnsubscribe)
+
 	mux.HandleFunc("GET /s/{id}", app.getShare)
+	mux.HandleFunc("GET /s/{id}/goals/{goalId}/attachments/{attachmentId}", app.getSharedAttachment)
+	mux.Handle("POST /s/{id}/goals/{goalId}/comments", app.withCommentRate(http.HandlerFunc(app.postShareComment)))
+	mux.Handle("POST /s/{id}/subscribe", app.withSubscribeRate(http.HandlerFunc(app.postShareSubscribe)))
+	mux.HandleFunc("GET /s/{id}/subscriptions/{token}/confirm", app.getConfirmSubscription)
+	mux.HandleFunc("POST /s/{id}/subscriptions/{token}/confirm", app.postConfirmSubscription)
+	mux.HandleFunc("GET /s/{id}/subscriptions/{token}/unsubscribe", app.getShareUnsubscribe)
+	mux.HandleFunc("POST /s/{id}/subscriptions/{token}/unsubscribe", app.postShareUnsubscribe)
+	mux.Handle("POST /s/{id}/goals/{goalId}/reactions", app.withReactionRate(http.HandlerFunc(app.postShareReaction)))
 
 	mux.Handle("GET /goals", app.withAuth(app.getGoals))
 	mux.Handle("GET /goals/add/{$}", app.withAuth(app.getAddGoal))
@@ -30,17 +41,70 @@ func (app *app) routes() http.Handler {
 	mux.Handle("GET /goals/share/{$}", app.withAuth(app.getShareGoals))
 	mux.Handle("DELETE /goals/share/{id}", app.withAuth(app.deleteShare))
 	mux.Handle("POST /goals/share/create", app.withAuth(app.postCreateShare))
+	mux.Handle("PATCH /goals/share/{id}", app.withAuth(app.patchShare))
+	mux.Handle("GET /goals/trash", app.withAuth(app.getTrash))
+	mux.Handle("GET /goals/archive", app.withAuth(app.getArchive))
+	mux.Handle("GET /goals/roadmap", app.withAuth(app.getRoadmap))
+	mux.Handle("GET /goals/overview", app.withAuth(app.getOverview))
+	mux.Handle("GET /goals/search", app.withAuth(app.getSearch))
+	mux.Handle("POST /goals/bulk", app.withAuth(app.postBulkGoals))
+	mux.Handle("POST /goals/preview", app.withAuth(app.postPreviewDescription))
+	mux.Handle("GET /goals/templates", app.withAuth(app.getTemplates))
+	mux.Handle("POST /goals/templates", app.withAuth(app.postSaveTemplate))
+	mux.Handle("POST /goals/templates/use", app.withAuth(app.postUseTemplate))
+	mux.Handle("POST /goals/templates/delete", app.withAuth(app.postDeleteTemplate))
+	mux.Handle("GET /goals/comments", app.withAuth(app.getComments))
+	mux.Handle("POST /goals/comments/approve", app.withAuth(app.postApproveComment))
+	mux.Handle("POST /goals/comments/delete", app.withAuth(app.postDeleteComment))
 	mux.Handle("GET /goals/{id}", app.withAuth(app.getEditGoal))
 	mux.Handle("POST /goals/{id}", app.withAuth(app.postEditGoal))
 	mux.Handle("POST /goals/{id}/delete", app.withAuth(app.deleteEditGoal))
 	mux.Handle("DELETE /goals/{id}", app.withAuth(app.deleteEditGoal))
+	mux.Handle("POST /goals/{id}/restore", app.withAuth(app.postRestoreGoal))
+	mux.Handle("POST /goals/{id}/purge", app.withAuth(app.postPurgeGoal))
+	mux.Handle("POST /goals/{id}/archive", app.withAuth(app.postArchiveGoal))
+	mux.Handle("POST /goals/{id}/unarchive", app.withAuth(app.postUnarchiveGoal))
+	mux.Handle("POST /goals/{id}/dependencies", app.withAuth(app.postAddDependency))
+	mux.Handle("POST /goals/{id}/dependencies/{dependsOnId}/delete", app.withAuth(app.postRemoveDependency))
+	mux.Handle("POST /goals/{id}/revisions/{revisionId}/restore", app.withAuth(app.postRestoreRevision))
+	mux.Handle("GET /goals/{id}/reschedule", app.withAuth(app.getReschedule))
+	mux.Handle("POST /goals/{id}/reschedule", app.withAuth(app.postReschedule))
+	mux.Handle("POST /goals/{id}/reschedule/{rescheduleId}/undo", app.withAuth(app.postUndoReschedule))
+	mux.Handle("GET /goals/{id}/duplicate", app.withAuth(app.getDuplicateGoal))
+	mux.Handle("POST /goals/{id}/duplicate", app.withAuth(app.postDuplicateGoal))
 	mux.Handle("POST /goals/{id}/criteria", app.withAuth(app.postAddSuccessCriteria))
 	mux.Handle("POST /goals/{id}/criteria/update", app.withAuth(app.postUpdateSuccessCriteria))
 	mux.Handle("POST /goals/{id}/criteria/{criteriaId}/toggle", app.withAuth(app.postToggleSuccessCriteria))
 	mux.Handle("POST /goals/{id}/criteria/{criteriaId}", app.withAuth(app.deleteSuccessCriteria))
+	mux.Handle("POST /goals/{id}/criteria/{criteriaId}/restore", app.withAuth(app.postRestoreSuccessCriteria))
+	mux.Handle("POST /goals/{id}/criteria/{criteriaId}/purge", app.withAuth(app.postPurgeSuccessCriteria))
+	mux.Handle("POST /goals/{id}/key-results", app.withAuth(app.postAddKeyResult))
+	mux.Handle("POST /goals/{id}/key-results/{keyResultId}/check-ins", app.withAuth(app.postCheckIn))
+	mux.Handle("POST /goals/{id}/key-results/{keyResultId}/delete", app.withAuth(app.postDeleteKeyResult))
+	mux.Handle("POST /goals/{id}/attachments/link", app.withAuth(app.postAddLinkAttachment))
+	mux.Handle("POST /goals/{id}/attachments/file", app.withAuth(app.postUploadAttachment))
+	mux.Handle("GET /goals/{id}/attachments/{attachmentId}", app.withAuth(app.getAttachment))
+	mux.Handle("POST /goals/{id}/attachments/{attachmentId}/public", app.withAuth(app.postAttachmentPublic))
+	mux.Handle("POST /goals/{id}/attachments/{attachmentId}/delete", app.withAuth(app.postDeleteAttachment))
+	mux.Handle("POST /goals/{id}/journal", app.withAuth(app.postAddJournalEntry))
+	mux.Handle("POST /goals/{id}/journal/public", app.withAuth(app.postJournalPublic))
+	mux.Handle("GET /goals/{id}/journal/{entryId}", app.withAuth(app.getJournalEntry))
+	mux.Handle("GET /goals/{id}/journal/{entryId}/edit", app.withAuth(app.getEditJournalEntry))
+	mux.Handle("PUT /goals/{id}/journal/{entryId}", app.withAuth(app.putJournalEntry))
+	mux.Handle("DELETE /goals/{id}/journal/{entryId}", app.withAuth(app.deleteJournalEntry))
+	mux.Handle("POST /goals/{id}/journal/{entryId}/delete", app.withAuth(app.deleteJournalEntry))
 
 	mux.Handle("GET /settings", app.withAuth(app.getSettings))
 	mux.Handle("POST /settings/branding", app.withAuth(app.postBranding))
+	mux.Handle("POST /settings/archive", app.withAuth(app.postArchiveSettings))
+	mux.Handle("POST /settings/risk", app.withAuth(app.postRiskSettings))
+	mux.Handle("POST /settings/reminders", app.withAuth(app.postReminderSettings))
+	mux.Handle("POST /settings/digest", app.withAuth(app.postDigestSettings))
+	mux.Handle("POST /settings/digest/preview", app.withAuth(app.postDigestPreview))
+	mux.Handle("GET /settings/webhooks", app.withAuth(app.getWebhooks))
+	mux.Handle("POST /settings/webhooks", app.withAuth(app.postWebhook))
+	mux.Handle("POST /settings/webhooks/delete", app.withAuth(app.postDeleteWebhook))
+	mux.Handle("POST /settings/webhooks/redeliver", app.withAuth(app.postRedeliverWebhook))
 	mux.Handle("DELETE /settings/delete-user", app.withAuth(app.deleteUser))
 
 	mux.HandleFunc("GET /api/healthz", app.getHealthz)
@@ -29,6 +29,12 @@ func (app *app) serve() error {
 		},
 	}
 
+	// Wait for the background jobs however the server stops.
+	defer func() {
+		app.stopJobs()
+		app.wg.Wait()
+	}()
+
 	shutdownError := make(chan error)
 
 	go func() {
@@ -48,6 +54,7 @@ func (app *app) serve() error {
 
 		app.logger.Info("completing background tasks", "addr", srv.Addr)
 
+		app.stopJobs()
 		app.wg.Wait()
 		shutdownError <- nil
 	}()
@@ -1,22 +1,39 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/attachments"
 	"github.com/bit8bytes/goalkeepr/internal/branding"
+	"github.com/bit8bytes/goalkeepr/internal/comments"
 	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/goalkeepr/internal/digest"
 	"github.com/bit8bytes/goalkeepr/internal/flags"
 	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/journal"
+	"github.com/bit8bytes/goalkeepr/internal/key_results"
 	"github.com/bit8bytes/goalkeepr/internal/logger"
+	"github.com/bit8bytes/goalkeepr/internal/mailer"
+	"github.com/bit8bytes/goalkeepr/internal/preferences"
+	"github.com/bit8bytes/goalkeepr/internal/reactions"
+	"github.com/bit8bytes/goalkeepr/internal/reminders"
+	"github.com/bit8bytes/goalkeepr/internal/search"
 	"github.com/bit8bytes/goalkeepr/internal/share"
+	"github.com/bit8bytes/goalkeepr/internal/signing"
+	"github.com/bit8bytes/goalkeepr/internal/subscriptions"
 	"github.com/bit8bytes/goalkeepr/internal/success_criteria"
+	"github.com/bit8bytes/goalkeepr/internal/templates"
 	"github.com/bit8bytes/goalkeepr/internal/users"
+	"github.com/bit8bytes/goalkeepr/internal/webhooks"
+	"github.com/bit8bytes/goalkeepr/ui"
 
 	"github.com/alexedwards/scs/sqlite3store"
 	"github.com/alexedwards/scs/v2"
+	"golang.org/x/time/rate"
 )
 
 //go:embed "db/migrations"
@@ -25,6 +42,9 @@ var migrations embed.FS
 const (
 	// GoalkeeprCookie is the session cookie name used across the application.
 	GoalkeeprCookie = "goalkeepr"
+	// ViewerCookie holds the signed ID of a share page viewer, so each
+	// viewer can react to a goal only once.
+	ViewerCookie = "goalkeepr_viewer"
 
 	HTMLDateFormat = "2006-01-02"
 )
@@ -35,6 +55,18 @@ type services struct {
 	branding        *branding.Service
 	share           *share.Service
 	successCriteria *success_criteria.Service
+	preferences     *preferences.Service
+	keyResults      *key_results.Service
+	journal         *journal.Service
+	search          *search.Service
+	templates       *templates.Service
+	attachments     *attachments.Service
+	comments        *comments.Service
+	reactions       *reactions.Service
+	reminders       *reminders.Service
+	digest          *digest.Service
+	subscriptions   *subscriptions.Service
+	webhooks        *webhooks.Service
 }
 
 // newApp creates and initializes a new app instance with the given configuration.
@@ -86,6 +118,31 @@ func newApp(cfg *flags.Options) (*app, error) {
 	sessionManager.Cookie.Name = GoalkeeprCookie
 	sessionManager.Store = sqlite3store.New(db)
 
+	attachmentStore, err := attachments.NewStore(cfg.Attachments.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("attachments store failure: %w", err)
+	}
+
+	viewerKey, err := signing.LoadKey(context.Background(), db, "viewer")
+	if err != nil {
+		return nil, fmt.Errorf("signing key failure: %w", err)
+	}
+
+	unsubscribeKey, err := signing.LoadKey(context.Background(), db, "unsubscribe")
+	if err != nil {
+		return nil, fmt.Errorf("signing key failure: %w", err)
+	}
+
+	emails, err := mailer.NewTemplates(ui.Emails())
+	if err != nil {
+		return nil, fmt.Errorf("email templates failure: %w", err)
+	}
+
+	var sender mailer.Sender = mailer.NewLog(logger)
+	if cfg.SMTP.Host != "" {
+		sender = mailer.NewSMTP(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+	}
+
 	// q := &queries{}
 
 	services := &services{
@@ -94,15 +151,44 @@ func newApp(cfg *flags.Options) (*app, error) {
 		branding:        branding.NewService(db),
 		share:           share.NewService(db),
 		successCriteria: success_criteria.NewService(db),
+		preferences:     preferences.NewService(db),
+		keyResults:      key_results.NewService(db),
+		journal:         journal.NewService(db),
+		search:          search.NewService(db),
+		templates:       templates.NewService(db),
+		attachments:     attachments.NewService(db, attachmentStore, int64(cfg.Attachments.QuotaMB)<<20),
+		comments:        comments.NewService(db),
+		reactions:       reactions.NewService(db),
+		reminders:       reminders.NewService(db),
+		digest:          digest.NewService(db),
+		subscriptions:   subscriptions.NewService(db),
+		webhooks:        webhooks.NewService(db, webhooks.NewClient(cfg.Webhooks.AllowPrivate)),
 	}
 
 	app := &app{
 		config:         cfg,
 		logger:         logger,
+		db:             db,
 		templateCache:  templateCache,
 		sessionManager: sessionManager,
 		services:       services,
 		limiters:       newLimiters(),
+		// Allow 5 comments in a row, then one per minute.
+		commentLimiters: newLimitersWithRate(rate.Every(time.Minute), 5),
+		// Reactions are toggled with a click, so allow quick bursts.
+		reactionLimiters: newLimitersWithRate(rate.Every(time.Second), 10),
+		// Every subscription sends an email, so allow a few per hour.
+		subscribeLimiters: newLimitersWithRate(rate.Every(10*time.Minute), 5),
+		viewerSigner:      signing.NewSigner(viewerKey),
+		unsubscribeSigner: signing.NewSigner(unsubscribeKey),
+		mailer:            sender,
+		emails:            emails,
+	}
+
+	if cfg.Jobs.Disabled {
+		app.stopJobs = func() {}
+	} else {
+		app.startJobs()
 	}
 
 	return app, nil
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/subscriptions"
+)
+
+// shareUpdateInterval is how often subscribers of share links are notified
+// of updates.
+const shareUpdateInterval = 15 * time.Minute
+
+// defaultShareTitle names shared timelines without a branding title.
+const defaultShareTitle = "a shared Goalkeepr timeline"
+
+// ShareConfirmEmailData contains data for the email that confirms a
+// subscription to a share link.
+type ShareConfirmEmailData struct {
+	Title      string
+	ShareURL   string
+	ConfirmURL string
+}
+
+// ShareUpdatesEmailData contains data for the email that notifies a
+// subscriber of updates on a share link.
+type ShareUpdatesEmailData struct {
+	Title          string
+	Updates        []subscriptions.Update
+	ShareURL       string
+	UnsubscribeURL string
+}
+
+// sendShareUpdates emails every subscriber of a share link about the goals
+// that were added, achieved or rescheduled on its timeline since the last
+// time. Updates are recorded before they are sent, so none is sent twice, and
+// given back when sending fails, so they are retried.
+func (app *app) sendShareUpdates(ctx context.Context) error {
+	errs := []error{app.services.subscriptions.Prune(ctx, time.Now())}
+
+	batches, err := app.services.subscriptions.Claim(ctx)
+	errs = append(errs, err)
+
+	for _, batch := range batches {
+		err := app.sendShareUpdate(ctx, batch)
+		if err != nil {
+			// The context may be done already, releasing must still happen.
+			errs = append(errs, err, app.services.subscriptions.Release(context.WithoutCancel(ctx), batch))
+			continue
+		}
+
+		app.logger.Info("sent share updates", "subscription_id", batch.SubscriptionID, "updates", len(batch.Updates))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (app *app) sendShareUpdate(ctx context.Context, batch subscriptions.Batch) error {
+	title, err := app.shareTitle(ctx, batch.UserID)
+	if err != nil {
+		return err
+	}
+
+	data := ShareUpdatesEmailData{
+		Title:          title,
+		Updates:        batch.Updates,
+		ShareURL:       app.config.BaseURL + "/s/" + batch.PublicID,
+		UnsubscribeURL: app.subscriptionURL(batch.PublicID, batch.Token, "unsubscribe"),
+	}
+
+	msg, err := app.emails.Render(batch.Email, "share_updates", data)
+	if err != nil {
+		return err
+	}
+	msg.Unsubscribe = data.UnsubscribeURL
+
+	return app.mailer.Send(ctx, msg)
+}
+
+// sendShareConfirmation emails the link that confirms a subscription.
+func (app *app) sendShareConfirmation(ctx context.Context, userID int64, publicID string, sub subscriptions.Subscription) error {
+	title, err := app.shareTitle(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	msg, err := app.emails.Render(sub.Email, "share_confirm", ShareConfirmEmailData{
+		Title:      title,
+		ShareURL:   app.config.BaseURL + "/s/" + publicID,
+		ConfirmURL: app.subscriptionURL(publicID, sub.Token, "confirm"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return app.mailer.Send(ctx, msg)
+}
+
+// shareTitle returns the branding title of the timelines a user shares.
+func (app *app) shareTitle(ctx context.Context, userID int64) (string, error) {
+	b, err := app.services.branding.GetByUserID(ctx, int(userID))
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	if title := b.ToView().Title; title != "" {
+		return title, nil
+	}
+	return defaultShareTitle, nil
+}
+
+// subscriptionURL returns the link that confirms or ends a subscription.
+func (app *app) subscriptionURL(publicID, token, action string) string {
+	return app.config.BaseURL + "/s/" + publicID + "/subscriptions/" + token + "/" + action
+}
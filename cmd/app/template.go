@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/markdown"
 	"github.com/bit8bytes/goalkeepr/ui"
 	"github.com/bit8bytes/goalkeepr/ui/page"
 )
@@ -18,6 +19,8 @@ type templateData struct {
 	// - GoalsPageData: for the user's goals page
 	// - EditGoalPageData: for the edit goal page
 	// - ShareGoalsPageData: for the share goals management page
+	// - TrashPageData: for the trash page
+	// - ArchivePageData: for the archive page
 	// - ErrorPageData: for error pages
 	// - map[string]any: for settings page (Account, Branding forms)
 	Data            any
@@ -48,9 +51,12 @@ func newTemplateCache() (map[string]*template.Template, error) {
 // defaultFunctions returns the standard template functions.
 func defaultFunctions() template.FuncMap {
 	return template.FuncMap{
+		"add":      func(a, b int) int { return a + b },
 		"sub":      func(a, b int) int { return a - b },
 		"mod":      func(a, b int) int { return a % b },
 		"unixTime": func(timestamp int64) time.Time { return time.Unix(timestamp, 0) },
+		"now":      time.Now,
+		"markdown": markdown.NewCache(markdown.DefaultCacheSize).Render,
 	}
 }
 
@@ -2,17 +2,29 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/bit8bytes/goalkeepr/internal/flags"
+	"github.com/bit8bytes/goalkeepr/internal/mailer"
 )
 
 func newTestApplication(tb testing.TB) *app {
+	return newTestApplicationWithConfig(tb, newTestConfig(tb))
+}
+
+// newTestConfig returns the configuration of test applications, with an
+// in-memory database.
+func newTestConfig(tb testing.TB) *flags.Options {
 	cfg := &flags.Options{
 		Env:  flags.SetEnv("prod"),
 		Port: 8080,
@@ -21,15 +33,68 @@ func newTestApplication(tb testing.TB) *app {
 			Dsn    string
 		}{Driver: "sqlite", Dsn: ":memory:"},
 	}
+	cfg.Trash.RetentionDays = 30
+	cfg.Attachments.Dir = tb.TempDir()
+	cfg.Attachments.QuotaMB = 1
+	cfg.BaseURL = "https://goalkeepr.test"
+	// Tests run jobs by hand, so they can swap the mailer first.
+	cfg.Jobs.Disabled = true
 
+	return cfg
+}
+
+// newTestApplicationWithConfig creates an app with cfg and stops its
+// background jobs when the test is done.
+func newTestApplicationWithConfig(tb testing.TB, cfg *flags.Options) *app {
 	app, err := newApp(cfg)
 	if err != nil {
 		tb.Fatal(err)
 	}
 
+	tb.Cleanup(func() {
+		app.stopJobs()
+		app.wg.Wait()
+	})
+
 	return app
 }
 
+// testMailer records the messages the app sends instead of sending them.
+type testMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+	// err is returned by Send while set, and nothing is recorded.
+	err error
+}
+
+func (m *testMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// take returns the messages sent since the last call.
+func (m *testMailer) take() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sent := m.sent
+	m.sent = nil
+	return sent
+}
+
+func (m *testMailer) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+}
+
 // Define a custom testServer type which embeds a httptest.Server instance.
 type testServer struct {
 	*httptest.Server
@@ -79,6 +144,25 @@ func (ts *testServer) signin(t *testing.T, email, password string) {
 
 }
 
+// addGoal creates a goal for the signed in user and returns its ID.
+func (ts *testServer) addGoal(t *testing.T, goal, due string) int {
+	form := url.Values{}
+	form.Add("goal", goal)
+	form.Add("due", due)
+
+	code, headers, _ := ts.postForm(t, "/goals/add/", form)
+	if code != http.StatusSeeOther {
+		t.Fatalf("add goal failed: expected redirect, got status %d", code)
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(headers.Get("Location"), "/goals/"))
+	if err != nil {
+		t.Fatalf("add goal failed: unexpected location %q", headers.Get("Location"))
+	}
+
+	return id
+}
+
 // Implement a get() method on our custom testServer type. This makes a GET
 // request to a given url path using the test server client, and returns the
 // response status code, headers and body.
@@ -116,3 +200,67 @@ func (ts *testServer) postForm(tb testing.TB, urlPath string, form url.Values) (
 	// Return the response status, headers and body.
 	return rs.StatusCode, rs.Header, string(body)
 }
+
+// htmx makes a request with a form body and the HX-Request header, like htmx
+// does for hx-get, hx-post, hx-put, hx-patch and hx-delete.
+func (ts *testServer) htmx(tb testing.TB, method, urlPath string, form url.Values) (int, http.Header, string) {
+	req, err := http.NewRequest(method, ts.URL+urlPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+
+	rs, err := ts.Client().Do(req)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	defer rs.Body.Close()
+	body, err := io.ReadAll(rs.Body)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	body = bytes.TrimSpace(body)
+
+	return rs.StatusCode, rs.Header, string(body)
+}
+
+// upload posts a multipart form with fields and a file named fileName, like a
+// browser does for forms with enctype="multipart/form-data".
+func (ts *testServer) upload(tb testing.TB, urlPath string, fields url.Values, fileName string, content []byte) (int, http.Header, string) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for key, values := range fields {
+		for _, v := range values {
+			if err := w.WriteField(key, v); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+
+	part, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		tb.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		tb.Fatal(err)
+	}
+
+	rs, err := ts.Client().Post(ts.URL+urlPath, w.FormDataContentType(), &buf)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	defer rs.Body.Close()
+	body, err := io.ReadAll(rs.Body)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	body = bytes.TrimSpace(body)
+
+	return rs.StatusCode, rs.Header, string(body)
+}
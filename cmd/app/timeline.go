@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/goalkeepr/internal/timeline"
+)
+
+// upcomingOccurrences is the number of future occurrences shown for each
+// repeating goal on the timeline.
+const upcomingOccurrences = 3
+
+// timelinePage is a page of goals on the timeline.
+type timelinePage struct {
+	// Goals are the goals on the page, without upcoming occurrences.
+	Goals []goals.Goal
+	// Following is the goal right after the page, if there is one.
+	Following *goals.Goal
+	Groups    []timeline.Group
+	// Next is the cursor of the following page, or nil if there is none.
+	Next *goals.Cursor
+	// Years are the years goals and their upcoming occurrences are due in.
+	Years []int
+}
+
+// loadTimeline loads the page of the timeline after the cursor, or the first
+// page if after is nil. Pages hold at least timeline.PageSize goals and end
+// with a whole group, so a group is never split across pages.
+func (app *app) loadTimeline(ctx context.Context, userID int, scope goals.Scope, filter timeline.Filter, after *goals.Cursor) (timelinePage, error) {
+	var page timelinePage
+
+	params := filter.Params(scope)
+	params.After = after
+	params.Limit = timeline.PageSize + 1
+
+	goalList, err := app.services.goals.GetPage(ctx, userID, params)
+	if err != nil {
+		return page, err
+	}
+
+	// end is the start of the first group after the page, if there are
+	// more goals than fit on it.
+	var end time.Time
+	if len(goalList) > timeline.PageSize {
+		goalList = goalList[:timeline.PageSize]
+		last := goalList[len(goalList)-1].Cursor()
+		end = filter.Zoom.Next(filter.Zoom.Start(time.Unix(last.Due, 0)))
+
+		params.After = &last
+		params.DueBefore = end
+		params.Limit = 0
+		rest, err := app.services.goals.GetPage(ctx, userID, params)
+		if err != nil {
+			return page, err
+		}
+		goalList = append(goalList, rest...)
+
+		last = goalList[len(goalList)-1].Cursor()
+		params.After = &last
+		params.DueBefore = time.Time{}
+		params.Limit = 1
+		following, err := app.services.goals.GetPage(ctx, userID, params)
+		if err != nil {
+			return page, err
+		}
+		if len(following) > 0 {
+			page.Following = &following[0]
+		}
+	}
+
+	years, err := app.services.goals.GetYears(ctx, userID, scope)
+	if err != nil {
+		return page, err
+	}
+
+	recurring, err := app.services.goals.GetAllRecurring(ctx, userID, scope)
+	if err != nil {
+		return page, err
+	}
+
+	views := make([]goals.View, len(goalList))
+	for i, goal := range goalList {
+		views[i] = goal.ToView()
+	}
+
+	// Upcoming occurrences are placed like goals: in the groups of this
+	// page, but not in the ones of earlier pages.
+	moreUpcoming := false
+	for _, goal := range recurring {
+		for _, view := range goal.UpcomingViews(upcomingOccurrences) {
+			years = append(years, view.Due.UTC().Year())
+
+			if !filter.Match(view) {
+				continue
+			}
+			if after != nil && !filter.Zoom.Start(view.Due).After(filter.Zoom.Start(time.Unix(after.Due, 0))) {
+				continue
+			}
+			if !end.IsZero() && !view.Due.Before(end) {
+				moreUpcoming = true
+				continue
+			}
+			views = append(views, view)
+		}
+	}
+
+	slices.SortStableFunc(views, func(a, b goals.View) int {
+		return a.Due.Compare(b.Due)
+	})
+	slices.Sort(years)
+
+	page.Goals = goalList
+	page.Groups = timeline.GroupBy(views, filter.Zoom)
+	page.Years = slices.Compact(years)
+	if (page.Following != nil || moreUpcoming) && len(goalList) > 0 {
+		next := goalList[len(goalList)-1].Cursor()
+		page.Next = &next
+	}
+
+	return page, nil
+}
+
+// GoalIDs returns the IDs of the goals shown on the page. Upcoming
+// occurrences have the ID of the goal they repeat.
+func (p timelinePage) GoalIDs() []int64 {
+	ids := []int64{}
+	for _, group := range p.Groups {
+		for _, goal := range group.Goals {
+			ids = append(ids, goal.ID)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// timelinePosition reads the index of the first group and the cursor of a
+// timeline page from the query. The first page has neither.
+func timelinePosition(query url.Values) (int, *goals.Cursor) {
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	cursor, ok := goals.ParseCursor(query.Get("after"))
+	if !ok {
+		return 0, nil
+	}
+	return max(offset, 0), &cursor
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// trashPurgeInterval is how often expired items are removed from the trash.
+const trashPurgeInterval = time.Hour
+
+// purgeTrash permanently deletes goals and success criteria that have been in
+// the trash for longer than the configured retention, and the uploaded files
+// that no attachment refers to anymore.
+func (app *app) purgeTrash(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -app.config.Trash.RetentionDays)
+
+	purgedGoals, err := app.services.goals.PurgeTrash(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	purgedCriteria, err := app.services.successCriteria.PurgeTrash(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	// Purged goals take their attachments with them, but not the files.
+	removedFiles, err := app.services.attachments.RemoveOrphans(ctx)
+	if err != nil {
+		return err
+	}
+
+	if purgedGoals > 0 || purgedCriteria > 0 || removedFiles > 0 {
+		app.logger.Info("purged trash", "goals", purgedGoals, "success_criteria", purgedCriteria, "files", removedFiles)
+	}
+
+	return nil
+}
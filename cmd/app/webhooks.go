@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// webhookInterval is how often queued webhook deliveries are sent. Failed
+// deliveries wait longer, see webhooks.Backoff.
+const webhookInterval = 15 * time.Second
+
+// deliverWebhooks sends the webhook deliveries that are due. Deliveries are
+// claimed before they are sent, so none is sent twice at once, and failed
+// ones stay queued for their next attempt.
+func (app *app) deliverWebhooks(ctx context.Context) error {
+	now := time.Now()
+
+	errs := []error{app.services.webhooks.Prune(ctx, now)}
+
+	deliveries, err := app.services.webhooks.Claim(ctx, now)
+	errs = append(errs, err)
+
+	for _, d := range deliveries {
+		delivered, err := app.services.webhooks.Send(ctx, d, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if delivered {
+			app.logger.Info("delivered webhook", "delivery_id", d.ID, "event", d.Event)
+		} else {
+			app.logger.Warn("webhook delivery failure", "delivery_id", d.ID, "event", d.Event, "attempts", d.Attempts)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,363 @@
+// Package attachments keeps links and uploaded files that belong to goals,
+// like a certificate, a design doc or a photo. Uploads are stored on disk and
+// count towards a quota per user.
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+const (
+	KindLink = "link"
+	KindFile = "file"
+
+	// MaxFileSize is the size of the largest file that can be uploaded.
+	MaxFileSize = 10 << 20
+)
+
+var (
+	ErrFileTooLarge  = errors.New("file is too large")
+	ErrQuotaExceeded = errors.New("upload quota exceeded")
+)
+
+type LinkForm struct {
+	URL                 string `form:"url"`
+	Title               string `form:"title"`
+	validator.Validator `form:"-"`
+}
+
+func (f *LinkForm) Validate() {
+	f.Check(validator.NotBlank(f.URL), "url", "URL cannot be blank")
+	f.Check(validator.MaxChars(f.URL, 2000), "url", "URL cannot be more than 2000 characters")
+	f.Check(f.URL == "" || ValidURL(f.URL), "url", "URL must start with http:// or https://")
+	f.Check(validator.MaxChars(f.Title, 200), "title", "Title cannot be more than 200 characters")
+}
+
+type FileForm struct {
+	Title string `form:"title"`
+	// FileName is the name the file was uploaded with.
+	FileName            string `form:"-"`
+	validator.Validator `form:"-"`
+}
+
+func (f *FileForm) Validate() {
+	f.Check(validator.NotBlank(f.FileName), "file", "Choose a file to upload")
+	f.Check(validator.MaxChars(f.FileName, 255), "file", "File name cannot be more than 255 characters")
+	f.Check(validator.MaxChars(f.Title, 200), "title", "Title cannot be more than 200 characters")
+}
+
+// ValidURL reports whether rawURL is an absolute http or https URL.
+func ValidURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// LinkTitle derives a title from a URL without fetching it, like
+// "example.com/docs/design" for https://www.example.com/docs/design/.
+func LinkTitle(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	title := strings.TrimPrefix(u.Hostname(), "www.")
+	if path := strings.Trim(u.EscapedPath(), "/"); path != "" {
+		if unescaped, err := url.PathUnescape(path); err == nil {
+			path = unescaped
+		}
+		title += "/" + path
+	}
+
+	if runes := []rune(title); len(runes) > 200 {
+		title = string(runes[:199]) + "…"
+	}
+
+	return title
+}
+
+type Service struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	queries *Queries
+	store   *Store
+	// quota is the number of bytes each user can upload.
+	quota int64
+}
+
+func NewService(db *sql.DB, store *Store, quota int64) *Service {
+	return &Service{
+		db:      db,
+		queries: New(db),
+		store:   store,
+		quota:   quota,
+	}
+}
+
+// WithTx returns a Service that runs all queries inside tx. The caller is
+// responsible for committing or rolling back the transaction.
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	return &Service{
+		db:      s.db,
+		tx:      tx,
+		queries: s.queries.WithTx(tx),
+		store:   s.store,
+		quota:   s.quota,
+	}
+}
+
+// inTx runs fn in the transaction of the Service. Without one, fn runs in a
+// new transaction that is committed when fn succeeds.
+func (s *Service) inTx(ctx context.Context, fn func(q *Queries) error) error {
+	return database.InTx(ctx, s.db, s.tx, func(tx *sql.Tx) error {
+		return fn(s.queries.WithTx(tx))
+	})
+}
+
+// AddLink attaches a link to a goal. Without a title, the title is derived
+// from the URL.
+func (s *Service) AddLink(ctx context.Context, goalID, userID int, form *LinkForm) error {
+	title := form.Title
+	if title == "" {
+		title = LinkTitle(form.URL)
+	}
+
+	_, err := s.queries.CreateAttachment(ctx, CreateAttachmentParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+		Kind:   KindLink,
+		Title:  title,
+		Url:    sql.NullString{String: form.URL, Valid: true},
+	})
+	return err
+}
+
+// AddFile stores the content of r and attaches it to a goal. The type of the
+// file is sniffed from its content, the type sent by the browser is never
+// trusted. ErrFileTooLarge is returned for files over MaxFileSize and
+// ErrQuotaExceeded if the file doesn't fit into the quota of the user.
+func (s *Service) AddFile(ctx context.Context, goalID, userID int, form *FileForm, r io.Reader) error {
+	// DetectContentType looks at no more than the first 512 bytes.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	storedName, size, err := s.store.Save(io.MultiReader(bytes.NewReader(head), r), MaxFileSize)
+	if errors.Is(err, errTooLarge) {
+		return ErrFileTooLarge
+	}
+	if err != nil {
+		return err
+	}
+
+	title := form.Title
+	if title == "" {
+		title = form.FileName
+	}
+
+	err = s.inTx(ctx, func(q *Queries) error {
+		used, err := q.GetTotalAttachmentSize(ctx, int64(userID))
+		if err != nil {
+			return err
+		}
+
+		if used+size > s.quota {
+			return ErrQuotaExceeded
+		}
+
+		_, err = q.CreateAttachment(ctx, CreateAttachmentParams{
+			GoalID:      int64(goalID),
+			UserID:      int64(userID),
+			Kind:        KindFile,
+			Title:       title,
+			FileName:    sql.NullString{String: form.FileName, Valid: true},
+			StoredName:  sql.NullString{String: storedName, Valid: true},
+			ContentType: sql.NullString{String: contentType, Valid: true},
+			Size:        size,
+		})
+		return err
+	})
+	if err != nil {
+		if removeErr := s.store.Remove(storedName); removeErr != nil {
+			return errors.Join(err, removeErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) GetAllByGoal(ctx context.Context, goalID, userID int) ([]GoalAttachment, error) {
+	return s.queries.GetAllAttachmentsByGoal(ctx, GetAllAttachmentsByGoalParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+}
+
+// GetAllPublicByGoals returns the attachments of the given goals of a user
+// that are shown on share pages, grouped by goal ID.
+func (s *Service) GetAllPublicByGoals(ctx context.Context, userID int, goalIDs []int64) (map[int64][]GoalAttachment, error) {
+	attachments, err := s.queries.GetAllPublicAttachmentsByGoals(ctx, GetAllPublicAttachmentsByGoalsParams{
+		UserID:  int64(userID),
+		GoalIds: goalIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64][]GoalAttachment)
+	for _, a := range attachments {
+		byGoal[a.GoalID] = append(byGoal[a.GoalID], a)
+	}
+
+	return byGoal, nil
+}
+
+// Open returns a file attachment of a goal together with its content. The
+// caller has to close the file. sql.ErrNoRows is returned if there is no
+// such file for the user.
+func (s *Service) Open(ctx context.Context, id, goalID, userID int) (GoalAttachment, *os.File, error) {
+	a, err := s.queries.GetAttachment(ctx, GetAttachmentParams{
+		ID:     int64(id),
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	return s.open(a, err)
+}
+
+// OpenPublic is like Open but only returns attachments that are shown on
+// share pages.
+func (s *Service) OpenPublic(ctx context.Context, id, goalID, userID int) (GoalAttachment, *os.File, error) {
+	a, err := s.queries.GetPublicAttachment(ctx, GetPublicAttachmentParams{
+		ID:     int64(id),
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	return s.open(a, err)
+}
+
+func (s *Service) open(a GoalAttachment, err error) (GoalAttachment, *os.File, error) {
+	if err != nil {
+		return GoalAttachment{}, nil, err
+	}
+
+	if a.Kind != KindFile {
+		return GoalAttachment{}, nil, sql.ErrNoRows
+	}
+
+	f, err := s.store.Open(a.StoredName.String)
+	if err != nil {
+		return GoalAttachment{}, nil, err
+	}
+
+	return a, f, nil
+}
+
+// SetPublic sets whether an attachment is shown on share pages.
+func (s *Service) SetPublic(ctx context.Context, id, goalID, userID int, public bool) (int, error) {
+	var value int64
+	if public {
+		value = 1
+	}
+
+	result, err := s.queries.SetAttachmentPublic(ctx, SetAttachmentPublicParams{
+		Public: value,
+		ID:     int64(id),
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Delete removes an attachment and its file.
+func (s *Service) Delete(ctx context.Context, id, goalID, userID int) (int, error) {
+	a, err := s.queries.DeleteAttachment(ctx, DeleteAttachmentParams{
+		ID:     int64(id),
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if a.StoredName.Valid {
+		if err := s.store.Remove(a.StoredName.String); err != nil {
+			return 0, err
+		}
+	}
+
+	return 1, nil
+}
+
+// Usage returns the number of bytes a user has uploaded and the quota.
+func (s *Service) Usage(ctx context.Context, userID int) (used, quota int64, err error) {
+	used, err = s.queries.GetTotalAttachmentSize(ctx, int64(userID))
+	return used, s.quota, err
+}
+
+// orphanAge is how old a file without an attachment has to be before it is
+// removed. Younger files may belong to an upload that is still running.
+const orphanAge = time.Hour
+
+// RemoveOrphans deletes stored files whose attachment is gone, like the
+// files of purged goals and deleted users, and returns how many it deleted.
+func (s *Service) RemoveOrphans(ctx context.Context) (int, error) {
+	names, err := s.store.Names(time.Now().Add(-orphanAge))
+	if err != nil {
+		return 0, err
+	}
+
+	storedNames, err := s.queries.GetAllStoredNames(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	known := make(map[string]bool, len(storedNames))
+	for _, name := range storedNames {
+		known[name.String] = true
+	}
+
+	removed := 0
+	for _, name := range names {
+		if known[name] {
+			continue
+		}
+
+		if err := s.store.Remove(name); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,49 @@
+package attachments
+
+import "testing"
+
+func TestLinkTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "host only", url: "https://example.com", want: "example.com"},
+		{name: "www and trailing slash", url: "https://www.example.com/docs/design/", want: "example.com/docs/design"},
+		{name: "escaped path", url: "https://example.com/my%20doc", want: "example.com/my doc"},
+		{name: "query is dropped", url: "http://example.com/a?b=c#d", want: "example.com/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LinkTitle(tt.url); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLinkFormValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{name: "https", url: "https://example.com/doc", valid: true},
+		{name: "http", url: "http://example.com", valid: true},
+		{name: "blank", url: "", valid: false},
+		{name: "javascript", url: "javascript:alert(1)", valid: false},
+		{name: "relative", url: "/goals", valid: false},
+		{name: "no host", url: "https:///doc", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &LinkForm{URL: tt.url}
+			form.Validate()
+			if form.Valid() != tt.valid {
+				t.Errorf("expected valid %t, got %t (%v)", tt.valid, form.Valid(), form.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package attachments
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// errTooLarge is returned by Store.Save when the content is longer than the
+// limit.
+var errTooLarge = errors.New("attachments: content too large")
+
+// Store keeps uploaded files in a directory. Files are saved under random
+// names, the name a file was uploaded with never touches the disk.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store that keeps files in dir and creates it if needed.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Save writes r to a new file and returns its name and size. Nothing is kept
+// if r has more than limit bytes.
+func (s *Store) Save(r io.Reader, limit int64) (string, int64, error) {
+	name := rand.Text()
+
+	f, err := os.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", 0, err
+	}
+
+	size, err := io.Copy(f, io.LimitReader(r, limit+1))
+	if err == nil && size > limit {
+		err = errTooLarge
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(s.path(name))
+		return "", 0, err
+	}
+
+	return name, size, nil
+}
+
+// Open opens the file with the given name for reading.
+func (s *Store) Open(name string) (*os.File, error) {
+	return os.Open(s.path(name))
+}
+
+// Remove deletes the file with the given name. Missing files are ignored.
+func (s *Store) Remove(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Names returns the names of all files in the store that were last modified
+// before the given time.
+func (s *Store) Names(before time.Time) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+
+		info, err := e.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue // Removed in the meantime
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if info.ModTime().Before(before) {
+			names = append(names, e.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// path returns the path of the file with the given name. Base keeps a
+// tampered name from leaving the directory.
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
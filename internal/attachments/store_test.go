@@ -0,0 +1,50 @@
+package attachments
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, size, err := store.Save(strings.NewReader("hello"), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 5 {
+		t.Errorf("expected size 5, got %d", size)
+	}
+
+	if _, _, err := store.Save(strings.NewReader("too long"), 5); !errors.Is(err, errTooLarge) {
+		t.Errorf("expected errTooLarge, got %v", err)
+	}
+
+	names, err := store.Names(time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != name {
+		t.Errorf("expected only %q to be kept, got %v", name, names)
+	}
+
+	names, err = store.Names(time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Errorf("expected no files modified before a minute ago, got %v", names)
+	}
+
+	if err := store.Remove(name); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(name); err != nil {
+		t.Errorf("expected removing a missing file to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package attachments
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+type View struct {
+	ID          int
+	GoalID      int
+	Kind        string
+	Title       string
+	URL         string
+	FileName    string
+	ContentType string
+	Size        int64
+	Public      bool
+	CreatedAt   time.Time
+}
+
+func (a GoalAttachment) ToView() View {
+	return View{
+		ID:          int(a.ID),
+		GoalID:      int(a.GoalID),
+		Kind:        a.Kind,
+		Title:       a.Title,
+		URL:         a.Url.String,
+		FileName:    a.FileName.String,
+		ContentType: a.ContentType.String,
+		Size:        a.Size,
+		Public:      a.Public == 1,
+		CreatedAt:   time.Unix(a.CreatedAt, 0),
+	}
+}
+
+func (v View) IsLink() bool {
+	return v.Kind == KindLink
+}
+
+// IsImage reports whether the file is an image that browsers show inline.
+func (v View) IsImage() bool {
+	contentType, inline := ServeType(v.ContentType)
+	return inline && strings.HasPrefix(contentType, "image/")
+}
+
+func (v View) SizeLabel() string {
+	return FormatSize(v.Size)
+}
+
+// FormatSize returns n bytes in a human readable form, like "1.5 MB".
+func FormatSize(n int64) string {
+	switch {
+	case n < 1<<10:
+		return fmt.Sprintf("%d B", n)
+	case n < 1<<20:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	}
+}
+
+// inlineTypes are shown by browsers without running anything.
+var inlineTypes = map[string]bool{
+	"image/gif":  true,
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+	"text/plain": true,
+}
+
+// downloadTypes are served with their own type but only as downloads.
+var downloadTypes = map[string]bool{
+	"application/pdf":              true,
+	"application/x-gzip":           true,
+	"application/x-rar-compressed": true,
+	"application/zip":              true,
+	"audio/mpeg":                   true,
+	"audio/wave":                   true,
+	"video/mp4":                    true,
+	"video/webm":                   true,
+}
+
+// ServeType returns the Content-Type a file with the sniffed contentType is
+// served with and whether browsers may show it inline. Every other type,
+// like HTML or XML that a browser would run, is served as a download of
+// application/octet-stream.
+func ServeType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	switch {
+	case err != nil:
+		return "application/octet-stream", false
+	case mediaType == "text/plain":
+		return "text/plain; charset=utf-8", true
+	case inlineTypes[mediaType]:
+		return mediaType, true
+	case downloadTypes[mediaType]:
+		return mediaType, false
+	default:
+		return "application/octet-stream", false
+	}
+}
+
+// Disposition returns the Content-Disposition header of a file with the given
+// name.
+func Disposition(fileName string, inline bool) string {
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+
+	if header := mime.FormatMediaType(disposition, map[string]string{"filename": fileName}); header != "" {
+		return header
+	}
+
+	return disposition
+}
@@ -0,0 +1,70 @@
+package attachments
+
+import "testing"
+
+func TestServeType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+		wantInline  bool
+	}{
+		{name: "png", contentType: "image/png", want: "image/png", wantInline: true},
+		{name: "text", contentType: "text/plain; charset=utf-8", want: "text/plain; charset=utf-8", wantInline: true},
+		{name: "pdf", contentType: "application/pdf", want: "application/pdf", wantInline: false},
+		{name: "html", contentType: "text/html; charset=utf-8", want: "application/octet-stream", wantInline: false},
+		{name: "xml", contentType: "text/xml; charset=utf-8", want: "application/octet-stream", wantInline: false},
+		{name: "unknown", contentType: "application/octet-stream", want: "application/octet-stream", wantInline: false},
+		{name: "invalid", contentType: "", want: "application/octet-stream", wantInline: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, inline := ServeType(tt.contentType)
+			if got != tt.want || inline != tt.wantInline {
+				t.Errorf("expected %q (inline %t), got %q (inline %t)", tt.want, tt.wantInline, got, inline)
+			}
+		})
+	}
+}
+
+func TestDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		inline   bool
+		want     string
+	}{
+		{name: "attachment", fileName: "certificate.pdf", want: "attachment; filename=certificate.pdf"},
+		{name: "inline", fileName: "photo.png", inline: true, want: "inline; filename=photo.png"},
+		{name: "quotes", fileName: `a"b.txt`, want: `attachment; filename="a\"b.txt"`},
+		{name: "non-ascii", fileName: "zürich.jpg", want: "attachment; filename*=utf-8''z%C3%BCrich.jpg"},
+		{name: "header injection", fileName: "a\r\nSet-Cookie: x", want: "attachment; filename*=utf-8''a%0D%0ASet-Cookie%3A%20x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Disposition(tt.fileName, tt.inline); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 0, want: "0 B"},
+		{n: 1023, want: "1023 B"},
+		{n: 1536, want: "1.5 KB"},
+		{n: 10 << 20, want: "10.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSize(tt.n); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -13,6 +13,11 @@ type Form struct {
 	validator.Validator `form:"-"`
 }
 
+func (f *Form) Validate() {
+	f.Check(validator.MaxChars(f.Title, 200), "title", "Title cannot be more than 200 characters")
+	f.Check(validator.MaxChars(f.Description, 5000), "description", "Description cannot be more than 5000 characters")
+}
+
 type Service struct {
 	queries *Queries
 }
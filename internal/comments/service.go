@@ -0,0 +1,122 @@
+// Package comments lets stakeholders comment on shared goals. Comments wait
+// in a moderation queue until the owner of the goal approves them.
+package comments
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// SignedInName is shown for signed in users who leave the name blank.
+const SignedInName = "Goalkeepr user"
+
+type Form struct {
+	GoalID int    `form:"-"`
+	Name   string `form:"name"`
+	Body   string `form:"body"`
+	// SignedIn is set for signed in users, who don't have to give a name.
+	SignedIn            bool `form:"-"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(f.SignedIn || validator.NotBlank(f.Name), "name", "Name cannot be blank")
+	f.Check(validator.MaxChars(f.Name, 50), "name", "Name cannot be more than 50 characters")
+	f.Check(validator.NotBlank(f.Body), "body", "Comment cannot be blank")
+	f.Check(validator.MaxChars(f.Body, 1000), "body", "Comment cannot be more than 1000 characters")
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Add saves a comment on a goal of ownerID. authorID is the signed in user
+// who wrote it, or 0 for anonymous viewers. Comments of the owner are
+// approved right away, all others wait for moderation.
+func (s *Service) Add(ctx context.Context, ownerID, authorID int, form *Form, now time.Time) (GoalComment, error) {
+	name := form.Name
+	if name == "" {
+		name = SignedInName
+	}
+
+	return s.queries.CreateComment(ctx, CreateCommentParams{
+		GoalID:     int64(form.GoalID),
+		UserID:     int64(ownerID),
+		AuthorID:   sql.NullInt64{Int64: int64(authorID), Valid: authorID != 0},
+		AuthorName: name,
+		Body:       form.Body,
+		ApprovedAt: sql.NullInt64{Int64: now.Unix(), Valid: authorID == ownerID},
+	})
+}
+
+// GetAllApprovedByGoals returns the approved comments on the given goals of a
+// user, oldest first and grouped by goal ID.
+func (s *Service) GetAllApprovedByGoals(ctx context.Context, userID int, goalIDs []int64) (map[int64][]GoalComment, error) {
+	comments, err := s.queries.GetAllApprovedCommentsByGoals(ctx, GetAllApprovedCommentsByGoalsParams{
+		UserID:  int64(userID),
+		GoalIds: goalIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64][]GoalComment)
+	for _, c := range comments {
+		byGoal[c.GoalID] = append(byGoal[c.GoalID], c)
+	}
+
+	return byGoal, nil
+}
+
+// GetAllByUser returns the comments on the goals of a user, the ones waiting
+// for moderation first.
+func (s *Service) GetAllByUser(ctx context.Context, userID int) ([]GetAllCommentsByUserRow, error) {
+	return s.queries.GetAllCommentsByUser(ctx, int64(userID))
+}
+
+// Approve shows a comment on the share page. It returns 0 if there is no
+// such comment waiting for moderation.
+func (s *Service) Approve(ctx context.Context, commentID, userID int) (int, error) {
+	result, err := s.queries.ApproveComment(ctx, ApproveCommentParams{
+		ID:     int64(commentID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Delete removes a comment, which also rejects a comment waiting for
+// moderation.
+func (s *Service) Delete(ctx context.Context, commentID, userID int) (int, error) {
+	result, err := s.queries.DeleteComment(ctx, DeleteCommentParams{
+		ID:     int64(commentID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
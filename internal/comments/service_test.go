@@ -0,0 +1,30 @@
+package comments
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  Form
+		valid bool
+	}{
+		{name: "anonymous with name", form: Form{Name: "Alex", Body: "Great work"}, valid: true},
+		{name: "anonymous without name", form: Form{Body: "Great work"}, valid: false},
+		{name: "signed in without name", form: Form{Body: "Great work", SignedIn: true}, valid: true},
+		{name: "blank body", form: Form{Name: "Alex"}, valid: false},
+		{name: "long name", form: Form{Name: strings.Repeat("a", 51), Body: "Hi"}, valid: false},
+		{name: "long body", form: Form{Name: "Alex", Body: strings.Repeat("a", 1001)}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			if tt.form.Valid() != tt.valid {
+				t.Errorf("expected valid %t, got %t (%v)", tt.valid, tt.form.Valid(), tt.form.Errors)
+			}
+		})
+	}
+}
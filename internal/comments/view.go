@@ -0,0 +1,46 @@
+package comments
+
+import "time"
+
+type View struct {
+	ID         int
+	GoalID     int
+	AuthorName string
+	// SignedIn is set for comments of signed in users.
+	SignedIn  bool
+	Body      string
+	CreatedAt time.Time
+}
+
+func (c GoalComment) ToView() View {
+	return View{
+		ID:         int(c.ID),
+		GoalID:     int(c.GoalID),
+		AuthorName: c.AuthorName,
+		SignedIn:   c.AuthorID.Valid,
+		Body:       c.Body,
+		CreatedAt:  time.Unix(c.CreatedAt, 0),
+	}
+}
+
+// QueueView is a comment as the owner sees it when moderating.
+type QueueView struct {
+	View
+	Goal     string
+	Approved bool
+}
+
+func (c GetAllCommentsByUserRow) ToView() QueueView {
+	return QueueView{
+		View: View{
+			ID:         int(c.ID),
+			GoalID:     int(c.GoalID),
+			AuthorName: c.AuthorName,
+			SignedIn:   c.AuthorID.Valid,
+			Body:       c.Body,
+			CreatedAt:  time.Unix(c.CreatedAt, 0),
+		},
+		Goal:     c.Goal.String,
+		Approved: c.ApprovedAt.Valid,
+	}
+}
@@ -83,6 +83,32 @@ func Open(driver, path string) (*sql.DB, error) {
 	return db, nil
 }
 
+// WithTx runs fn inside a transaction. The transaction is committed when fn
+// returns nil and rolled back otherwise.
+func WithTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once the transaction is committed
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// InTx runs fn in tx. Without one, fn runs in a new transaction of db like
+// with WithTx. Services use it so their methods work both on their own and
+// as part of a caller's transaction.
+func InTx(ctx context.Context, db *sql.DB, tx *sql.Tx, fn func(tx *sql.Tx) error) error {
+	if tx != nil {
+		return fn(tx)
+	}
+	return WithTx(ctx, db, fn)
+}
+
 func Migrate(db *sql.DB, migrations fs.FS) (int64, error) {
 	goose.SetBaseFS(migrations)
 	goose.SetLogger(goose.NopLogger())
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"os"
 	"testing"
 
@@ -33,6 +36,71 @@ func TestOpen_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestInTx(t *testing.T) {
+	db, err := Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if _, err := db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY);"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	insert := func(id int) func(tx *sql.Tx) error {
+		return func(tx *sql.Tx) error {
+			_, err := tx.Exec("INSERT INTO items (id) VALUES (?);", id)
+			return err
+		}
+	}
+	count := func() int {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM items;").Scan(&n); err != nil {
+			t.Fatalf("failed to count items: %v", err)
+		}
+		return n
+	}
+
+	// Without a transaction, fn runs in its own one.
+	if err := InTx(ctx, db, nil, insert(1)); err != nil {
+		t.Fatalf("InTx() failed: %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("expected 1 item, got %d", n)
+	}
+
+	// Errors roll the new transaction back.
+	failed := errors.New("failed")
+	err = InTx(ctx, db, nil, func(tx *sql.Tx) error {
+		if err := insert(2)(tx); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected InTx() to return fn's error, got %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("expected the failed insert to be rolled back, got %d items", n)
+	}
+
+	// With a transaction, fn runs in it and the caller decides.
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if err := InTx(ctx, db, tx, insert(3)); err != nil {
+		t.Fatalf("InTx() failed: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if n := count(); n != 1 {
+		t.Errorf("expected the caller's rollback to undo the insert, got %d items", n)
+	}
+}
+
 func TestVerify_ForeignKeyViolation(t *testing.T) {
 	db, err := Open("sqlite", ":memory:")
 	if err != nil {
@@ -0,0 +1,202 @@
+// Package digest builds the weekly progress digest of users: the goals they
+// achieved and the success criteria they completed in the last week, and
+// their goals that are overdue or due in the next two weeks.
+package digest
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Off is the digest day of users who unsubscribed from the digest.
+const Off = "off"
+
+// DefaultDay is the day the digest is sent on for users who never chose one.
+const DefaultDay = time.Monday
+
+const (
+	// periodDays is how many days back the digest looks.
+	periodDays = 7
+	// dueSoonDays is how many days ahead the digest looks.
+	dueSoonDays = 14
+)
+
+// DayName returns the name a digest day is stored by, like "monday".
+func DayName(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
+
+// ValidDay reports whether name is a digest day or Off.
+func ValidDay(name string) bool {
+	if name == Off {
+		return true
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name == DayName(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// Goal is a goal listed in a digest.
+type Goal struct {
+	ID   int64
+	Goal string
+	Due  time.Time
+}
+
+// Criterion is a success criterion listed in a digest.
+type Criterion struct {
+	GoalID      int64
+	Goal        string
+	Description string
+}
+
+// Digest is the progress of a user from the same day a week before through
+// the day it is built on, and what is coming up in the two weeks after.
+type Digest struct {
+	// From is the first day and To the end of the last day of the week.
+	From time.Time
+	To   time.Time
+	// Achieved goals were achieved in the last week.
+	Achieved []Goal
+	// Completed success criteria were completed in the last week.
+	Completed []Criterion
+	// DueSoon goals are open and due in the next two weeks.
+	DueSoon []Goal
+	// Overdue goals are open and past their due date.
+	Overdue []Goal
+}
+
+// Empty reports whether there is nothing to tell in the digest.
+func (d Digest) Empty() bool {
+	return len(d.Achieved) == 0 && len(d.Completed) == 0 && len(d.DueSoon) == 0 && len(d.Overdue) == 0
+}
+
+// Subscriber is a user a digest is due for.
+type Subscriber struct {
+	UserID int64
+	Email  string
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Claim returns the users whose digest is due at now and records their
+// digest as sent. A digest is claimed only once a day, even across restarts,
+// so digests that can't be sent must be given back with Release. On error,
+// the subscribers claimed so far are returned as well.
+func (s *Service) Claim(ctx context.Context, now time.Time) ([]Subscriber, error) {
+	today := day(now)
+
+	rows, err := s.queries.GetAllSubscribersByDay(ctx, DayName(today.Weekday()))
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribers []Subscriber
+	for _, row := range rows {
+		result, err := s.queries.CreateDigest(ctx, CreateDigestParams{
+			UserID: row.ID,
+			Day:    today.Unix(),
+		})
+		if err != nil {
+			return subscribers, err
+		}
+
+		claimed, err := result.RowsAffected()
+		if err != nil {
+			return subscribers, err
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		subscribers = append(subscribers, Subscriber{UserID: row.ID, Email: row.Email})
+	}
+
+	return subscribers, nil
+}
+
+// Release gives back the digest of a user claimed at now, so it is claimed
+// again.
+func (s *Service) Release(ctx context.Context, userID int64, now time.Time) error {
+	return s.queries.DeleteDigest(ctx, DeleteDigestParams{
+		UserID: userID,
+		Day:    day(now).Unix(),
+	})
+}
+
+// Build returns the digest of a user at now.
+func (s *Service) Build(ctx context.Context, userID int64, now time.Time) (Digest, error) {
+	today := day(now)
+	d := Digest{
+		From: today.AddDate(0, 0, -periodDays),
+		To:   today.AddDate(0, 0, 1),
+	}
+
+	achieved, err := s.queries.GetAllGoalsAchievedBetween(ctx, GetAllGoalsAchievedBetweenParams{
+		UserID:       userID,
+		AchievedFrom: d.From.Unix(),
+		AchievedTo:   d.To.Unix(),
+	})
+	if err != nil {
+		return Digest{}, err
+	}
+	for _, row := range achieved {
+		d.Achieved = append(d.Achieved, Goal{ID: row.ID, Goal: row.Goal.String, Due: unixDate(row.Due)})
+	}
+
+	completed, err := s.queries.GetAllCriteriaCompletedBetween(ctx, GetAllCriteriaCompletedBetweenParams{
+		UserID:        userID,
+		CompletedFrom: d.From.Unix(),
+		CompletedTo:   d.To.Unix(),
+	})
+	if err != nil {
+		return Digest{}, err
+	}
+	for _, row := range completed {
+		d.Completed = append(d.Completed, Criterion{GoalID: row.GoalID, Goal: row.Goal.String, Description: row.Description})
+	}
+
+	open, err := s.queries.GetAllOpenGoalsDueBefore(ctx, GetAllOpenGoalsDueBeforeParams{
+		UserID:    userID,
+		DueBefore: today.AddDate(0, 0, dueSoonDays).Unix(),
+	})
+	if err != nil {
+		return Digest{}, err
+	}
+	for _, row := range open {
+		goal := Goal{ID: row.ID, Goal: row.Goal.String, Due: unixDate(row.Due)}
+		if goal.Due.Before(today) {
+			d.Overdue = append(d.Overdue, goal)
+		} else {
+			d.DueSoon = append(d.DueSoon, goal)
+		}
+	}
+
+	return d, nil
+}
+
+// day returns midnight UTC of the day of t.
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func unixDate(v sql.NullInt64) time.Time {
+	if !v.Valid {
+		return time.Time{}
+	}
+	return time.Unix(v.Int64, 0).UTC()
+}
@@ -0,0 +1,39 @@
+package digest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidDay(t *testing.T) {
+	tests := []struct {
+		name string
+		day  string
+		want bool
+	}{
+		{"monday", "monday", true},
+		{"sunday", "sunday", true},
+		{"off", Off, true},
+		{"default day", DayName(DefaultDay), true},
+		{"capitalized", "Monday", false},
+		{"abbreviated", "mon", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidDay(tt.day); got != tt.want {
+				t.Errorf("ValidDay(%q) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDay(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	want := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)
+
+	if got := day(at); !got.Equal(want) {
+		t.Errorf("day(%v) = %v, want %v", at, got, want)
+	}
+}
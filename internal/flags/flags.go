@@ -4,7 +4,10 @@ package flags
 import (
 	"flag"
 	"fmt"
+	"net/mail"
+	"net/url"
 	"slices"
+	"strings"
 )
 
 // Options contains command-line configuration.
@@ -15,6 +18,34 @@ type Options struct {
 		Driver string
 		Dsn    string
 	}
+	Trash struct {
+		RetentionDays int
+	}
+	Attachments struct {
+		Dir     string
+		QuotaMB int
+	}
+	// BaseURL is the public URL of the app, used for links in emails.
+	BaseURL string
+	// SMTP configures the server emails are sent with. Without a host,
+	// emails are only logged.
+	SMTP struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+		From     string
+	}
+	Webhooks struct {
+		// AllowPrivate allows webhooks to loopback and private addresses,
+		// which are refused by default so webhooks can't reach internal
+		// services.
+		AllowPrivate bool
+	}
+	Jobs struct {
+		// Disabled keeps the background jobs from running, e.g. in tests.
+		Disabled bool
+	}
 }
 
 // Parse parses command-line flags and returns Options.
@@ -29,6 +60,28 @@ func Parse() (*Options, error) {
 	flag.StringVar(&cfg.Database.Driver, "database-driver", "sqlite", "database driver")
 	flag.StringVar(&cfg.Database.Dsn, "database-dsn", "", "database dsn")
 
+	// Trash configuration
+	flag.IntVar(&cfg.Trash.RetentionDays, "trash-retention-days", 30, "days before deleted goals are purged")
+
+	// Attachments configuration
+	flag.StringVar(&cfg.Attachments.Dir, "attachments-dir", "attachments", "directory for uploaded files")
+	flag.IntVar(&cfg.Attachments.QuotaMB, "attachments-quota-mb", 100, "megabytes of uploads per user")
+
+	flag.StringVar(&cfg.BaseURL, "base-url", "http://localhost:8080", "public URL of the app, used in emails")
+
+	// SMTP configuration
+	flag.StringVar(&cfg.SMTP.Host, "smtp-host", "", "SMTP host, emails are only logged without one")
+	flag.IntVar(&cfg.SMTP.Port, "smtp-port", 587, "SMTP port")
+	flag.StringVar(&cfg.SMTP.Username, "smtp-username", "", "SMTP username")
+	flag.StringVar(&cfg.SMTP.Password, "smtp-password", "", "SMTP password")
+	flag.StringVar(&cfg.SMTP.From, "smtp-from", "Goalkeepr <no-reply@goalkeepr.com>", "sender of emails")
+
+	// Webhooks configuration
+	flag.BoolVar(&cfg.Webhooks.AllowPrivate, "webhooks-allow-private", false, "allow webhooks to private and loopback addresses, for development")
+
+	// Jobs configuration
+	flag.BoolVar(&cfg.Jobs.Disabled, "jobs-disabled", false, "don't run background jobs such as reminders and the trash purge")
+
 	flag.Parse()
 
 	if cfg.Port < 0 || cfg.Port > 65535 {
@@ -44,5 +97,30 @@ func Parse() (*Options, error) {
 		return nil, fmt.Errorf("database dsn cannot be empty")
 	}
 
+	if cfg.Trash.RetentionDays < 1 {
+		return nil, fmt.Errorf("trash retention must be at least 1 day")
+	}
+
+	if cfg.Attachments.Dir == "" {
+		return nil, fmt.Errorf("attachments dir cannot be empty")
+	}
+
+	if cfg.Attachments.QuotaMB < 1 {
+		return nil, fmt.Errorf("attachments quota must be at least 1 MB")
+	}
+
+	if u, err := url.Parse(cfg.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("base url must be an absolute http or https URL")
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+
+	if cfg.SMTP.Port < 1 || cfg.SMTP.Port > 65535 {
+		return nil, fmt.Errorf("smtp port is not in valid range of 1-65535")
+	}
+
+	if _, err := mail.ParseAddress(cfg.SMTP.From); err != nil {
+		return nil, fmt.Errorf("smtp from is not a valid address: %w", err)
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,71 @@
+package goals
+
+import (
+	"context"
+	"time"
+)
+
+// Archive hides a goal from the timeline and moves it to the archive page.
+func (s *Service) Archive(ctx context.Context, goalID, userID int) (int, error) {
+	result, err := s.queries.Archive(ctx, ArchiveParams{
+		ID:     int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Unarchive moves a goal back to the timeline.
+func (s *Service) Unarchive(ctx context.Context, goalID, userID int) (int, error) {
+	result, err := s.queries.Unarchive(ctx, UnarchiveParams{
+		ID:     int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetAllArchived returns the archived goals, latest due date first.
+func (s *Service) GetAllArchived(ctx context.Context, userID int) ([]Goal, error) {
+	goals, err := s.queries.GetAllArchived(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+// AutoArchive archives the user's goals that were achieved before the given
+// time.
+func (s *Service) AutoArchive(ctx context.Context, userID int, before time.Time) (int, error) {
+	result, err := s.queries.AutoArchive(ctx, AutoArchiveParams{
+		UserID:         int64(userID),
+		AchievedBefore: before.Unix(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
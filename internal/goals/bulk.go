@@ -0,0 +1,213 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// BulkAction is a change applied to several goals at once.
+type BulkAction string
+
+const (
+	BulkAchieve   BulkAction = "achieve"
+	BulkUnachieve BulkAction = "unachieve"
+	BulkPublic    BulkAction = "public"
+	BulkPrivate   BulkAction = "private"
+	BulkShift     BulkAction = "shift"
+	BulkDelete    BulkAction = "delete"
+	// BulkRestore undoes BulkDelete and is not offered in the UI.
+	BulkRestore BulkAction = "restore"
+)
+
+// MaxBulkGoals is the number of goals a single bulk action may change.
+const MaxBulkGoals = 500
+
+// MaxBulkShiftDays limits how far due dates can be moved in one bulk action.
+const MaxBulkShiftDays = 3650
+
+type BulkForm struct {
+	IDs                 []int  `form:"id"`
+	Action              string `form:"action"`
+	Days                int    `form:"days"`
+	validator.Validator `form:"-"`
+}
+
+func (f *BulkForm) Validate() {
+	f.Check(len(f.IDs) > 0, "id", "Select at least one goal")
+	f.Check(len(f.IDs) <= MaxBulkGoals, "id", fmt.Sprintf("Select no more than %d goals", MaxBulkGoals))
+	f.Check(validator.PermittedValue(BulkAction(f.Action),
+		BulkAchieve, BulkUnachieve, BulkPublic, BulkPrivate, BulkShift, BulkDelete, BulkRestore,
+	), "action", "Choose a valid action")
+
+	if BulkAction(f.Action) == BulkShift {
+		f.Check(f.Days != 0, "days", "Days cannot be zero")
+		f.Check(f.Days >= -MaxBulkShiftDays && f.Days <= MaxBulkShiftDays, "days", fmt.Sprintf("Days must be between %d and %d", -MaxBulkShiftDays, MaxBulkShiftDays))
+	}
+}
+
+// Bulk applies the action of the form to all selected goals in a single
+// transaction. If any goal doesn't exist or belongs to another user, nothing
+// is changed and sql.ErrNoRows is returned. It returns the number of goals
+// that changed.
+func (s *Service) Bulk(ctx context.Context, userID int, form *BulkForm) (int, error) {
+	ids := slices.Clone(form.IDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	changed := 0
+	err := s.inTx(ctx, func(q *Queries) error {
+		for _, id := range ids {
+			ok, err := bulkApply(ctx, q, id, userID, BulkAction(form.Action), form.Days)
+			if err != nil {
+				return err
+			}
+			if ok {
+				changed++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return changed, nil
+}
+
+// bulkApply applies action to one goal and reports whether it changed.
+func bulkApply(ctx context.Context, q *Queries, goalID, userID int, action BulkAction, days int) (bool, error) {
+	// Trashed goals are not returned by Get, so restoring checks the owner
+	// through the restore itself.
+	if action == BulkRestore {
+		result, err := q.Restore(ctx, RestoreParams{ID: int64(goalID), UserID: int64(userID)})
+		if err != nil {
+			return false, err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		if rowsAffected == 0 {
+			return false, sql.ErrNoRows
+		}
+		return true, nil
+	}
+
+	goal, err := q.Get(ctx, GetParams{ID: int64(goalID), UserID: int64(userID)})
+	if err != nil {
+		return false, err
+	}
+
+	switch action {
+	case BulkDelete:
+		_, err := q.Trash(ctx, TrashParams{ID: goal.ID, UserID: goal.UserID})
+		return err == nil, err
+
+	case BulkShift:
+		if !goal.Due.Valid {
+			return false, nil
+		}
+		delta := int64(days) * secondsPerDay
+		due := sql.NullInt64{Int64: goal.Due.Int64 + delta, Valid: true}
+		if err := saveRevision(ctx, q, goal, goal.Goal, goal.Description, due); err != nil {
+			return false, err
+		}
+		_, err := q.UpdateDue(ctx, UpdateDueParams{
+			Due:        due,
+			StartShift: delta,
+			ID:         goal.ID,
+			UserID:     goal.UserID,
+		})
+		return err == nil, err
+	}
+
+	update := UpdateParams{
+		Goal:            goal.Goal,
+		Description:     goal.Description,
+		Due:             goal.Due,
+		VisibleToPublic: goal.VisibleToPublic,
+		Status:          goal.Status,
+		Recurrence:      goal.Recurrence,
+		AchievedAt:      goal.AchievedAt,
+		StartDate:       goal.StartDate,
+		ID:              goal.ID,
+		UserID:          goal.UserID,
+	}
+
+	switch action {
+	case BulkPublic, BulkPrivate:
+		visible := int64(0)
+		if action == BulkPublic {
+			visible = 1
+		}
+		if goal.VisibleToPublic.Int64 == visible {
+			return false, nil
+		}
+		update.VisibleToPublic = sql.NullInt64{Int64: visible, Valid: true}
+
+	case BulkAchieve:
+		if Status(goal.Status) == Achieved {
+			return false, nil
+		}
+		update.Status = string(Achieved)
+		update.AchievedAt = sql.NullInt64{Int64: time.Now().Unix(), Valid: true}
+
+	case BulkUnachieve:
+		if Status(goal.Status) != Achieved {
+			return false, nil
+		}
+		status, err := statusBeforeAchieved(ctx, q, goal)
+		if err != nil {
+			return false, err
+		}
+		update.Status = string(status)
+		update.AchievedAt = sql.NullInt64{}
+
+	default:
+		return false, errors.New("unknown bulk action")
+	}
+
+	if _, err := q.Update(ctx, update); err != nil {
+		return false, err
+	}
+
+	if update.Status != goal.Status {
+		if err := q.CreateStatusChange(ctx, CreateStatusChangeParams{
+			GoalID:     goal.ID,
+			UserID:     goal.UserID,
+			FromStatus: sql.NullString{String: goal.Status, Valid: true},
+			ToStatus:   update.Status,
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// statusBeforeAchieved returns the status the goal had before it was last
+// marked as achieved, or InProgress if that isn't known.
+func statusBeforeAchieved(ctx context.Context, q *Queries, goal Goal) (Status, error) {
+	history, err := q.GetStatusHistory(ctx, GetStatusHistoryParams{
+		GoalID: goal.ID,
+		UserID: goal.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, h := range history {
+		from := Status(h.FromStatus.String)
+		if Status(h.ToStatus) == Achieved && from.Valid() && from != Achieved {
+			return from, nil
+		}
+	}
+
+	return InProgress, nil
+}
@@ -0,0 +1,166 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// AddDependency makes goalID depend on dependsOnID. Both goals must belong to
+// the user. ErrDependencyCycle is returned if dependsOnID already depends on
+// goalID, directly or transitively.
+func (s *Service) AddDependency(ctx context.Context, goalID, dependsOnID, userID int) error {
+	if goalID == dependsOnID {
+		return ErrDependencyCycle
+	}
+
+	for _, id := range []int{goalID, dependsOnID} {
+		if _, err := s.Get(ctx, id, userID); err != nil {
+			return err
+		}
+	}
+
+	edges, err := s.queries.GetAllDependencies(ctx, int64(userID))
+	if err != nil {
+		return err
+	}
+
+	prerequisites := make(map[int64][]int64)
+	for _, e := range edges {
+		prerequisites[e.GoalID] = append(prerequisites[e.GoalID], e.DependsOnID)
+	}
+
+	if reachable(prerequisites, int64(dependsOnID), int64(goalID)) {
+		return ErrDependencyCycle
+	}
+
+	return s.queries.CreateDependency(ctx, CreateDependencyParams{
+		GoalID:      int64(goalID),
+		DependsOnID: int64(dependsOnID),
+		UserID:      int64(userID),
+	})
+}
+
+func (s *Service) RemoveDependency(ctx context.Context, goalID, dependsOnID, userID int) (int, error) {
+	result, err := s.queries.DeleteDependency(ctx, DeleteDependencyParams{
+		GoalID:      int64(goalID),
+		DependsOnID: int64(dependsOnID),
+		UserID:      int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetPrerequisites returns the goals that goalID depends on.
+func (s *Service) GetPrerequisites(ctx context.Context, goalID, userID int) ([]Goal, error) {
+	return s.queries.GetPrerequisites(ctx, GetPrerequisitesParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+}
+
+// GetDependents returns the goals that depend on goalID.
+func (s *Service) GetDependents(ctx context.Context, goalID, userID int) ([]Goal, error) {
+	return s.queries.GetDependents(ctx, GetDependentsParams{
+		DependsOnID: int64(goalID),
+		UserID:      int64(userID),
+	})
+}
+
+// ScheduleConflicts returns, per goal ID, the titles of prerequisites that
+// are due after the goal itself.
+func (s *Service) ScheduleConflicts(ctx context.Context, userID int) (map[int64][]string, error) {
+	rows, err := s.queries.GetScheduleConflicts(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make(map[int64][]string, len(rows))
+	for _, row := range rows {
+		conflicts[row.GoalID] = append(conflicts[row.GoalID], row.PrerequisiteGoal.String)
+	}
+
+	return conflicts, nil
+}
+
+// reachable reports whether to can be reached from from by following edges.
+func reachable(edges map[int64][]int64, from, to int64) bool {
+	visited := make(map[int64]bool)
+	stack := []int64{from}
+
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if id == to {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		stack = append(stack, edges[id]...)
+	}
+
+	return false
+}
+
+// shiftDependents moves every goal that transitively depends on goalID by
+// delta seconds. Closed goals stay where they are, but goals depending on
+// them are still moved.
+func shiftDependents(ctx context.Context, q *Queries, goalID, userID int, delta int64) error {
+	edges, err := q.GetAllDependencies(ctx, int64(userID))
+	if err != nil {
+		return err
+	}
+
+	dependents := make(map[int64][]int64)
+	for _, e := range edges {
+		dependents[e.DependsOnID] = append(dependents[e.DependsOnID], e.GoalID)
+	}
+
+	visited := map[int64]bool{int64(goalID): true}
+	queue := dependents[int64(goalID)]
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, dependents[id]...)
+
+		goal, err := q.Get(ctx, GetParams{ID: id, UserID: int64(userID)})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // In the trash
+		}
+		if err != nil {
+			return err
+		}
+		if !goal.Due.Valid || Status(goal.Status).Closed() {
+			continue
+		}
+
+		if _, err := q.UpdateDue(ctx, UpdateDueParams{
+			Due:        sql.NullInt64{Int64: goal.Due.Int64 + delta, Valid: true},
+			StartShift: delta,
+			ID:         id,
+			UserID:     int64(userID),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package goals
+
+import "strings"
+
+// DiffOp tells whether a part of a diff was kept, added or removed.
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffPart is a run of words that share the same DiffOp.
+type DiffPart struct {
+	Op   DiffOp
+	Text string
+}
+
+func (p DiffPart) Inserted() bool { return p.Op == DiffInsert }
+func (p DiffPart) Deleted() bool  { return p.Op == DiffDelete }
+
+// DiffWords returns the word level changes needed to turn old into new.
+// Whitespace is normalized, so parts are meant to be joined with spaces.
+func DiffWords(old, new string) []DiffPart {
+	a, b := strings.Fields(old), strings.Fields(new)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	parts := []DiffPart{}
+	add := func(op DiffOp, word string) {
+		if n := len(parts); n > 0 && parts[n-1].Op == op {
+			parts[n-1].Text += " " + word
+			return
+		}
+		parts = append(parts, DiffPart{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(DiffInsert, b[j])
+	}
+
+	return parts
+}
@@ -0,0 +1,67 @@
+package goals
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []DiffPart
+	}{
+		{
+			name: "unchanged",
+			old:  "Run a marathon",
+			new:  "Run a marathon",
+			want: []DiffPart{{DiffEqual, "Run a marathon"}},
+		},
+		{
+			name: "replaced word",
+			old:  "Run a marathon",
+			new:  "Run a half marathon",
+			want: []DiffPart{{DiffEqual, "Run a"}, {DiffInsert, "half"}, {DiffEqual, "marathon"}},
+		},
+		{
+			name: "removed words",
+			old:  "Read twelve books this year",
+			new:  "Read books",
+			want: []DiffPart{{DiffEqual, "Read"}, {DiffDelete, "twelve"}, {DiffEqual, "books"}, {DiffDelete, "this year"}},
+		},
+		{
+			name: "whitespace is ignored",
+			old:  "Learn  Go\n",
+			new:  "Learn Go",
+			want: []DiffPart{{DiffEqual, "Learn Go"}},
+		},
+		{
+			name: "from empty",
+			old:  "",
+			new:  "Ship it",
+			want: []DiffPart{{DiffInsert, "Ship it"}},
+		},
+		{
+			name: "to empty",
+			old:  "Ship it",
+			new:  "",
+			want: []DiffPart{{DiffDelete, "Ship it"}},
+		},
+		{
+			name: "both empty",
+			old:  "",
+			new:  "",
+			want: []DiffPart{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffWords(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/recurrence"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// DuplicateForm copies a goal under a new title and due date.
+type DuplicateForm struct {
+	Goal                string `form:"goal"`
+	Due                 string `form:"due"`
+	validator.Validator `form:"-"`
+}
+
+func (f *DuplicateForm) Validate() {
+	f.Check(validator.NotBlank(f.Goal), "goal", "Goal cannot be blank")
+	f.Check(validator.MaxChars(f.Goal, 500), "goal", "Goal cannot be more than 500 characters")
+	f.Check(validator.NotBlank(f.Due), "due", "Due date cannot be blank")
+
+	if f.Due != "" {
+		_, err := time.Parse(HTMLDateFormat, f.Due)
+		f.Check(err == nil, "due", "Due date must be a valid date")
+	}
+}
+
+// SuggestDue returns a due date for a copy of goal. Repeating goals suggest
+// their next occurrence and goals with a start date the span of the same
+// length right after them. Other goals suggest one month after their due
+// date, or after now if the goal is overdue.
+func SuggestDue(goal Goal, now time.Time) time.Time {
+	due := time.Unix(goal.Due.Int64, 0).UTC()
+
+	if goal.Recurrence.Valid {
+		if rule, err := recurrence.Parse(goal.Recurrence.String); err == nil {
+			if next, _, ok := rule.Next(due); ok {
+				return next
+			}
+		}
+	}
+
+	if goal.StartDate.Valid && goal.StartDate.Int64 < goal.Due.Int64 {
+		return due.Add(time.Duration(goal.Due.Int64-goal.StartDate.Int64) * time.Second)
+	}
+
+	if now = now.UTC(); due.Before(now) {
+		due = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return due.AddDate(0, 1, 0)
+}
+
+// Duplicate copies the title, description and visibility of a goal of the
+// user into a new goal that is not started yet. A start date keeps its
+// distance to the due date. The copy doesn't repeat. It returns sql.ErrNoRows
+// if the user has no such goal.
+func (s *Service) Duplicate(ctx context.Context, goalID, userID int, form *DuplicateForm) (int, error) {
+	due, err := time.Parse(HTMLDateFormat, form.Due)
+	if err != nil {
+		return 0, err
+	}
+
+	var copyID int
+	err = s.inTx(ctx, func(q *Queries) error {
+		goal, err := q.Get(ctx, GetParams{
+			ID:     int64(goalID),
+			UserID: int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		startDate := goal.StartDate
+		if startDate.Valid {
+			startDate.Int64 += due.Unix() - goal.Due.Int64
+		}
+
+		created, err := q.Create(ctx, CreateParams{
+			UserID:          goal.UserID,
+			Goal:            sql.NullString{String: form.Goal, Valid: true},
+			Description:     goal.Description,
+			Due:             sql.NullInt64{Int64: due.Unix(), Valid: true},
+			VisibleToPublic: goal.VisibleToPublic,
+			Status:          string(NotStarted),
+			StartDate:       startDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		copyID = int(created.ID)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return copyID, nil
+}
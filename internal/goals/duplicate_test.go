@@ -0,0 +1,55 @@
+package goals
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestSuggestDue(t *testing.T) {
+	date := func(s string) sql.NullInt64 {
+		d, _ := time.Parse(HTMLDateFormat, s)
+		return sql.NullInt64{Int64: d.Unix(), Valid: true}
+	}
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		goal Goal
+		want string
+	}{
+		{
+			name: "one month after the due date",
+			goal: Goal{Due: date("2026-11-30")},
+			want: "2026-12-30",
+		},
+		{
+			name: "one month after today for overdue goals",
+			goal: Goal{Due: date("2026-09-01")},
+			want: "2026-11-19",
+		},
+		{
+			name: "span of the same length after the goal",
+			goal: Goal{Due: date("2026-11-30"), StartDate: date("2026-11-16")},
+			want: "2026-12-14",
+		},
+		{
+			name: "next occurrence of repeating goals",
+			goal: Goal{Due: date("2026-11-30"), Recurrence: sql.NullString{String: "FREQ=WEEKLY", Valid: true}},
+			want: "2026-12-07",
+		},
+		{
+			name: "invalid recurrence falls back",
+			goal: Goal{Due: date("2026-11-30"), Recurrence: sql.NullString{String: "often", Valid: true}},
+			want: "2026-12-30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestDue(tt.goal, now).Format(HTMLDateFormat); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
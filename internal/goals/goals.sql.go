@@ -10,10 +10,41 @@ import (
 	"database/sql"
 )
 
+const archive = `-- name: Archive :execresult
+UPDATE goals
+SET archived_at = unixepoch()
+WHERE id = ? AND user_id = ? AND archived_at IS NULL
+`
+
+type ArchiveParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) Archive(ctx context.Context, arg ArchiveParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, archive, arg.ID, arg.UserID)
+}
+
+const autoArchive = `-- name: AutoArchive :execresult
+UPDATE goals
+SET archived_at = unixepoch()
+WHERE user_id = ? AND status = 'achieved' AND archived_at IS NULL AND deleted_at IS NULL
+  AND achieved_at < CAST(? AS INTEGER)
+`
+
+type AutoArchiveParams struct {
+	UserID         int64
+	AchievedBefore int64
+}
+
+func (q *Queries) AutoArchive(ctx context.Context, arg AutoArchiveParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, autoArchive, arg.UserID, arg.AchievedBefore)
+}
+
 const create = `-- name: Create :one
-INSERT INTO goals (user_id, goal, description, due, visible_to_public, achieved)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, user_id, goal, due, visible_to_public, achieved, description
+INSERT INTO goals (user_id, goal, description, due, visible_to_public, status, recurrence, start_date)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date
 `
 
 type CreateParams struct {
@@ -22,7 +53,9 @@ type CreateParams struct {
 	Description     sql.NullString
 	Due             sql.NullInt64
 	VisibleToPublic sql.NullInt64
-	Achieved        sql.NullInt64
+	Status          string
+	Recurrence      sql.NullString
+	StartDate       sql.NullInt64
 }
 
 func (q *Queries) Create(ctx context.Context, arg CreateParams) (Goal, error) {
@@ -32,7 +65,9 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (Goal, error) {
 		arg.Description,
 		arg.Due,
 		arg.VisibleToPublic,
-		arg.Achieved,
+		arg.Status,
+		arg.Recurrence,
+		arg.StartDate,
 	)
 	var i Goal
 	err := row.Scan(
@@ -41,15 +76,137 @@ func (q *Queries) Create(ctx context.Context, arg CreateParams) (Goal, error) {
 		&i.Goal,
 		&i.Due,
 		&i.VisibleToPublic,
-		&i.Achieved,
 		&i.Description,
+		&i.Recurrence,
+		&i.NextOccurrenceID,
+		&i.Status,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.JournalPublic,
+		&i.AchievedAt,
+		&i.StartDate,
+	)
+	return i, err
+}
+
+const createDependency = `-- name: CreateDependency :exec
+INSERT INTO goal_dependencies (goal_id, depends_on_id, user_id)
+VALUES (?, ?, ?)
+`
+
+type CreateDependencyParams struct {
+	GoalID      int64
+	DependsOnID int64
+	UserID      int64
+}
+
+func (q *Queries) CreateDependency(ctx context.Context, arg CreateDependencyParams) error {
+	_, err := q.db.ExecContext(ctx, createDependency, arg.GoalID, arg.DependsOnID, arg.UserID)
+	return err
+}
+
+const createReschedule = `-- name: CreateReschedule :one
+INSERT INTO goal_reschedules (goal_id, user_id, delta)
+VALUES (?, ?, ?)
+RETURNING id, goal_id, user_id, delta, created_at
+`
+
+type CreateRescheduleParams struct {
+	GoalID int64
+	UserID int64
+	Delta  int64
+}
+
+func (q *Queries) CreateReschedule(ctx context.Context, arg CreateRescheduleParams) (GoalReschedule, error) {
+	row := q.db.QueryRowContext(ctx, createReschedule, arg.GoalID, arg.UserID, arg.Delta)
+	var i GoalReschedule
+	err := row.Scan(
+		&i.ID,
+		&i.GoalID,
+		&i.UserID,
+		&i.Delta,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const createRescheduleItem = `-- name: CreateRescheduleItem :exec
+INSERT INTO goal_reschedule_items (reschedule_id, goal_id, user_id, previous_due, previous_start_date, due)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateRescheduleItemParams struct {
+	RescheduleID      int64
+	GoalID            int64
+	UserID            int64
+	PreviousDue       int64
+	PreviousStartDate sql.NullInt64
+	Due               int64
+}
+
+func (q *Queries) CreateRescheduleItem(ctx context.Context, arg CreateRescheduleItemParams) error {
+	_, err := q.db.ExecContext(ctx, createRescheduleItem,
+		arg.RescheduleID,
+		arg.GoalID,
+		arg.UserID,
+		arg.PreviousDue,
+		arg.PreviousStartDate,
+		arg.Due,
+	)
+	return err
+}
+
+const createRevision = `-- name: CreateRevision :exec
+INSERT INTO goal_revisions (goal_id, user_id, goal, description, due)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateRevisionParams struct {
+	GoalID      int64
+	UserID      int64
+	Goal        sql.NullString
+	Description sql.NullString
+	Due         sql.NullInt64
+}
+
+func (q *Queries) CreateRevision(ctx context.Context, arg CreateRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, createRevision,
+		arg.GoalID,
+		arg.UserID,
+		arg.Goal,
+		arg.Description,
+		arg.Due,
+	)
+	return err
+}
+
+const createStatusChange = `-- name: CreateStatusChange :exec
+INSERT INTO goal_status_history (goal_id, user_id, from_status, to_status, note)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateStatusChangeParams struct {
+	GoalID     int64
+	UserID     int64
+	FromStatus sql.NullString
+	ToStatus   string
+	Note       sql.NullString
+}
+
+func (q *Queries) CreateStatusChange(ctx context.Context, arg CreateStatusChangeParams) error {
+	_, err := q.db.ExecContext(ctx, createStatusChange,
+		arg.GoalID,
+		arg.UserID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+	)
+	return err
+}
+
 const delete = `-- name: Delete :execresult
 DELETE FROM goals
-WHERE id = ? AND user_id = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
 `
 
 type DeleteParams struct {
@@ -61,9 +218,49 @@ func (q *Queries) Delete(ctx context.Context, arg DeleteParams) (sql.Result, err
 	return q.db.ExecContext(ctx, delete, arg.ID, arg.UserID)
 }
 
+const deleteDependency = `-- name: DeleteDependency :execresult
+DELETE FROM goal_dependencies
+WHERE goal_id = ? AND depends_on_id = ? AND user_id = ?
+`
+
+type DeleteDependencyParams struct {
+	GoalID      int64
+	DependsOnID int64
+	UserID      int64
+}
+
+func (q *Queries) DeleteDependency(ctx context.Context, arg DeleteDependencyParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteDependency, arg.GoalID, arg.DependsOnID, arg.UserID)
+}
+
+const deleteReschedule = `-- name: DeleteReschedule :execresult
+DELETE FROM goal_reschedules
+WHERE id = ? AND user_id = ? AND created_at >= ?
+`
+
+type DeleteRescheduleParams struct {
+	ID        int64
+	UserID    int64
+	CreatedAt int64
+}
+
+func (q *Queries) DeleteReschedule(ctx context.Context, arg DeleteRescheduleParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, deleteReschedule, arg.ID, arg.UserID, arg.CreatedAt)
+}
+
+const deleteReschedulesBefore = `-- name: DeleteReschedulesBefore :exec
+DELETE FROM goal_reschedules
+WHERE created_at < ?
+`
+
+func (q *Queries) DeleteReschedulesBefore(ctx context.Context, createdAt int64) error {
+	_, err := q.db.ExecContext(ctx, deleteReschedulesBefore, createdAt)
+	return err
+}
+
 const get = `-- name: Get :one
-SELECT id, user_id, goal, due, visible_to_public, achieved, description FROM goals
-WHERE id = ? AND user_id = ?
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
 `
 
 type GetParams struct {
@@ -80,15 +277,22 @@ func (q *Queries) Get(ctx context.Context, arg GetParams) (Goal, error) {
 		&i.Goal,
 		&i.Due,
 		&i.VisibleToPublic,
-		&i.Achieved,
 		&i.Description,
+		&i.Recurrence,
+		&i.NextOccurrenceID,
+		&i.Status,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.JournalPublic,
+		&i.AchievedAt,
+		&i.StartDate,
 	)
 	return i, err
 }
 
 const getAll = `-- name: GetAll :many
-SELECT id, user_id, goal, due, visible_to_public, achieved, description FROM goals
-WHERE user_id = ?
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NULL
 ORDER BY due ASC
 `
 
@@ -107,8 +311,15 @@ func (q *Queries) GetAll(ctx context.Context, userID int64) ([]Goal, error) {
 			&i.Goal,
 			&i.Due,
 			&i.VisibleToPublic,
-			&i.Achieved,
 			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
 		); err != nil {
 			return nil, err
 		}
@@ -123,14 +334,14 @@ func (q *Queries) GetAll(ctx context.Context, userID int64) ([]Goal, error) {
 	return items, nil
 }
 
-const getAllShared = `-- name: GetAllShared :many
-SELECT id, user_id, goal, due, visible_to_public, achieved, description FROM goals
-WHERE user_id = ? AND visible_to_public = 1
-ORDER BY due ASC
+const getAllArchived = `-- name: GetAllArchived :many
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NOT NULL
+ORDER BY due DESC
 `
 
-func (q *Queries) GetAllShared(ctx context.Context, userID int64) ([]Goal, error) {
-	rows, err := q.db.QueryContext(ctx, getAllShared, userID)
+func (q *Queries) GetAllArchived(ctx context.Context, userID int64) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getAllArchived, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -144,8 +355,15 @@ func (q *Queries) GetAllShared(ctx context.Context, userID int64) ([]Goal, error
 			&i.Goal,
 			&i.Due,
 			&i.VisibleToPublic,
-			&i.Achieved,
 			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
 		); err != nil {
 			return nil, err
 		}
@@ -160,29 +378,778 @@ func (q *Queries) GetAllShared(ctx context.Context, userID int64) ([]Goal, error
 	return items, nil
 }
 
-const update = `-- name: Update :execresult
-UPDATE goals
-SET goal = ?, description = ?, due = ?, visible_to_public = ?, achieved = ?
-WHERE id = ? AND user_id = ?
+const getAllDependencies = `-- name: GetAllDependencies :many
+SELECT goal_id, depends_on_id FROM goal_dependencies
+WHERE user_id = ?
 `
 
-type UpdateParams struct {
-	Goal            sql.NullString
-	Description     sql.NullString
-	Due             sql.NullInt64
-	VisibleToPublic sql.NullInt64
-	Achieved        sql.NullInt64
-	ID              int64
+type GetAllDependenciesRow struct {
+	GoalID      int64
+	DependsOnID int64
+}
+
+func (q *Queries) GetAllDependencies(ctx context.Context, userID int64) ([]GetAllDependenciesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllDependencies, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllDependenciesRow
+	for rows.Next() {
+		var i GetAllDependenciesRow
+		if err := rows.Scan(&i.GoalID, &i.DependsOnID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllNeedingAttention = `-- name: GetAllNeedingAttention :many
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND archived_at IS NULL AND due IS NOT NULL
+  AND status NOT IN ('achieved', 'abandoned')
+  AND (due < CAST(? AS INTEGER) OR status = 'at_risk')
+ORDER BY due ASC, id ASC
+`
+
+type GetAllNeedingAttentionParams struct {
+	UserID    int64
+	DueBefore int64
+}
+
+func (q *Queries) GetAllNeedingAttention(ctx context.Context, arg GetAllNeedingAttentionParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getAllNeedingAttention, arg.UserID, arg.DueBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllRecurring = `-- name: GetAllRecurring :many
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND recurrence IS NOT NULL AND next_occurrence_id IS NULL
+  AND (archived_at IS NULL OR CAST(? AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(? AS INTEGER) = 0)
+ORDER BY due ASC, id ASC
+`
+
+type GetAllRecurringParams struct {
 	UserID          int64
+	IncludeArchived int64
+	Shared          int64
 }
 
-func (q *Queries) Update(ctx context.Context, arg UpdateParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, update,
-		arg.Goal,
-		arg.Description,
-		arg.Due,
-		arg.VisibleToPublic,
+func (q *Queries) GetAllRecurring(ctx context.Context, arg GetAllRecurringParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getAllRecurring, arg.UserID, arg.IncludeArchived, arg.Shared)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTrashed = `-- name: GetAllTrashed :many
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetAllTrashed(ctx context.Context, userID int64) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getAllTrashed, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDependents = `-- name: GetDependents :many
+SELECT goals.id, goals.user_id, goals.goal, goals.due, goals.visible_to_public, goals.description, goals.recurrence, goals.next_occurrence_id, goals.status, goals.deleted_at, goals.archived_at, goals.journal_public, goals.achieved_at, goals.start_date FROM goals
+JOIN goal_dependencies ON goal_dependencies.goal_id = goals.id
+WHERE goal_dependencies.depends_on_id = ? AND goal_dependencies.user_id = ? AND goals.deleted_at IS NULL
+ORDER BY goals.due ASC
+`
+
+type GetDependentsParams struct {
+	DependsOnID int64
+	UserID      int64
+}
+
+func (q *Queries) GetDependents(ctx context.Context, arg GetDependentsParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getDependents, arg.DependsOnID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPage = `-- name: GetPage :many
+SELECT id, user_id, goal, due, visible_to_public, description, recurrence, next_occurrence_id, status, deleted_at, archived_at, journal_public, achieved_at, start_date FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND (archived_at IS NULL OR CAST(? AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(? AS INTEGER) = 0)
+  AND IFNULL(visible_to_public, 0) = COALESCE(CAST(? AS INTEGER), IFNULL(visible_to_public, 0))
+  AND (status = 'achieved') = COALESCE(CAST(? AS INTEGER), status = 'achieved')
+  AND due >= COALESCE(CAST(? AS INTEGER), due)
+  AND COALESCE(MIN(start_date, due), due) < COALESCE(CAST(? AS INTEGER), due + 1)
+  AND due < COALESCE(CAST(? AS INTEGER), due + 1)
+  AND (due, id) > (CAST(? AS INTEGER), CAST(? AS INTEGER))
+ORDER BY due ASC, id ASC
+LIMIT ?
+`
+
+type GetPageParams struct {
+	UserID          int64
+	IncludeArchived int64
+	Shared          int64
+	Visible         sql.NullInt64
+	Achieved        sql.NullInt64
+	DueFrom         sql.NullInt64
+	StartBefore     sql.NullInt64
+	DueBefore       sql.NullInt64
+	AfterDue        int64
+	AfterID         int64
+	Limit           int64
+}
+
+func (q *Queries) GetPage(ctx context.Context, arg GetPageParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getPage,
+		arg.UserID,
+		arg.IncludeArchived,
+		arg.Shared,
+		arg.Visible,
 		arg.Achieved,
+		arg.DueFrom,
+		arg.StartBefore,
+		arg.DueBefore,
+		arg.AfterDue,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrerequisites = `-- name: GetPrerequisites :many
+SELECT goals.id, goals.user_id, goals.goal, goals.due, goals.visible_to_public, goals.description, goals.recurrence, goals.next_occurrence_id, goals.status, goals.deleted_at, goals.archived_at, goals.journal_public, goals.achieved_at, goals.start_date FROM goals
+JOIN goal_dependencies ON goal_dependencies.depends_on_id = goals.id
+WHERE goal_dependencies.goal_id = ? AND goal_dependencies.user_id = ? AND goals.deleted_at IS NULL
+ORDER BY goals.due ASC
+`
+
+type GetPrerequisitesParams struct {
+	GoalID int64
+	UserID int64
+}
+
+func (q *Queries) GetPrerequisites(ctx context.Context, arg GetPrerequisitesParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getPrerequisites, arg.GoalID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Goal,
+			&i.Due,
+			&i.VisibleToPublic,
+			&i.Description,
+			&i.Recurrence,
+			&i.NextOccurrenceID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.JournalPublic,
+			&i.AchievedAt,
+			&i.StartDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRescheduleItems = `-- name: GetRescheduleItems :many
+SELECT id, reschedule_id, goal_id, user_id, previous_due, previous_start_date, due FROM goal_reschedule_items
+WHERE reschedule_id = ? AND user_id = ?
+ORDER BY id ASC
+`
+
+type GetRescheduleItemsParams struct {
+	RescheduleID int64
+	UserID       int64
+}
+
+func (q *Queries) GetRescheduleItems(ctx context.Context, arg GetRescheduleItemsParams) ([]GoalRescheduleItem, error) {
+	rows, err := q.db.QueryContext(ctx, getRescheduleItems, arg.RescheduleID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GoalRescheduleItem
+	for rows.Next() {
+		var i GoalRescheduleItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.RescheduleID,
+			&i.GoalID,
+			&i.UserID,
+			&i.PreviousDue,
+			&i.PreviousStartDate,
+			&i.Due,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRevision = `-- name: GetRevision :one
+SELECT id, goal_id, user_id, goal, description, due, created_at FROM goal_revisions
+WHERE id = ? AND goal_id = ? AND user_id = ?
+`
+
+type GetRevisionParams struct {
+	ID     int64
+	GoalID int64
+	UserID int64
+}
+
+func (q *Queries) GetRevision(ctx context.Context, arg GetRevisionParams) (GoalRevision, error) {
+	row := q.db.QueryRowContext(ctx, getRevision, arg.ID, arg.GoalID, arg.UserID)
+	var i GoalRevision
+	err := row.Scan(
+		&i.ID,
+		&i.GoalID,
+		&i.UserID,
+		&i.Goal,
+		&i.Description,
+		&i.Due,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRevisions = `-- name: GetRevisions :many
+SELECT id, goal_id, user_id, goal, description, due, created_at FROM goal_revisions
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+type GetRevisionsParams struct {
+	GoalID int64
+	UserID int64
+}
+
+func (q *Queries) GetRevisions(ctx context.Context, arg GetRevisionsParams) ([]GoalRevision, error) {
+	rows, err := q.db.QueryContext(ctx, getRevisions, arg.GoalID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GoalRevision
+	for rows.Next() {
+		var i GoalRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.GoalID,
+			&i.UserID,
+			&i.Goal,
+			&i.Description,
+			&i.Due,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getScheduleConflicts = `-- name: GetScheduleConflicts :many
+SELECT goal_dependencies.goal_id, prerequisite.goal AS prerequisite_goal
+FROM goal_dependencies
+JOIN goals AS dependent ON dependent.id = goal_dependencies.goal_id
+JOIN goals AS prerequisite ON prerequisite.id = goal_dependencies.depends_on_id
+WHERE goal_dependencies.user_id = ? AND dependent.due < prerequisite.due
+  AND dependent.deleted_at IS NULL AND prerequisite.deleted_at IS NULL
+ORDER BY prerequisite.due ASC
+`
+
+type GetScheduleConflictsRow struct {
+	GoalID           int64
+	PrerequisiteGoal sql.NullString
+}
+
+func (q *Queries) GetScheduleConflicts(ctx context.Context, userID int64) ([]GetScheduleConflictsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getScheduleConflicts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetScheduleConflictsRow
+	for rows.Next() {
+		var i GetScheduleConflictsRow
+		if err := rows.Scan(&i.GoalID, &i.PrerequisiteGoal); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStatusHistory = `-- name: GetStatusHistory :many
+SELECT id, goal_id, user_id, from_status, to_status, note, created_at FROM goal_status_history
+WHERE goal_id = ? AND user_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+type GetStatusHistoryParams struct {
+	GoalID int64
+	UserID int64
+}
+
+func (q *Queries) GetStatusHistory(ctx context.Context, arg GetStatusHistoryParams) ([]GoalStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getStatusHistory, arg.GoalID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GoalStatusHistory
+	for rows.Next() {
+		var i GoalStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.GoalID,
+			&i.UserID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getYears = `-- name: GetYears :many
+SELECT DISTINCT CAST(strftime('%Y', due, 'unixepoch') AS INTEGER) AS year FROM goals
+WHERE user_id = ? AND deleted_at IS NULL AND due IS NOT NULL
+  AND (archived_at IS NULL OR CAST(? AS INTEGER) = 1)
+  AND (visible_to_public = 1 OR CAST(? AS INTEGER) = 0)
+ORDER BY year ASC
+`
+
+type GetYearsParams struct {
+	UserID          int64
+	IncludeArchived int64
+	Shared          int64
+}
+
+func (q *Queries) GetYears(ctx context.Context, arg GetYearsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getYears, arg.UserID, arg.IncludeArchived, arg.Shared)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var year int64
+		if err := rows.Scan(&year); err != nil {
+			return nil, err
+		}
+		items = append(items, year)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeTrash = `-- name: PurgeTrash :execresult
+DELETE FROM goals
+WHERE deleted_at IS NOT NULL AND deleted_at < ?
+`
+
+func (q *Queries) PurgeTrash(ctx context.Context, deletedAt sql.NullInt64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeTrash, deletedAt)
+}
+
+const restore = `-- name: Restore :execresult
+UPDATE goals
+SET deleted_at = NULL
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+`
+
+type RestoreParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) Restore(ctx context.Context, arg RestoreParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, restore, arg.ID, arg.UserID)
+}
+
+const setJournalPublic = `-- name: SetJournalPublic :execresult
+UPDATE goals
+SET journal_public = ?
+WHERE id = ? AND user_id = ?
+`
+
+type SetJournalPublicParams struct {
+	JournalPublic int64
+	ID            int64
+	UserID        int64
+}
+
+func (q *Queries) SetJournalPublic(ctx context.Context, arg SetJournalPublicParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setJournalPublic, arg.JournalPublic, arg.ID, arg.UserID)
+}
+
+const setNextOccurrence = `-- name: SetNextOccurrence :execresult
+UPDATE goals
+SET next_occurrence_id = ?
+WHERE id = ? AND user_id = ?
+`
+
+type SetNextOccurrenceParams struct {
+	NextOccurrenceID sql.NullInt64
+	ID               int64
+	UserID           int64
+}
+
+func (q *Queries) SetNextOccurrence(ctx context.Context, arg SetNextOccurrenceParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setNextOccurrence, arg.NextOccurrenceID, arg.ID, arg.UserID)
+}
+
+const setSchedule = `-- name: SetSchedule :execresult
+UPDATE goals
+SET due = ?, start_date = ?
+WHERE id = ? AND user_id = ? AND due = ?
+`
+
+type SetScheduleParams struct {
+	Due        sql.NullInt64
+	StartDate  sql.NullInt64
+	ID         int64
+	UserID     int64
+	CurrentDue sql.NullInt64
+}
+
+func (q *Queries) SetSchedule(ctx context.Context, arg SetScheduleParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setSchedule,
+		arg.Due,
+		arg.StartDate,
+		arg.ID,
+		arg.UserID,
+		arg.CurrentDue,
+	)
+}
+
+const trash = `-- name: Trash :execresult
+UPDATE goals
+SET deleted_at = unixepoch()
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type TrashParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) Trash(ctx context.Context, arg TrashParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, trash, arg.ID, arg.UserID)
+}
+
+const unarchive = `-- name: Unarchive :execresult
+UPDATE goals
+SET archived_at = NULL
+WHERE id = ? AND user_id = ? AND archived_at IS NOT NULL
+`
+
+type UnarchiveParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) Unarchive(ctx context.Context, arg UnarchiveParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, unarchive, arg.ID, arg.UserID)
+}
+
+const update = `-- name: Update :execresult
+UPDATE goals
+SET goal = ?, description = ?, due = ?, visible_to_public = ?, status = ?, recurrence = ?, achieved_at = ?, start_date = ?
+WHERE id = ? AND user_id = ?
+`
+
+type UpdateParams struct {
+	Goal            sql.NullString
+	Description     sql.NullString
+	Due             sql.NullInt64
+	VisibleToPublic sql.NullInt64
+	Status          string
+	Recurrence      sql.NullString
+	AchievedAt      sql.NullInt64
+	StartDate       sql.NullInt64
+	ID              int64
+	UserID          int64
+}
+
+func (q *Queries) Update(ctx context.Context, arg UpdateParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, update,
+		arg.Goal,
+		arg.Description,
+		arg.Due,
+		arg.VisibleToPublic,
+		arg.Status,
+		arg.Recurrence,
+		arg.AchievedAt,
+		arg.StartDate,
+		arg.ID,
+		arg.UserID,
+	)
+}
+
+const updateContent = `-- name: UpdateContent :execresult
+UPDATE goals
+SET goal = ?, description = ?, due = ?
+WHERE id = ? AND user_id = ?
+`
+
+type UpdateContentParams struct {
+	Goal        sql.NullString
+	Description sql.NullString
+	Due         sql.NullInt64
+	ID          int64
+	UserID      int64
+}
+
+func (q *Queries) UpdateContent(ctx context.Context, arg UpdateContentParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateContent,
+		arg.Goal,
+		arg.Description,
+		arg.Due,
+		arg.ID,
+		arg.UserID,
+	)
+}
+
+const updateDue = `-- name: UpdateDue :execresult
+UPDATE goals
+SET due = ?, start_date = start_date + CAST(? AS INTEGER)
+WHERE id = ? AND user_id = ?
+`
+
+type UpdateDueParams struct {
+	Due        sql.NullInt64
+	StartShift int64
+	ID         int64
+	UserID     int64
+}
+
+func (q *Queries) UpdateDue(ctx context.Context, arg UpdateDueParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateDue,
+		arg.Due,
+		arg.StartShift,
 		arg.ID,
 		arg.UserID,
 	)
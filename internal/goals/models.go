@@ -9,11 +9,63 @@ import (
 )
 
 type Goal struct {
-	ID              int64
-	UserID          int64
-	Goal            sql.NullString
-	Due             sql.NullInt64
-	VisibleToPublic sql.NullInt64
-	Achieved        sql.NullInt64
-	Description     sql.NullString
+	ID               int64
+	UserID           int64
+	Goal             sql.NullString
+	Due              sql.NullInt64
+	VisibleToPublic  sql.NullInt64
+	Description      sql.NullString
+	Recurrence       sql.NullString
+	NextOccurrenceID sql.NullInt64
+	Status           string
+	DeletedAt        sql.NullInt64
+	ArchivedAt       sql.NullInt64
+	JournalPublic    int64
+	AchievedAt       sql.NullInt64
+	StartDate        sql.NullInt64
+}
+
+type GoalDependency struct {
+	GoalID      int64
+	DependsOnID int64
+	UserID      int64
+	CreatedAt   int64
+}
+
+type GoalStatusHistory struct {
+	ID         int64
+	GoalID     int64
+	UserID     int64
+	FromStatus sql.NullString
+	ToStatus   string
+	Note       sql.NullString
+	CreatedAt  int64
+}
+
+type GoalRevision struct {
+	ID          int64
+	GoalID      int64
+	UserID      int64
+	Goal        sql.NullString
+	Description sql.NullString
+	Due         sql.NullInt64
+	CreatedAt   int64
+}
+
+type GoalReschedule struct {
+	ID        int64
+	GoalID    int64
+	UserID    int64
+	Delta     int64
+	CreatedAt int64
+}
+
+type GoalRescheduleItem struct {
+	ID                int64
+	RescheduleID      int64
+	GoalID            int64
+	UserID            int64
+	PreviousDue       int64
+	PreviousStartDate sql.NullInt64
+	Due               int64
 }
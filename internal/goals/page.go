@@ -0,0 +1,165 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scope selects the goals of a timeline: all goals of the owner, or the
+// goals on a share link.
+type Scope struct {
+	// Shared leaves out private goals.
+	Shared bool
+	// IncludeArchived adds archived goals.
+	IncludeArchived bool
+}
+
+func (s Scope) params() (includeArchived, shared int64) {
+	if s.IncludeArchived {
+		includeArchived = 1
+	}
+	if s.Shared {
+		shared = 1
+	}
+	return includeArchived, shared
+}
+
+// Cursor is the position of a goal on the timeline, which is sorted by due
+// date and ID.
+type Cursor struct {
+	Due int64
+	ID  int64
+}
+
+// Cursor returns the position of the goal on the timeline.
+func (g Goal) Cursor() Cursor {
+	return Cursor{Due: g.Due.Int64, ID: g.ID}
+}
+
+// String returns the cursor as used in URLs, like "1767225600.42".
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d.%d", c.Due, c.ID)
+}
+
+// ParseCursor reads a cursor written by String.
+func ParseCursor(s string) (Cursor, bool) {
+	dueText, idText, ok := strings.Cut(s, ".")
+	if !ok {
+		return Cursor{}, false
+	}
+
+	due, err := strconv.ParseInt(dueText, 10, 64)
+	if err != nil {
+		return Cursor{}, false
+	}
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil {
+		return Cursor{}, false
+	}
+
+	return Cursor{Due: due, ID: id}, true
+}
+
+// PageParams select goals of a timeline in the order of their due dates.
+// Zero values match all goals.
+type PageParams struct {
+	Scope
+	// Visible and Achieved match goals by visibility and state when valid.
+	Visible  sql.NullBool
+	Achieved sql.NullBool
+	// From matches goals due on or after it.
+	From time.Time
+	// StartBefore matches goals that start, or are due if they don't span
+	// a date range, before it.
+	StartBefore time.Time
+	// DueBefore matches goals due before it.
+	DueBefore time.Time
+	// After matches goals after the cursor.
+	After *Cursor
+	// Limit is the most goals returned. Zero returns all goals.
+	Limit int
+}
+
+// GetPage returns the goals of a timeline that match params, sorted by due
+// date and ID. Goals without a due date are left out.
+func (s *Service) GetPage(ctx context.Context, userID int, params PageParams) ([]Goal, error) {
+	includeArchived, shared := params.Scope.params()
+
+	arg := GetPageParams{
+		UserID:          int64(userID),
+		IncludeArchived: includeArchived,
+		Shared:          shared,
+		Visible:         nullBoolInt(params.Visible),
+		Achieved:        nullBoolInt(params.Achieved),
+		DueFrom:         nullUnix(params.From),
+		StartBefore:     nullUnix(params.StartBefore),
+		DueBefore:       nullUnix(params.DueBefore),
+		AfterDue:        math.MinInt64,
+		// A negative limit is no limit in SQLite.
+		Limit: -1,
+	}
+	if params.After != nil {
+		arg.AfterDue = params.After.Due
+		arg.AfterID = params.After.ID
+	}
+	if params.Limit > 0 {
+		arg.Limit = int64(params.Limit)
+	}
+
+	return s.queries.GetPage(ctx, arg)
+}
+
+// GetAllRecurring returns the goals of a timeline that are the latest of
+// their series, so their upcoming occurrences can be shown.
+func (s *Service) GetAllRecurring(ctx context.Context, userID int, scope Scope) ([]Goal, error) {
+	includeArchived, shared := scope.params()
+
+	return s.queries.GetAllRecurring(ctx, GetAllRecurringParams{
+		UserID:          int64(userID),
+		IncludeArchived: includeArchived,
+		Shared:          shared,
+	})
+}
+
+// GetYears returns the distinct years the goals of a timeline are due in, in
+// ascending order.
+func (s *Service) GetYears(ctx context.Context, userID int, scope Scope) ([]int, error) {
+	includeArchived, shared := scope.params()
+
+	rows, err := s.queries.GetYears(ctx, GetYearsParams{
+		UserID:          int64(userID),
+		IncludeArchived: includeArchived,
+		Shared:          shared,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	years := make([]int, len(rows))
+	for i, year := range rows {
+		years[i] = int(year)
+	}
+	return years, nil
+}
+
+func nullBoolInt(b sql.NullBool) sql.NullInt64 {
+	if !b.Valid {
+		return sql.NullInt64{}
+	}
+	if b.Bool {
+		return sql.NullInt64{Int64: 1, Valid: true}
+	}
+	return sql.NullInt64{Int64: 0, Valid: true}
+}
+
+func nullUnix(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
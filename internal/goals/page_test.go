@@ -0,0 +1,30 @@
+package goals
+
+import "testing"
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Cursor
+		ok   bool
+	}{
+		{in: "1767225600.42", want: Cursor{Due: 1767225600, ID: 42}, ok: true},
+		{in: "-86400.7", want: Cursor{Due: -86400, ID: 7}, ok: true},
+		{in: "", ok: false},
+		{in: "1767225600", ok: false},
+		{in: "soon.42", ok: false},
+		{in: "1767225600.x", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseCursor(tt.in)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("expected %+v %v, got %+v %v", tt.want, tt.ok, got, ok)
+			}
+			if ok && got.String() != tt.in {
+				t.Errorf("expected %q, got %q", tt.in, got.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// RescheduleUndoWindow is how long a reschedule can be undone. Older
+// reschedules are pruned with PruneReschedules.
+const RescheduleUndoWindow = time.Hour
+
+// RescheduleForm moves a goal together with all goals due on or after it.
+// The move is given either as a number of days or as the new due date of the
+// goal. The date wins if both are set.
+type RescheduleForm struct {
+	Days                int    `form:"days"`
+	Date                string `form:"date"`
+	SkipAchieved        bool   `form:"skip_achieved"`
+	validator.Validator `form:"-"`
+}
+
+// Validate checks the form for a goal that is due at due.
+func (f *RescheduleForm) Validate(due time.Time) {
+	f.Check(f.Days != 0 || f.Date != "", "days", "Enter a number of days or a new due date")
+	f.Check(f.Days >= -MaxBulkShiftDays && f.Days <= MaxBulkShiftDays, "days", fmt.Sprintf("Days must be between %d and %d", -MaxBulkShiftDays, MaxBulkShiftDays))
+
+	if f.Date != "" {
+		_, err := time.Parse(HTMLDateFormat, f.Date)
+		f.Check(err == nil, "date", "New due date must be a valid date")
+	}
+
+	if f.Valid() && f.Date != "" {
+		days := f.dateOffset(due)
+		f.Check(days >= -MaxBulkShiftDays && days <= MaxBulkShiftDays, "date", fmt.Sprintf("New due date must be within %d days of the current due date", MaxBulkShiftDays))
+	}
+
+	if f.Valid() {
+		f.Check(f.Offset(due) != 0, "date", "New due date is the current due date")
+	}
+}
+
+// Offset returns by how many days the goals move when the first of them is
+// due at due. Offsets beyond MaxBulkShiftDays are 0, like invalid dates.
+func (f *RescheduleForm) Offset(due time.Time) int {
+	if f.Date == "" {
+		return f.Days
+	}
+
+	days := f.dateOffset(due)
+	if days < -MaxBulkShiftDays || days > MaxBulkShiftDays {
+		return 0
+	}
+	return days
+}
+
+// dateOffset returns the days between due and the date of the form, or 0 if
+// the date is invalid.
+func (f *RescheduleForm) dateOffset(due time.Time) int {
+	date, err := time.Parse(HTMLDateFormat, f.Date)
+	if err != nil {
+		return 0
+	}
+
+	due = due.UTC()
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	return int(date.Sub(dueDay) / (24 * time.Hour))
+}
+
+// Shift is a goal moved by a reschedule together with its new dates.
+type Shift struct {
+	Goal  View
+	Due   time.Time
+	Start time.Time
+}
+
+// rescheduled returns the goals of goalList that move when from is
+// rescheduled: from itself and all goals due on or after it. Achieved goals
+// other than from are left out if skipAchieved is set.
+func rescheduled(goalList []Goal, from Goal, skipAchieved bool) []Goal {
+	if !from.Due.Valid {
+		return nil
+	}
+
+	moved := []Goal{from}
+	for _, g := range goalList {
+		if g.ID == from.ID || !g.Due.Valid || g.Due.Int64 < from.Due.Int64 {
+			continue
+		}
+		if skipAchieved && Status(g.Status) == Achieved {
+			continue
+		}
+		moved = append(moved, g)
+	}
+
+	return moved
+}
+
+// PreviewReschedule returns the goals that Reschedule would move and where
+// they would end up, without changing anything.
+func (s *Service) PreviewReschedule(ctx context.Context, goalID, userID int, form *RescheduleForm) ([]Shift, error) {
+	goal, err := s.Get(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goalList, err := s.queries.GetAll(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	delta := int64(form.Offset(time.Unix(goal.Due.Int64, 0))) * secondsPerDay
+	shifts := []Shift{}
+	for _, g := range rescheduled(goalList, goal, form.SkipAchieved) {
+		view := g.ToView()
+		shift := Shift{
+			Goal: view,
+			Due:  time.Unix(g.Due.Int64+delta, 0),
+		}
+		if !view.Start.IsZero() {
+			shift.Start = time.Unix(g.StartDate.Int64+delta, 0)
+		}
+		shifts = append(shifts, shift)
+	}
+
+	return shifts, nil
+}
+
+// Reschedule moves the goal and all goals due on or after it by the delta of
+// the form. The previous dates are kept, so the whole reschedule can be
+// undone with UndoReschedule within RescheduleUndoWindow. It returns the ID of the reschedule and the
+// number of goals moved.
+func (s *Service) Reschedule(ctx context.Context, goalID, userID int, form *RescheduleForm) (int, int, error) {
+	var rescheduleID, moved int
+	err := s.inTx(ctx, func(q *Queries) error {
+		goal, err := q.Get(ctx, GetParams{ID: int64(goalID), UserID: int64(userID)})
+		if err != nil {
+			return err
+		}
+
+		goalList, err := q.GetAll(ctx, int64(userID))
+		if err != nil {
+			return err
+		}
+
+		delta := int64(form.Offset(time.Unix(goal.Due.Int64, 0))) * secondsPerDay
+		goalsToMove := rescheduled(goalList, goal, form.SkipAchieved)
+		if delta == 0 || len(goalsToMove) == 0 {
+			return nil
+		}
+
+		reschedule, err := q.CreateReschedule(ctx, CreateRescheduleParams{
+			GoalID: goal.ID,
+			UserID: goal.UserID,
+			Delta:  delta,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, g := range goalsToMove {
+			due := sql.NullInt64{Int64: g.Due.Int64 + delta, Valid: true}
+
+			if err := q.CreateRescheduleItem(ctx, CreateRescheduleItemParams{
+				RescheduleID:      reschedule.ID,
+				GoalID:            g.ID,
+				UserID:            g.UserID,
+				PreviousDue:       g.Due.Int64,
+				PreviousStartDate: g.StartDate,
+				Due:               due.Int64,
+			}); err != nil {
+				return err
+			}
+
+			if err := saveRevision(ctx, q, g, g.Goal, g.Description, due); err != nil {
+				return err
+			}
+
+			if _, err := q.UpdateDue(ctx, UpdateDueParams{
+				Due:        due,
+				StartShift: delta,
+				ID:         g.ID,
+				UserID:     g.UserID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		rescheduleID = int(reschedule.ID)
+		moved = len(goalsToMove)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rescheduleID, moved, nil
+}
+
+// UndoReschedule moves the goals of a reschedule back to their previous
+// dates. Goals whose due date was changed since keep it. A reschedule can
+// only be undone once and within RescheduleUndoWindow; sql.ErrNoRows is
+// returned afterwards. It returns the number of goals moved back and the
+// number of goals skipped.
+func (s *Service) UndoReschedule(ctx context.Context, rescheduleID, userID int) (int, int, error) {
+	var moved, skipped int
+	err := s.inTx(ctx, func(q *Queries) error {
+		items, err := q.GetRescheduleItems(ctx, GetRescheduleItemsParams{
+			RescheduleID: int64(rescheduleID),
+			UserID:       int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err := q.DeleteReschedule(ctx, DeleteRescheduleParams{
+			ID:        int64(rescheduleID),
+			UserID:    int64(userID),
+			CreatedAt: time.Now().Add(-RescheduleUndoWindow).Unix(),
+		})
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		for _, item := range items {
+			due := sql.NullInt64{Int64: item.PreviousDue, Valid: true}
+
+			// Goals moved to the trash since are restored to their dates
+			// too, but without a revision.
+			goal, err := q.Get(ctx, GetParams{ID: item.GoalID, UserID: item.UserID})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			found := err == nil
+
+			result, err := q.SetSchedule(ctx, SetScheduleParams{
+				Due:        due,
+				StartDate:  item.PreviousStartDate,
+				ID:         item.GoalID,
+				UserID:     item.UserID,
+				CurrentDue: sql.NullInt64{Int64: item.Due, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if rowsAffected == 0 {
+				skipped++
+				continue
+			}
+
+			if found {
+				if err := saveRevision(ctx, q, goal, goal.Goal, goal.Description, due); err != nil {
+					return err
+				}
+			}
+			moved++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return moved, skipped, nil
+}
+
+// PruneReschedules deletes reschedules that can no longer be undone.
+func (s *Service) PruneReschedules(ctx context.Context, now time.Time) error {
+	return s.queries.DeleteReschedulesBefore(ctx, now.Add(-RescheduleUndoWindow).Unix())
+}
@@ -0,0 +1,79 @@
+package goals
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRescheduleOffset(t *testing.T) {
+	due := time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		form RescheduleForm
+		want int
+	}{
+		{name: "days later", form: RescheduleForm{Days: 14}, want: 14},
+		{name: "days earlier", form: RescheduleForm{Days: -3}, want: -3},
+		{name: "new date", form: RescheduleForm{Date: "2026-12-04"}, want: 14},
+		{name: "new date wins over days", form: RescheduleForm{Days: 1, Date: "2026-11-10"}, want: -10},
+		{name: "same date", form: RescheduleForm{Date: "2026-11-20"}, want: 0},
+		{name: "invalid date", form: RescheduleForm{Date: "soon"}, want: 0},
+		{name: "date too far", form: RescheduleForm{Date: "2099-01-01"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.form.Offset(due.Add(9 * time.Hour)); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRescheduled(t *testing.T) {
+	goal := func(id int64, due string, status Status) Goal {
+		d, _ := time.Parse(HTMLDateFormat, due)
+		return Goal{ID: id, Due: sql.NullInt64{Int64: d.Unix(), Valid: true}, Status: string(status)}
+	}
+
+	goalList := []Goal{
+		goal(1, "2026-10-01", InProgress),
+		goal(2, "2026-11-01", Achieved),
+		goal(3, "2026-11-01", NotStarted),
+		goal(4, "2026-12-01", Achieved),
+		goal(5, "2027-01-01", NotStarted),
+		{ID: 6, Status: string(NotStarted)},
+	}
+
+	ids := func(goalList []Goal) []int64 {
+		ids := []int64{}
+		for _, g := range goalList {
+			ids = append(ids, g.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name         string
+		from         Goal
+		skipAchieved bool
+		want         []int64
+	}{
+		{name: "goal and later goals", from: goalList[2], want: []int64{3, 2, 4, 5}},
+		{name: "skip achieved", from: goalList[2], skipAchieved: true, want: []int64{3, 5}},
+		{name: "achieved goal itself moves", from: goalList[1], skipAchieved: true, want: []int64{2, 3, 5}},
+		{name: "last goal", from: goalList[4], want: []int64{5}},
+		{name: "no due date", from: goalList[5], want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(rescheduled(goalList, tt.from, tt.skipAchieved)); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Revision is an earlier version of a goal together with the changes that
+// were made to it by the following edit.
+type Revision struct {
+	ID                 int64
+	CreatedAt          time.Time
+	Goal               []DiffPart
+	GoalChanged        bool
+	Description        []DiffPart
+	DescriptionChanged bool
+	DueFrom            time.Time
+	DueTo              time.Time
+	DueChanged         bool
+}
+
+// GetRevisions returns the earlier versions of a goal, newest first. Each
+// revision is compared to the version that replaced it.
+func (s *Service) GetRevisions(ctx context.Context, goalID, userID int) ([]Revision, error) {
+	goal, err := s.Get(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetRevisions(ctx, GetRevisionsParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newer := GoalRevision{Goal: goal.Goal, Description: goal.Description, Due: goal.Due}
+	revisions := make([]Revision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, Revision{
+			ID:                 row.ID,
+			CreatedAt:          time.Unix(row.CreatedAt, 0),
+			Goal:               DiffWords(row.Goal.String, newer.Goal.String),
+			GoalChanged:        row.Goal.String != newer.Goal.String,
+			Description:        DiffWords(row.Description.String, newer.Description.String),
+			DescriptionChanged: row.Description.String != newer.Description.String,
+			DueFrom:            time.Unix(row.Due.Int64, 0),
+			DueTo:              time.Unix(newer.Due.Int64, 0),
+			DueChanged:         row.Due != newer.Due,
+		})
+		newer = row
+	}
+
+	return revisions, nil
+}
+
+// RestoreRevision sets the title, description and due date of a goal back
+// to an earlier version. The replaced version is kept as a revision, so a
+// restore can be undone.
+func (s *Service) RestoreRevision(ctx context.Context, goalID, revisionID, userID int) (int, error) {
+	var rowsAffected int64
+	err := s.inTx(ctx, func(q *Queries) error {
+		revision, err := q.GetRevision(ctx, GetRevisionParams{
+			ID:     int64(revisionID),
+			GoalID: int64(goalID),
+			UserID: int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		previous, err := q.Get(ctx, GetParams{
+			ID:     int64(goalID),
+			UserID: int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := saveRevision(ctx, q, previous, revision.Goal, revision.Description, revision.Due); err != nil {
+			return err
+		}
+
+		result, err := q.UpdateContent(ctx, UpdateContentParams{
+			Goal:        revision.Goal,
+			Description: revision.Description,
+			Due:         revision.Due,
+			ID:          int64(goalID),
+			UserID:      int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// saveRevision stores the title, description and due date of previous if an
+// update is about to change any of them.
+func saveRevision(ctx context.Context, q *Queries, previous Goal, goal, description sql.NullString, due sql.NullInt64) error {
+	if previous.Goal.String == goal.String &&
+		previous.Description.String == description.String &&
+		previous.Due == due {
+		return nil
+	}
+
+	return q.CreateRevision(ctx, CreateRevisionParams{
+		GoalID:      previous.ID,
+		UserID:      previous.UserID,
+		Goal:        previous.Goal,
+		Description: previous.Description,
+		Due:         previous.Due,
+	})
+}
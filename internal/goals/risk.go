@@ -0,0 +1,120 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// dueSoonDays is how many days ahead goals count as due this week.
+const dueSoonDays = 7
+
+// Thresholds decide when an open goal is at risk.
+type Thresholds struct {
+	// Days is how many days before its due date a goal can be at risk.
+	Days int
+	// Progress is the share of completed success criteria in percent a goal
+	// needs to not be at risk.
+	Progress int
+}
+
+// Overview lists the open goals that need attention, each list by due date.
+type Overview struct {
+	// Overdue goals are past their due date.
+	Overdue []View
+	// DueThisWeek goals are due within the next 7 days.
+	DueThisWeek []View
+	// AtRisk goals are due within the thresholds with too few success
+	// criteria completed, or were marked at risk by hand.
+	AtRisk []View
+}
+
+// Empty reports whether no goal needs attention.
+func (o Overview) Empty() bool {
+	return len(o.Overdue) == 0 && len(o.DueThisWeek) == 0 && len(o.AtRisk) == 0
+}
+
+// Assess sorts goals into an Overview. Achieved, abandoned and archived goals
+// and goals without a due date are left out. Goals are expected to be sorted
+// by due date and to have their success criteria counted.
+func Assess(goals []View, now time.Time, t Thresholds) Overview {
+	var o Overview
+	for _, g := range goals {
+		if g.Status.Closed() || g.Archived || g.Upcoming || g.Due.IsZero() {
+			continue
+		}
+
+		days := g.DaysLeft(now)
+		if days < 0 {
+			o.Overdue = append(o.Overdue, g)
+			continue
+		}
+
+		if days < dueSoonDays {
+			o.DueThisWeek = append(o.DueThisWeek, g)
+		}
+
+		if g.Status == AtRisk || (days <= t.Days && g.BehindOn(t.Progress)) {
+			o.AtRisk = append(o.AtRisk, g)
+		}
+	}
+	return o
+}
+
+// GetAllNeedingAttention returns the open goals that Assess may list with
+// the thresholds t: goals due before the end of the risk window and goals
+// marked at risk, sorted by due date.
+func (s *Service) GetAllNeedingAttention(ctx context.Context, userID int, now time.Time, t Thresholds) ([]Goal, error) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return s.queries.GetAllNeedingAttention(ctx, GetAllNeedingAttentionParams{
+		UserID:    int64(userID),
+		DueBefore: today.AddDate(0, 0, max(t.Days+1, dueSoonDays)).Unix(),
+	})
+}
+
+// DaysLeft returns the number of days from now until the due date. It is
+// negative for goals past their due date.
+func (v View) DaysLeft(now time.Time) int {
+	due := v.Due.UTC()
+	now = now.UTC()
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(dueDay.Sub(today).Hours() / 24)
+}
+
+// DueLabel describes the due date relative to now, e.g. "Due in 3 days" or
+// "2 days overdue".
+func (v View) DueLabel(now time.Time) string {
+	days := v.DaysLeft(now)
+	switch {
+	case days == 0:
+		return "Due today"
+	case days == 1:
+		return "Due tomorrow"
+	case days > 1:
+		return fmt.Sprintf("Due in %d days", days)
+	case days == -1:
+		return "1 day overdue"
+	default:
+		return fmt.Sprintf("%d days overdue", -days)
+	}
+}
+
+// CriteriaProgress returns the share of completed success criteria in
+// percent.
+func (v View) CriteriaProgress() int {
+	if v.TotalCriteriaCount == 0 {
+		return 0
+	}
+	return v.CompletedCriteriaCount * 100 / v.TotalCriteriaCount
+}
+
+// BehindOn reports whether less than progress percent of the success criteria
+// are completed. Goals without success criteria are never behind, there is
+// nothing to measure them by.
+func (v View) BehindOn(progress int) bool {
+	return v.TotalCriteriaCount > 0 && v.CompletedCriteriaCount*100 < progress*v.TotalCriteriaCount
+}
@@ -0,0 +1,77 @@
+package goals
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssess(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	day := func(days int) time.Time {
+		return time.Date(2026, 10, 19+days, 0, 0, 0, 0, time.UTC)
+	}
+	thresholds := Thresholds{Days: 14, Progress: 50}
+
+	tests := []struct {
+		name        string
+		goal        View
+		overdue     bool
+		dueThisWeek bool
+		atRisk      bool
+	}{
+		{name: "past due", goal: View{Due: day(-1)}, overdue: true},
+		{name: "due today", goal: View{Due: day(0)}, dueThisWeek: true},
+		{name: "due in 6 days", goal: View{Due: day(6)}, dueThisWeek: true},
+		{name: "due in 7 days", goal: View{Due: day(7)}},
+		{name: "achieved", goal: View{Due: day(-1), Status: Achieved}},
+		{name: "abandoned", goal: View{Due: day(-1), Status: Abandoned}},
+		{name: "archived", goal: View{Due: day(-1), Archived: true}},
+		{name: "upcoming", goal: View{Due: day(-1), Upcoming: true}},
+		{name: "no due date", goal: View{}},
+		{name: "behind within days", goal: View{Due: day(14), TotalCriteriaCount: 4, CompletedCriteriaCount: 1}, atRisk: true},
+		{name: "behind due soon", goal: View{Due: day(3), TotalCriteriaCount: 2}, dueThisWeek: true, atRisk: true},
+		{name: "behind later", goal: View{Due: day(15), TotalCriteriaCount: 4, CompletedCriteriaCount: 1}},
+		{name: "on track", goal: View{Due: day(10), TotalCriteriaCount: 2, CompletedCriteriaCount: 1}},
+		{name: "no criteria", goal: View{Due: day(10)}},
+		{name: "marked at risk", goal: View{Due: day(60), Status: AtRisk}, atRisk: true},
+		{name: "overdue and behind", goal: View{Due: day(-2), TotalCriteriaCount: 2}, overdue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Assess([]View{tt.goal}, now, thresholds)
+			if got := len(o.Overdue) == 1; got != tt.overdue {
+				t.Errorf("expected overdue %t, got %t", tt.overdue, got)
+			}
+			if got := len(o.DueThisWeek) == 1; got != tt.dueThisWeek {
+				t.Errorf("expected due this week %t, got %t", tt.dueThisWeek, got)
+			}
+			if got := len(o.AtRisk) == 1; got != tt.atRisk {
+				t.Errorf("expected at risk %t, got %t", tt.atRisk, got)
+			}
+		})
+	}
+}
+
+func TestDueLabel(t *testing.T) {
+	now := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		due  time.Time
+		want string
+	}{
+		{due: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), want: "Due today"},
+		{due: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), want: "Due tomorrow"},
+		{due: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), want: "Due in 5 days"},
+		{due: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), want: "1 day overdue"},
+		{due: time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC), want: "30 days overdue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := (View{Due: tt.due}).DueLabel(now); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
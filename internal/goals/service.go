@@ -3,45 +3,221 @@ package goals
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/goalkeepr/internal/recurrence"
 	"github.com/bit8bytes/toolbox/validator"
 )
 
 const HTMLDateFormat = "2006-01-02"
 
+// ErrDependencyCycle is returned when adding a dependency would make a goal
+// depend on itself, directly or through other goals.
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// MaxDescriptionChars limits descriptions, which are rendered as Markdown.
+const MaxDescriptionChars = 5000
+
+// DescriptionTooLongMessage returns the form error of descriptions over
+// MaxDescriptionChars.
+func DescriptionTooLongMessage() string {
+	return fmt.Sprintf("Description cannot be more than %d characters", MaxDescriptionChars)
+}
+
 type Form struct {
 	ID                  int    `form:"id"`
 	Goal                string `form:"goal"`
 	Description         string `form:"description"`
+	Start               string `form:"start"`
 	Due                 string `form:"due"`
-	Achieved            bool   `form:"achieved"`
+	Status              string `form:"status"`
+	StatusNote          string `form:"status_note"`
+	AchievedAt          string `form:"achieved_at"`
 	VisibleToPublic     bool   `form:"visible"`
+	ShiftDependents     bool   `form:"shift_dependents"`
+	Repeat              string `form:"repeat"`
+	RepeatInterval      int    `form:"repeat_interval"`
+	RepeatUntil         string `form:"repeat_until"`
+	RepeatCount         int    `form:"repeat_count"`
 	validator.Validator `form:"-"`
 }
 
 func (f *Form) Validate() {
 	f.Check(validator.NotBlank(f.Goal), "goal", "Goal cannot be blank")
 	f.Check(validator.MaxChars(f.Goal, 500), "goal", "Goal cannot be more than 500 characters")
+	f.Check(validator.MaxChars(f.Description, MaxDescriptionChars), "description", DescriptionTooLongMessage())
 	f.Check(validator.NotBlank(f.Due), "due", "Due date cannot be blank")
+
+	if f.Start != "" {
+		start, err := time.Parse(HTMLDateFormat, f.Start)
+		due, dueErr := time.Parse(HTMLDateFormat, f.Due)
+		f.Check(err == nil, "start", "Start date must be a valid date")
+		f.Check(err != nil || dueErr != nil || !start.After(due), "start", "Start date cannot be after the due date")
+	}
+	f.Check(f.Status == "" || Status(f.Status).Valid(), "status", "Choose a valid status")
+	f.Check(validator.MaxChars(f.StatusNote, 500), "status_note", "Note cannot be more than 500 characters")
+
+	if f.Achieved() && f.AchievedAt != "" {
+		achievedAt, err := time.Parse(HTMLDateFormat, f.AchievedAt)
+		f.Check(err == nil, "achieved_at", "Achieved on must be a valid date")
+		f.Check(err != nil || !achievedAt.After(time.Now()), "achieved_at", "Achieved on cannot be in the future")
+	}
+
+	if f.Repeat == "" {
+		return
+	}
+
+	rule, err := f.rule()
+	if err != nil {
+		f.AddError("repeat", "Choose a valid repetition")
+		return
+	}
+
+	due, err := time.Parse(HTMLDateFormat, f.Due)
+	f.Check(err != nil || rule.Until.IsZero() || !rule.Until.Before(due), "repeat", "Repeat until cannot be before the due date")
+}
+
+// Achieved reports whether the form marks the goal as achieved.
+func (f *Form) Achieved() bool {
+	return Status(f.Status) == Achieved
+}
+
+// Recurrence returns the RRULE described by the repeat fields, or an empty
+// string if the goal does not repeat.
+func (f *Form) Recurrence() string {
+	rule, err := f.rule()
+	if f.Repeat == "" || err != nil {
+		return ""
+	}
+	return rule.String()
+}
+
+// SetRecurrence fills the repeat fields from a stored RRULE.
+func (f *Form) SetRecurrence(rrule string) {
+	rule, err := recurrence.Parse(rrule)
+	if err != nil {
+		return
+	}
+
+	f.Repeat = string(rule.Freq)
+	f.RepeatInterval = rule.Interval
+	f.RepeatCount = rule.Count
+	if !rule.Until.IsZero() {
+		f.RepeatUntil = rule.Until.Format(HTMLDateFormat)
+	}
+}
+
+// startDate returns the start date of the form, or NULL if the goal is a
+// single-day milestone.
+func (f *Form) startDate() (sql.NullInt64, error) {
+	if f.Start == "" {
+		return sql.NullInt64{}, nil
+	}
+
+	start, err := time.Parse(HTMLDateFormat, f.Start)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: start.Unix(), Valid: true}, nil
+}
+
+// achievedAt returns when the goal was achieved. A date set in the form wins;
+// otherwise the goal keeps its previous date or, if it just became achieved,
+// is stamped with the current time. Goals that are not achieved have none.
+func (f *Form) achievedAt(previous Goal) (sql.NullInt64, error) {
+	status := Status(f.Status)
+	if status == "" {
+		status = Status(previous.Status)
+	}
+
+	if status != Achieved {
+		return sql.NullInt64{}, nil
+	}
+
+	if f.AchievedAt != "" {
+		achievedAt, err := time.Parse(HTMLDateFormat, f.AchievedAt)
+		if err != nil {
+			return sql.NullInt64{}, err
+		}
+
+		// Keep the exact time when the day did not change.
+		if previous.AchievedAt.Valid && time.Unix(previous.AchievedAt.Int64, 0).UTC().Format(HTMLDateFormat) == f.AchievedAt {
+			return previous.AchievedAt, nil
+		}
+		return sql.NullInt64{Int64: achievedAt.Unix(), Valid: true}, nil
+	}
+
+	if previous.AchievedAt.Valid {
+		return previous.AchievedAt, nil
+	}
+
+	return sql.NullInt64{Int64: time.Now().Unix(), Valid: true}, nil
+}
+
+func (f *Form) rule() (recurrence.Rule, error) {
+	rule := recurrence.Rule{
+		Freq:     recurrence.Frequency(f.Repeat),
+		Interval: max(f.RepeatInterval, 1),
+		Count:    f.RepeatCount,
+	}
+
+	if f.RepeatUntil != "" {
+		until, err := time.Parse(HTMLDateFormat, f.RepeatUntil)
+		if err != nil {
+			return recurrence.Rule{}, err
+		}
+		rule.Until = until
+	}
+
+	return rule, rule.Validate()
 }
 
 type Service struct {
+	db      *sql.DB
+	tx      *sql.Tx
 	queries *Queries
 }
 
 func NewService(db *sql.DB) *Service {
 	return &Service{
+		db:      db,
 		queries: New(db),
 	}
 }
 
+// WithTx returns a Service that runs all queries inside tx. The caller is
+// responsible for committing or rolling back the transaction.
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	return &Service{
+		db:      s.db,
+		tx:      tx,
+		queries: s.queries.WithTx(tx),
+	}
+}
+
+// inTx runs fn in the transaction of the Service. Without one, fn runs in a
+// new transaction that is committed when fn succeeds.
+func (s *Service) inTx(ctx context.Context, fn func(q *Queries) error) error {
+	return database.InTx(ctx, s.db, s.tx, func(tx *sql.Tx) error {
+		return fn(s.queries.WithTx(tx))
+	})
+}
+
 func (s *Service) Add(ctx context.Context, userID int, form *Form) (int, error) {
 	dueTime, err := time.Parse(HTMLDateFormat, form.Due)
 	if err != nil {
 		return 0, err
 	}
 
+	startDate, err := form.startDate()
+	if err != nil {
+		return 0, err
+	}
+
 	visibleToPublic := int64(0)
 	if form.VisibleToPublic {
 		visibleToPublic = 1
@@ -65,10 +241,12 @@ func (s *Service) Add(ctx context.Context, userID int, form *Form) (int, error)
 			Int64: visibleToPublic,
 			Valid: true,
 		},
-		Achieved: sql.NullInt64{
-			Int64: 0,
-			Valid: true,
+		Status: string(NotStarted),
+		Recurrence: sql.NullString{
+			String: form.Recurrence(),
+			Valid:  form.Recurrence() != "",
 		},
+		StartDate: startDate,
 	})
 	if err != nil {
 		return 0, err
@@ -97,57 +275,197 @@ func (s *Service) Get(ctx context.Context, goalID, userID int) (Goal, error) {
 	return goal, nil
 }
 
+// Update saves the form to the goal. The previous version and status
+// changes are recorded in the goal's history. When form.ShiftDependents is
+// set and the due date moved, all goals depending on it are moved by the
+// same amount.
 func (s *Service) Update(ctx context.Context, goalID, userID int, form *Form) (int, error) {
 	dueTime, err := time.Parse(HTMLDateFormat, form.Due)
 	if err != nil {
 		return 0, err
 	}
 
+	startDate, err := form.startDate()
+	if err != nil {
+		return 0, err
+	}
+
 	visibleToPublic := int64(0)
 	if form.VisibleToPublic {
 		visibleToPublic = 1
 	}
-	achieved := int64(0)
-	if form.Achieved {
-		achieved = 1
-	}
 
-	result, err := s.queries.Update(ctx, UpdateParams{
-		Goal: sql.NullString{
-			String: form.Goal,
-			Valid:  true,
-		},
-		Description: sql.NullString{
-			String: form.Description,
-			Valid:  true,
-		},
-		Due: sql.NullInt64{
-			Int64: dueTime.Unix(),
-			Valid: true,
-		},
-		VisibleToPublic: sql.NullInt64{
-			Int64: visibleToPublic,
-			Valid: true,
-		},
-		Achieved: sql.NullInt64{
-			Int64: achieved,
-			Valid: true,
-		},
-		ID:     int64(goalID),
-		UserID: int64(userID),
+	goal := sql.NullString{String: form.Goal, Valid: true}
+	description := sql.NullString{String: form.Description, Valid: true}
+	due := sql.NullInt64{Int64: dueTime.Unix(), Valid: true}
+
+	var rowsAffected int64
+	err = s.inTx(ctx, func(q *Queries) error {
+		previous, err := q.Get(ctx, GetParams{
+			ID:     int64(goalID),
+			UserID: int64(userID),
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		// Keep the current status when the form doesn't set one.
+		status := Status(form.Status)
+		if status == "" {
+			status = Status(previous.Status)
+		}
+
+		achievedAt, err := form.achievedAt(previous)
+		if err != nil {
+			return err
+		}
+
+		if err := saveRevision(ctx, q, previous, goal, description, due); err != nil {
+			return err
+		}
+
+		result, err := q.Update(ctx, UpdateParams{
+			Goal:        goal,
+			Description: description,
+			Due:         due,
+			VisibleToPublic: sql.NullInt64{
+				Int64: visibleToPublic,
+				Valid: true,
+			},
+			Status: string(status),
+			Recurrence: sql.NullString{
+				String: form.Recurrence(),
+				Valid:  form.Recurrence() != "",
+			},
+			AchievedAt: achievedAt,
+			StartDate:  startDate,
+			ID:         int64(goalID),
+			UserID:     int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if Status(previous.Status) != status {
+			if err := q.CreateStatusChange(ctx, CreateStatusChangeParams{
+				GoalID:     int64(goalID),
+				UserID:     int64(userID),
+				FromStatus: sql.NullString{String: previous.Status, Valid: true},
+				ToStatus:   string(status),
+				Note:       sql.NullString{String: form.StatusNote, Valid: form.StatusNote != ""},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if delta := dueTime.Unix() - previous.Due.Int64; form.ShiftDependents && previous.Due.Valid && delta != 0 {
+			return shiftDependents(ctx, q, goalID, userID, delta)
+		}
+
+		return nil
 	})
 	if err != nil {
 		return 0, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+// CreateNextOccurrence creates the next goal of a repeating series once the
+// goal is achieved. It returns the ID of the new goal, or 0 if the goal does
+// not repeat, is not achieved, the series has ended or the next goal already
+// exists. Success criteria are not copied.
+func (s *Service) CreateNextOccurrence(ctx context.Context, goalID, userID int) (int, error) {
+	var nextID int
+	err := s.inTx(ctx, func(q *Queries) error {
+		goal, err := q.Get(ctx, GetParams{
+			ID:     int64(goalID),
+			UserID: int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if Status(goal.Status) != Achieved || !goal.Recurrence.Valid || goal.NextOccurrenceID.Valid || !goal.Due.Valid {
+			return nil
+		}
+
+		rule, err := recurrence.Parse(goal.Recurrence.String)
+		if err != nil {
+			return err
+		}
+
+		due, rule, ok := rule.Next(time.Unix(goal.Due.Int64, 0).UTC())
+		if !ok {
+			return nil
+		}
+
+		// Keep the length of the span for goals with a start date.
+		startDate := goal.StartDate
+		if startDate.Valid {
+			startDate.Int64 += due.Unix() - goal.Due.Int64
+		}
+
+		next, err := q.Create(ctx, CreateParams{
+			UserID:          goal.UserID,
+			Goal:            goal.Goal,
+			Description:     goal.Description,
+			Due:             sql.NullInt64{Int64: due.Unix(), Valid: true},
+			VisibleToPublic: goal.VisibleToPublic,
+			Status:          string(NotStarted),
+			Recurrence:      sql.NullString{String: rule.String(), Valid: true},
+			StartDate:       startDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.SetNextOccurrence(ctx, SetNextOccurrenceParams{
+			NextOccurrenceID: sql.NullInt64{Int64: next.ID, Valid: true},
+			ID:               goal.ID,
+			UserID:           goal.UserID,
+		}); err != nil {
+			return err
+		}
+
+		nextID = int(next.ID)
+		return nil
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	return int(rowsAffected), nil
+	return nextID, nil
+}
+
+// GetStatusHistory returns the status changes of a goal, newest first.
+func (s *Service) GetStatusHistory(ctx context.Context, goalID, userID int) ([]StatusChange, error) {
+	history, err := s.queries.GetStatusHistory(ctx, GetStatusHistoryParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]StatusChange, 0, len(history))
+	for _, h := range history {
+		changes = append(changes, h.ToStatusChange())
+	}
+
+	return changes, nil
 }
 
+// Delete permanently deletes a goal from the trash together with its
+// success criteria. Goals that are not in the trash are left untouched.
 func (s *Service) Delete(ctx context.Context, goalID, userID int) (int, error) {
 	result, err := s.queries.Delete(ctx, DeleteParams{
 		ID:     int64(goalID),
@@ -165,11 +483,27 @@ func (s *Service) Delete(ctx context.Context, goalID, userID int) (int, error) {
 	return int(rowsAffected), nil
 }
 
-func (s *Service) GetAllShared(ctx context.Context, userID int) ([]Goal, error) {
-	goals, err := s.queries.GetAllShared(ctx, int64(userID))
+// SetJournalPublic sets whether the journal of a goal is shown on the share
+// page.
+func (s *Service) SetJournalPublic(ctx context.Context, goalID, userID int, public bool) (int, error) {
+	journalPublic := int64(0)
+	if public {
+		journalPublic = 1
+	}
+
+	result, err := s.queries.SetJournalPublic(ctx, SetJournalPublicParams{
+		JournalPublic: journalPublic,
+		ID:            int64(goalID),
+		UserID:        int64(userID),
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return goals, nil
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
 }
@@ -0,0 +1,70 @@
+package goals
+
+import "time"
+
+// Status is the stage a goal is in. It is stored as text in goals.status.
+type Status string
+
+const (
+	NotStarted Status = "not_started"
+	InProgress Status = "in_progress"
+	AtRisk     Status = "at_risk"
+	OnHold     Status = "on_hold"
+	Achieved   Status = "achieved"
+	Abandoned  Status = "abandoned"
+)
+
+// Statuses returns all statuses in the order they are offered to the user.
+func Statuses() []Status {
+	return []Status{NotStarted, InProgress, AtRisk, OnHold, Achieved, Abandoned}
+}
+
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	for _, status := range Statuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the status as shown in the UI.
+func (s Status) Label() string {
+	switch s {
+	case InProgress:
+		return "In progress"
+	case AtRisk:
+		return "At risk"
+	case OnHold:
+		return "On hold"
+	case Achieved:
+		return "Achieved"
+	case Abandoned:
+		return "Abandoned"
+	default:
+		return "Not started"
+	}
+}
+
+// Closed reports whether no more work is expected on the goal.
+func (s Status) Closed() bool {
+	return s == Achieved || s == Abandoned
+}
+
+// StatusChange is a recorded transition of a goal's status.
+type StatusChange struct {
+	From      Status
+	To        Status
+	Note      string
+	CreatedAt time.Time
+}
+
+func (h *GoalStatusHistory) ToStatusChange() StatusChange {
+	return StatusChange{
+		From:      Status(h.FromStatus.String),
+		To:        Status(h.ToStatus),
+		Note:      h.Note.String,
+		CreatedAt: time.Unix(h.CreatedAt, 0),
+	}
+}
@@ -0,0 +1,73 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Trash moves a goal to the trash. Trashed goals are hidden everywhere except
+// on the trash page until they are restored or purged.
+func (s *Service) Trash(ctx context.Context, goalID, userID int) (int, error) {
+	result, err := s.queries.Trash(ctx, TrashParams{
+		ID:     int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Restore moves a goal out of the trash.
+func (s *Service) Restore(ctx context.Context, goalID, userID int) (int, error) {
+	result, err := s.queries.Restore(ctx, RestoreParams{
+		ID:     int64(goalID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetAllTrashed returns the goals in the trash, most recently deleted first.
+func (s *Service) GetAllTrashed(ctx context.Context, userID int) ([]Goal, error) {
+	goals, err := s.queries.GetAllTrashed(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+// PurgeTrash permanently deletes all goals of all users that were moved to
+// the trash before the given time.
+func (s *Service) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.queries.PurgeTrash(ctx, sql.NullInt64{
+		Int64: before.Unix(),
+		Valid: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
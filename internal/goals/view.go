@@ -1,18 +1,45 @@
 package goals
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/recurrence"
+)
 
 type View struct {
-	ID                     int64
-	UserID                 int64
-	Goal                   string
-	Description            string
-	Year                   string
-	Due                    time.Time
-	VisibleToPublic        bool
-	Achieved               bool
+	ID          int64
+	UserID      int64
+	Goal        string
+	Description string
+	Year        string
+	// Start is set for goals that span a date range up to Due.
+	Start           time.Time
+	Due             time.Time
+	VisibleToPublic bool
+	Status          Status
+	Achieved        bool
+	// AchievedAt is set for achieved goals.
+	AchievedAt             time.Time
 	CompletedCriteriaCount int
 	TotalCriteriaCount     int
+	KeyResultCount         int
+	// KeyResultProgress is the average progress of the key results in percent.
+	KeyResultProgress int
+	// ScheduleConflicts holds the titles of prerequisites due after this goal.
+	ScheduleConflicts []string
+	// Recurrence describes how the goal repeats, e.g. "Every 3 months".
+	Recurrence string
+	// DeletedAt is set for goals in the trash.
+	DeletedAt time.Time
+	// ArchivedAt is set for archived goals.
+	ArchivedAt time.Time
+	Archived   bool
+	// JournalPublic shows the journal of the goal on the share page.
+	JournalPublic bool
+	// Upcoming marks a future occurrence of a repeating goal that does not
+	// exist yet. ID refers to the goal it repeats.
+	Upcoming bool
 }
 
 func (g *Goal) ToView() View {
@@ -22,7 +49,9 @@ func (g *Goal) ToView() View {
 		Goal:            g.Goal.String,
 		Description:     g.Description.String,
 		VisibleToPublic: g.VisibleToPublic.Int64 == 1,
-		Achieved:        g.Achieved.Int64 == 1,
+		Status:          Status(g.Status),
+		Achieved:        Status(g.Status) == Achieved,
+		JournalPublic:   g.JournalPublic == 1,
 	}
 
 	if g.Due.Valid {
@@ -31,5 +60,144 @@ func (g *Goal) ToView() View {
 		view.Due = dueTime
 	}
 
+	if g.StartDate.Valid && g.Due.Valid && g.StartDate.Int64 <= g.Due.Int64 {
+		view.Start = time.Unix(g.StartDate.Int64, 0)
+	}
+
+	if g.AchievedAt.Valid && view.Achieved {
+		view.AchievedAt = time.Unix(g.AchievedAt.Int64, 0)
+	}
+
+	if g.DeletedAt.Valid {
+		view.DeletedAt = time.Unix(g.DeletedAt.Int64, 0)
+	}
+
+	if g.ArchivedAt.Valid {
+		view.ArchivedAt = time.Unix(g.ArchivedAt.Int64, 0)
+		view.Archived = true
+	}
+
+	if rule, err := recurrence.Parse(g.Recurrence.String); err == nil {
+		view.Recurrence = rule.Describe()
+	}
+
 	return view
 }
+
+// UpcomingViews returns up to n future occurrences of a repeating goal that
+// is the latest of its series.
+func (g *Goal) UpcomingViews(n int) []View {
+	if !g.Recurrence.Valid || g.NextOccurrenceID.Valid || !g.Due.Valid {
+		return nil
+	}
+
+	rule, err := recurrence.Parse(g.Recurrence.String)
+	if err != nil {
+		return nil
+	}
+
+	current := g.ToView()
+	views := []View{}
+	for _, due := range rule.Upcoming(time.Unix(g.Due.Int64, 0).UTC(), n) {
+		view := current
+		view.Due = time.Unix(due.Unix(), 0)
+		if !current.Start.IsZero() {
+			view.Start = view.Due.Add(current.Start.Sub(current.Due))
+		}
+		view.Year = view.Due.Format("2006")
+		view.Status = NotStarted
+		view.Achieved = false
+		view.CompletedCriteriaCount = 0
+		view.TotalCriteriaCount = 0
+		view.KeyResultCount = 0
+		view.KeyResultProgress = 0
+		view.ScheduleConflicts = nil
+		view.Upcoming = true
+		views = append(views, view)
+	}
+
+	return views
+}
+
+// HasProgress reports whether the goal has success criteria or key results
+// to show progress for.
+func (v View) HasProgress() bool {
+	return v.TotalCriteriaCount > 0 || v.KeyResultCount > 0
+}
+
+// ProgressComplete reports whether all success criteria are achieved and all
+// key results reached their target.
+func (v View) ProgressComplete() bool {
+	return v.CompletedCriteriaCount == v.TotalCriteriaCount &&
+		(v.KeyResultCount == 0 || v.KeyResultProgress == 100)
+}
+
+// HasSpan reports whether the goal runs over a date range rather than being a
+// single-day milestone.
+func (v View) HasSpan() bool {
+	return !v.Start.IsZero() && v.Start.Before(v.Due)
+}
+
+// Span describes the date range of the goal, e.g. "Nov 15, 2026 – Feb 1,
+// 2027". The year of the start is left out when both dates are in the same
+// year.
+func (v View) Span() string {
+	if !v.HasSpan() {
+		return ""
+	}
+
+	start, due := v.Start.UTC(), v.Due.UTC()
+	if start.Year() == due.Year() {
+		return start.Format("Jan 2") + " – " + due.Format("Jan 2, 2006")
+	}
+	return start.Format("Jan 2, 2006") + " – " + due.Format("Jan 2, 2006")
+}
+
+// Elapsed returns how far now is through the span of the goal in percent.
+func (v View) Elapsed(now time.Time) int {
+	if !v.HasSpan() {
+		return 0
+	}
+
+	total := v.Due.Sub(v.Start)
+	elapsed := now.Sub(v.Start)
+	return int(max(0, min(100, elapsed*100/total)))
+}
+
+// DaysLate returns how many days after the due date the goal was achieved.
+// It is negative for goals achieved early and 0 for goals that are on time
+// or not achieved.
+func (v View) DaysLate() int {
+	if v.AchievedAt.IsZero() || v.Due.IsZero() {
+		return 0
+	}
+
+	due := v.Due.UTC()
+	achieved := v.AchievedAt.UTC()
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	achievedDay := time.Date(achieved.Year(), achieved.Month(), achieved.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(achievedDay.Sub(dueDay).Hours() / 24)
+}
+
+// Delivery describes when the goal was achieved compared with its due date,
+// e.g. "Achieved 12 days early". It is empty for goals that are not achieved.
+func (v View) Delivery() string {
+	if v.AchievedAt.IsZero() {
+		return ""
+	}
+
+	days := v.DaysLate()
+	switch {
+	case days == 0:
+		return "Achieved on time"
+	case days == -1:
+		return "Achieved 1 day early"
+	case days < 0:
+		return fmt.Sprintf("Achieved %d days early", -days)
+	case days == 1:
+		return "Achieved 1 day late"
+	default:
+		return fmt.Sprintf("Achieved %d days late", days)
+	}
+}
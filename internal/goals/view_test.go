@@ -0,0 +1,55 @@
+package goals
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelivery(t *testing.T) {
+	due := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		achievedAt time.Time
+		want       string
+	}{
+		{name: "not achieved", want: ""},
+		{name: "on time", achievedAt: due.Add(18 * time.Hour), want: "Achieved on time"},
+		{name: "one day early", achievedAt: due.Add(-time.Hour), want: "Achieved 1 day early"},
+		{name: "days early", achievedAt: due.AddDate(0, 0, -12), want: "Achieved 12 days early"},
+		{name: "one day late", achievedAt: due.AddDate(0, 0, 1), want: "Achieved 1 day late"},
+		{name: "days late", achievedAt: due.AddDate(0, 0, 3).Add(23 * time.Hour), want: "Achieved 3 days late"},
+		{name: "across years", achievedAt: due.AddDate(1, 0, 0), want: "Achieved 365 days late"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := View{Due: due, AchievedAt: tt.achievedAt}
+			if got := view.Delivery(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		due   time.Time
+		want  string
+	}{
+		{name: "milestone", due: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), want: ""},
+		{name: "same year", start: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), due: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), want: "Mar 1 – Apr 30, 2026"},
+		{name: "across years", start: time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC), due: time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC), want: "Nov 15, 2026 – Feb 1, 2027"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := View{Start: tt.start, Due: tt.due}
+			if got := view.Span(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
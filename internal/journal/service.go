@@ -0,0 +1,127 @@
+// Package journal keeps timestamped progress notes on goals: what happened,
+// blockers and wins.
+package journal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// Mood is how the user felt about a goal when writing an entry.
+type Mood string
+
+const (
+	Great      Mood = "great"
+	Good       Mood = "good"
+	Okay       Mood = "okay"
+	Struggling Mood = "struggling"
+)
+
+// Moods returns all moods in the order they are offered to the user.
+func Moods() []Mood {
+	return []Mood{Great, Good, Okay, Struggling}
+}
+
+// Label returns the human readable name of the mood.
+func (m Mood) Label() string {
+	switch m {
+	case Great:
+		return "Great"
+	case Good:
+		return "Good"
+	case Okay:
+		return "Okay"
+	case Struggling:
+		return "Struggling"
+	}
+	return ""
+}
+
+type Form struct {
+	Body string `form:"body"`
+	// Mood and Confidence are optional. Confidence is 1–5, 0 means unset.
+	Mood                string `form:"mood"`
+	Confidence          int    `form:"confidence"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(validator.NotBlank(f.Body), "body", "Entry cannot be blank")
+	f.Check(validator.MaxChars(f.Body, 5000), "body", "Entry cannot be more than 5000 characters")
+	f.Check(f.Mood == "" || Mood(f.Mood).Label() != "", "mood", "Choose a valid mood")
+	f.Check(f.Confidence >= 0 && f.Confidence <= 5, "confidence", "Confidence must be between 1 and 5")
+}
+
+func (f *Form) mood() sql.NullString {
+	return sql.NullString{String: f.Mood, Valid: f.Mood != ""}
+}
+
+func (f *Form) confidence() sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(f.Confidence), Valid: f.Confidence > 0}
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+func (s *Service) Add(ctx context.Context, goalID, userID int, form *Form) (JournalEntry, error) {
+	return s.queries.CreateEntry(ctx, CreateEntryParams{
+		GoalID:     int64(goalID),
+		UserID:     int64(userID),
+		Body:       form.Body,
+		Mood:       form.mood(),
+		Confidence: form.confidence(),
+	})
+}
+
+func (s *Service) Get(ctx context.Context, entryID, userID int) (JournalEntry, error) {
+	return s.queries.GetEntry(ctx, GetEntryParams{
+		ID:     int64(entryID),
+		UserID: int64(userID),
+	})
+}
+
+// GetAllByGoal returns the journal of a goal, newest entry first.
+func (s *Service) GetAllByGoal(ctx context.Context, goalID, userID int) ([]JournalEntry, error) {
+	return s.queries.GetAllEntriesByGoal(ctx, GetAllEntriesByGoalParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+}
+
+// Update changes an entry and returns it. sql.ErrNoRows is returned if the
+// entry does not exist.
+func (s *Service) Update(ctx context.Context, entryID, userID int, form *Form) (JournalEntry, error) {
+	return s.queries.UpdateEntry(ctx, UpdateEntryParams{
+		Body:       form.Body,
+		Mood:       form.mood(),
+		Confidence: form.confidence(),
+		ID:         int64(entryID),
+		UserID:     int64(userID),
+	})
+}
+
+func (s *Service) Delete(ctx context.Context, entryID, userID int) (int, error) {
+	result, err := s.queries.DeleteEntry(ctx, DeleteEntryParams{
+		ID:     int64(entryID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
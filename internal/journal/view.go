@@ -0,0 +1,31 @@
+package journal
+
+import "time"
+
+type View struct {
+	ID         int
+	GoalID     int
+	Body       string
+	Mood       Mood
+	Confidence int
+	CreatedAt  time.Time
+	// UpdatedAt is set once the entry has been edited.
+	UpdatedAt time.Time
+}
+
+func (e JournalEntry) ToView() View {
+	view := View{
+		ID:         int(e.ID),
+		GoalID:     int(e.GoalID),
+		Body:       e.Body,
+		Mood:       Mood(e.Mood.String),
+		Confidence: int(e.Confidence.Int64),
+		CreatedAt:  time.Unix(e.CreatedAt, 0),
+	}
+
+	if e.UpdatedAt.Valid {
+		view.UpdatedAt = time.Unix(e.UpdatedAt.Int64, 0)
+	}
+
+	return view
+}
@@ -0,0 +1,197 @@
+// Package key_results tracks measurable results of a goal, like "run 1000
+// km", and the check-ins that move them towards their target.
+package key_results
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bit8bytes/goalkeepr/internal/database"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+type Form struct {
+	Description         string  `form:"description"`
+	Unit                string  `form:"unit"`
+	StartValue          float64 `form:"start_value"`
+	TargetValue         float64 `form:"target_value"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(validator.NotBlank(f.Description), "description", "Description cannot be blank")
+	f.Check(validator.MaxChars(f.Description, 500), "description", "Description cannot be more than 500 characters")
+	f.Check(validator.MaxChars(f.Unit, 20), "unit", "Unit cannot be more than 20 characters")
+	f.Check(f.StartValue != f.TargetValue, "target_value", "Target must differ from the start value")
+}
+
+type CheckInForm struct {
+	KeyResultID         int     `form:"-"`
+	Value               float64 `form:"value"`
+	Note                string  `form:"note"`
+	validator.Validator `form:"-"`
+}
+
+func (f *CheckInForm) Validate() {
+	f.Check(validator.MaxChars(f.Note, 500), "note", "Note cannot be more than 500 characters")
+}
+
+type Service struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		db:      db,
+		queries: New(db),
+	}
+}
+
+// WithTx returns a Service that runs all queries inside tx. The caller is
+// responsible for committing or rolling back the transaction.
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	return &Service{
+		db:      s.db,
+		tx:      tx,
+		queries: s.queries.WithTx(tx),
+	}
+}
+
+// inTx runs fn in the transaction of the Service. Without one, fn runs in a
+// new transaction that is committed when fn succeeds.
+func (s *Service) inTx(ctx context.Context, fn func(q *Queries) error) error {
+	return database.InTx(ctx, s.db, s.tx, func(tx *sql.Tx) error {
+		return fn(s.queries.WithTx(tx))
+	})
+}
+
+// Add creates a key result for a goal. It starts out at its start value.
+func (s *Service) Add(ctx context.Context, goalID, userID int, form *Form) error {
+	_, err := s.queries.CreateKeyResult(ctx, CreateKeyResultParams{
+		GoalID:       int64(goalID),
+		UserID:       int64(userID),
+		Description:  form.Description,
+		Unit:         form.Unit,
+		StartValue:   form.StartValue,
+		TargetValue:  form.TargetValue,
+		CurrentValue: form.StartValue,
+	})
+	return err
+}
+
+// CopyToGoal copies all key results of one goal to another goal of the same
+// user. The copies start over at their start value.
+func (s *Service) CopyToGoal(ctx context.Context, fromGoalID, toGoalID, userID int) error {
+	results, err := s.GetAllByGoal(ctx, fromGoalID, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, kr := range results {
+		if err := s.Add(ctx, toGoalID, userID, &Form{
+			Description: kr.Description,
+			Unit:        kr.Unit,
+			StartValue:  kr.StartValue,
+			TargetValue: kr.TargetValue,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) GetAllByGoal(ctx context.Context, goalID, userID int) ([]KeyResult, error) {
+	return s.queries.GetAllKeyResultsByGoal(ctx, GetAllKeyResultsByGoalParams{
+		GoalID: int64(goalID),
+		UserID: int64(userID),
+	})
+}
+
+// GetAllByUser returns the key results of all goals of a user, grouped by
+// goal ID.
+func (s *Service) GetAllByUser(ctx context.Context, userID int) (map[int64][]KeyResult, error) {
+	results, err := s.queries.GetAllKeyResultsByUser(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64][]KeyResult)
+	for _, kr := range results {
+		byGoal[kr.GoalID] = append(byGoal[kr.GoalID], kr)
+	}
+
+	return byGoal, nil
+}
+
+// CheckIn records a new current value for a key result. It returns 0 if the
+// key result does not exist.
+func (s *Service) CheckIn(ctx context.Context, keyResultID, userID int, form *CheckInForm) (int, error) {
+	var rowsAffected int64
+	err := s.inTx(ctx, func(q *Queries) error {
+		if _, err := q.GetKeyResult(ctx, GetKeyResultParams{
+			ID:     int64(keyResultID),
+			UserID: int64(userID),
+		}); err != nil {
+			return err
+		}
+
+		if err := q.CreateCheckIn(ctx, CreateCheckInParams{
+			KeyResultID: int64(keyResultID),
+			UserID:      int64(userID),
+			Value:       form.Value,
+			Note:        sql.NullString{String: form.Note, Valid: form.Note != ""},
+		}); err != nil {
+			return err
+		}
+
+		result, err := q.UpdateKeyResultCurrentValue(ctx, UpdateKeyResultCurrentValueParams{
+			CurrentValue: form.Value,
+			ID:           int64(keyResultID),
+			UserID:       int64(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetCheckIns returns the check-ins of a key result, newest first.
+func (s *Service) GetCheckIns(ctx context.Context, keyResultID, userID int) ([]KeyResultCheckIn, error) {
+	return s.queries.GetCheckIns(ctx, GetCheckInsParams{
+		KeyResultID: int64(keyResultID),
+		UserID:      int64(userID),
+	})
+}
+
+// Delete removes a key result together with its check-ins.
+func (s *Service) Delete(ctx context.Context, keyResultID, userID int) (int, error) {
+	result, err := s.queries.DeleteKeyResult(ctx, DeleteKeyResultParams{
+		ID:     int64(keyResultID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,77 @@
+package key_results
+
+import "time"
+
+type View struct {
+	ID           int
+	GoalID       int
+	Description  string
+	Unit         string
+	StartValue   float64
+	TargetValue  float64
+	CurrentValue float64
+	// Progress is how far the current value got from start to target, in
+	// percent between 0 and 100.
+	Progress int
+}
+
+func (k KeyResult) ToView() View {
+	return View{
+		ID:           int(k.ID),
+		GoalID:       int(k.GoalID),
+		Description:  k.Description,
+		Unit:         k.Unit,
+		StartValue:   k.StartValue,
+		TargetValue:  k.TargetValue,
+		CurrentValue: k.CurrentValue,
+		Progress:     Progress(k.StartValue, k.TargetValue, k.CurrentValue),
+	}
+}
+
+type CheckInView struct {
+	Value     float64
+	Note      string
+	CreatedAt time.Time
+}
+
+func (c KeyResultCheckIn) ToView() CheckInView {
+	return CheckInView{
+		Value:     c.Value,
+		Note:      c.Note.String,
+		CreatedAt: time.Unix(c.CreatedAt, 0),
+	}
+}
+
+// Progress returns how far current got from start to target in percent,
+// clamped to 0–100. Targets below the start value, like "reduce costs to
+// 500", work the same way.
+func Progress(start, target, current float64) int {
+	if start == target {
+		return 100
+	}
+
+	percent := (current - start) / (target - start) * 100
+	switch {
+	case percent < 0:
+		return 0
+	case percent > 100:
+		return 100
+	}
+
+	return int(percent)
+}
+
+// AverageProgress returns the mean progress of results in percent. It is 0
+// when there are no results.
+func AverageProgress(results []KeyResult) int {
+	if len(results) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, kr := range results {
+		total += Progress(kr.StartValue, kr.TargetValue, kr.CurrentValue)
+	}
+
+	return total / len(results)
+}
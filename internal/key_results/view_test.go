@@ -0,0 +1,46 @@
+package key_results
+
+import "testing"
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   float64
+		target  float64
+		current float64
+		want    int
+	}{
+		{name: "not started", start: 0, target: 1000, current: 0, want: 0},
+		{name: "halfway", start: 0, target: 1000, current: 500, want: 50},
+		{name: "offset start", start: 100, target: 500, current: 200, want: 25},
+		{name: "reached", start: 0, target: 1000, current: 1000, want: 100},
+		{name: "beyond target", start: 0, target: 1000, current: 1200, want: 100},
+		{name: "below start", start: 100, target: 500, current: 50, want: 0},
+		{name: "decreasing target", start: 90, target: 80, current: 85, want: 50},
+		{name: "start equals target", start: 10, target: 10, current: 0, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Progress(tt.start, tt.target, tt.current)
+			if got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAverageProgress(t *testing.T) {
+	results := []KeyResult{
+		{StartValue: 0, TargetValue: 100, CurrentValue: 100},
+		{StartValue: 0, TargetValue: 100, CurrentValue: 50},
+	}
+
+	if got := AverageProgress(results); got != 75 {
+		t.Errorf("expected 75, got %d", got)
+	}
+
+	if got := AverageProgress(nil); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+}
@@ -0,0 +1,223 @@
+// Package mailer sends emails, like due date reminders, either through an
+// SMTP server or, when none is configured, to the log.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is an email with a plain text and an HTML body.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Unsubscribe is a URL that unsubscribes the recipient with a POST
+	// request, for mail clients that offer one-click unsubscribe.
+	Unsubscribe string
+}
+
+// Sender sends messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTP sends messages through an SMTP server, using STARTTLS when the server
+// supports it.
+type SMTP struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	return &SMTP{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return err
+	}
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	body, err := msg.Bytes(s.from, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return err
+	}
+
+	// Don't let a stuck server block shutdown.
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(time.Minute))
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(nil); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// Log writes messages to a logger instead of sending them, for development
+// and for setups without an SMTP server.
+type Log struct {
+	logger *slog.Logger
+}
+
+func NewLog(logger *slog.Logger) *Log {
+	return &Log{logger: logger}
+}
+
+func (l *Log) Send(ctx context.Context, msg Message) error {
+	l.logger.InfoContext(ctx, "email", "to", msg.To, "subject", msg.Subject, "text", msg.Text)
+	return nil
+}
+
+// Bytes returns the message as a multipart/alternative email from from,
+// dated now.
+func (m Message) Bytes(from string, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", headerValue(from))
+	header.Set("To", headerValue(m.To))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", headerValue(m.Subject)))
+	header.Set("Date", now.Format(time.RFC1123Z))
+	header.Set("Message-ID", "<"+rand.Text()+"@"+messageIDHost(from)+">")
+
+	keys := []string{"From", "To", "Subject", "Date", "Message-ID"}
+	if m.Unsubscribe != "" {
+		// See RFC 8058.
+		header.Set("List-Unsubscribe", "<"+headerValue(m.Unsubscribe)+">")
+		header.Set("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+		keys = append(keys, "List-Unsubscribe", "List-Unsubscribe-Post")
+	}
+
+	header.Set("MIME-Version", "1.0")
+
+	w := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/alternative; boundary="+w.Boundary())
+
+	for _, key := range append(keys, "MIME-Version", "Content-Type") {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, header.Get(key))
+	}
+	buf.WriteString("\r\n")
+
+	// The last part is the preferred one.
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// headerValue removes line breaks, so values can't add headers.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// messageIDHost returns the domain of the from address, or localhost.
+func messageIDHost(from string) string {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return "localhost"
+	}
+
+	_, host, ok := strings.Cut(addr.Address, "@")
+	if !ok {
+		return "localhost"
+	}
+	return host
+}
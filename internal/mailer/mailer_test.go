@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageBytes(t *testing.T) {
+	msg := Message{
+		To:      "ada@example.com",
+		Subject: "Reminder: Café opening\r\nBcc: eve@example.com",
+		Text:    "Due tomorrow\n",
+		HTML:    "<p>Due tomorrow</p>",
+	}
+
+	b, err := msg.Bytes("Goalkeepr <no-reply@goalkeepr.com>", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := m.Header.Get("Bcc"); got != "" {
+		t.Errorf("expected no Bcc header, got %q", got)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Reminder: Café openingBcc: eve@example.com"; subject != want {
+		t.Errorf("expected subject %q, got %q", want, subject)
+	}
+
+	if got := m.Header.Get("Date"); got != "Mon, 19 Oct 2026 08:00:00 +0000" {
+		t.Errorf("expected date of now, got %q", got)
+	}
+
+	if got := m.Header.Get("Message-Id"); !strings.HasSuffix(got, "@goalkeepr.com>") {
+		t.Errorf("expected message ID of the sender domain, got %q", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %q", mediaType)
+	}
+
+	r := multipart.NewReader(m.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		part, err := r.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := part.Header.Get("Content-Type"); got != want.contentType {
+			t.Errorf("expected content type %q, got %q", want.contentType, got)
+		}
+
+		// NextPart decodes quoted-printable, line breaks stay CRLF.
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != want.body {
+			t.Errorf("expected body %q, got %q", want.body, got)
+		}
+	}
+}
+
+func TestMessageBytesUnsubscribe(t *testing.T) {
+	for _, tt := range []struct {
+		unsubscribe string
+		want        string
+	}{
+		{unsubscribe: "", want: ""},
+		{unsubscribe: "https://goalkeepr.com/digest/unsubscribe/abc", want: "<https://goalkeepr.com/digest/unsubscribe/abc>"},
+	} {
+		msg := Message{To: "ada@example.com", Subject: "Your week", Text: "Hi", Unsubscribe: tt.unsubscribe}
+		b, err := msg.Bytes("no-reply@goalkeepr.com", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := mail.ReadMessage(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := m.Header.Get("List-Unsubscribe"); got != tt.want {
+			t.Errorf("expected List-Unsubscribe %q, got %q", tt.want, got)
+		}
+
+		wantPost := ""
+		if tt.want != "" {
+			wantPost = "List-Unsubscribe=One-Click"
+		}
+		if got := m.Header.Get("List-Unsubscribe-Post"); got != wantPost {
+			t.Errorf("expected List-Unsubscribe-Post %q, got %q", wantPost, got)
+		}
+	}
+}
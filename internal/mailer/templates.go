@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Templates renders emails from pairs of templates, name.txt for the plain
+// text body and name.html for the HTML body. The text template defines the
+// subject as "name/subject".
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// NewTemplates parses all email templates in fsys.
+func NewTemplates(fsys fs.FS) (*Templates, error) {
+	text, err := texttemplate.ParseFS(fsys, "*.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.ParseFS(fsys, "*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Templates{text: text, html: html}, nil
+}
+
+// Render renders the email name to to with data. Unsubscribe links have to be
+// set on the message by the caller.
+func (t *Templates) Render(to, name string, data any) (Message, error) {
+	var subject, text, html bytes.Buffer
+
+	if err := t.text.ExecuteTemplate(&subject, name+"/subject", data); err != nil {
+		return Message{}, err
+	}
+
+	if err := t.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return Message{}, err
+	}
+
+	if err := t.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package markdown
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"html/template"
+	"sync"
+)
+
+// DefaultCacheSize is the number of rendered descriptions a Cache keeps.
+const DefaultCacheSize = 2048
+
+// Cache renders Markdown and keeps the most recently used results. Entries
+// are keyed by the source, so every revision of a description is rendered
+// once and an edit never serves stale HTML.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[[sha256.Size]byte]*list.Element
+	// recent orders the entries from most to least recently used.
+	recent *list.List
+}
+
+type entry struct {
+	key  [sha256.Size]byte
+	html template.HTML
+}
+
+// NewCache returns a cache that keeps up to size rendered sources.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    max(size, 1),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// Render is like the package-level Render but reuses earlier results.
+func (c *Cache) Render(src string) template.HTML {
+	if src == "" {
+		return ""
+	}
+
+	key := keyOf(src)
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(e)
+		html := e.Value.(*entry).html
+		c.mu.Unlock()
+		return html
+	}
+	c.mu.Unlock()
+
+	html := Render(src)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(e)
+		return html
+	}
+
+	c.entries[key] = c.recent.PushFront(&entry{key: key, html: html})
+	if c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+
+	return html
+}
+
+func keyOf(src string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(src))
+}
+
+// Len returns the number of cached entries.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recent.Len()
+}
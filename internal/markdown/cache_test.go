@@ -0,0 +1,50 @@
+package markdown
+
+import "testing"
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+
+	if got := c.Render("*a*"); got != "<p><em>a</em></p>" {
+		t.Errorf("unexpected html %q", got)
+	}
+	c.Render("*a*")
+	if c.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", c.Len())
+	}
+
+	c.Render("b")
+	c.Render("*a*")
+	c.Render("c")
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+
+	// b was the least recently used entry.
+	if _, ok := c.entries[keyOf("b")]; ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := c.entries[keyOf("*a*")]; !ok {
+		t.Error("expected *a* to be kept")
+	}
+
+	if got := c.Render(""); got != "" {
+		t.Errorf("expected no html, got %q", got)
+	}
+}
+
+func BenchmarkCacheRender(b *testing.B) {
+	src := "Run a **half marathon** in under two hours.\n\n- Train 4 times a week\n- Follow [the plan](https://example.com/plan)\n"
+	c := NewCache(DefaultCacheSize)
+
+	b.Run("uncached", func(b *testing.B) {
+		for b.Loop() {
+			Render(src)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			c.Render(src)
+		}
+	})
+}
@@ -0,0 +1,513 @@
+package markdown
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is an inline node or a run of * or _ that may open or close
+// emphasis.
+type token struct {
+	node *node
+	// delim is the character of a delimiter run, count how many of them are
+	// left and length how many there were.
+	delim    byte
+	count    int
+	length   int
+	canOpen  bool
+	canClose bool
+	// prev and next link the tokens, and prevDelim and nextDelim the
+	// delimiters among them, while emphasis is processed.
+	prev, next           *token
+	prevDelim, nextDelim *token
+	// pos orders the delimiters.
+	pos int
+}
+
+// maxParenDepth is how deeply parentheses may nest in link destinations,
+// like in cmark. It keeps hostile input from being scanned to the end for
+// every link.
+const maxParenDepth = 32
+
+var (
+	autolinkRX = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*)>`)
+	emailRX    = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)*)>`)
+)
+
+func parseInlines(s string) []*node {
+	return parseInlinesIn(strings.TrimRight(s, " \t"), false)
+}
+
+// parseInlinesIn parses s. Links inside the text of a link are kept as
+// text.
+func parseInlinesIn(s string, inLink bool) []*node {
+	var tokens []*token
+	var text strings.Builder
+
+	// Code spans and brackets are matched up front, so no construct scans to
+	// the end of s more than once.
+	ticks := indexBackticks(s)
+	var brackets map[int]int
+	if !inLink {
+		brackets = matchBrackets(s, ticks)
+	}
+
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, &token{node: &node{kind: kindText, text: text.String()}})
+			text.Reset()
+		}
+	}
+	add := func(n *node) {
+		flush()
+		tokens = append(tokens, &token{node: n})
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			add(&node{kind: kindLineBreak})
+			i += 2
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+		case c == '`':
+			n := runLength(s, i, '`')
+			if code, end, ok := ticks.codeSpan(s, i, n); ok {
+				add(&node{kind: kindCode, text: code})
+				i = end
+				continue
+			}
+			text.WriteString(s[i : i+n])
+			i += n
+		case c == '*' || c == '_':
+			n := runLength(s, i, c)
+			flush()
+			t := &token{
+				node:   &node{kind: kindText, text: s[i : i+n]},
+				delim:  c,
+				count:  n,
+				length: n,
+				pos:    len(tokens),
+			}
+			t.canOpen, t.canClose = flanking(s, i, n)
+			tokens = append(tokens, t)
+			i += n
+		case c == '[' && !inLink:
+			if link, end, ok := parseLink(s, i, brackets); ok {
+				if link.href == "" {
+					// The URL isn't allowed, keep the text of the link.
+					flush()
+					for _, child := range link.children {
+						tokens = append(tokens, &token{node: child})
+					}
+				} else {
+					add(link)
+				}
+				i = end
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		case c == '<' && !inLink:
+			if link, end, ok := parseAutolink(s, i); ok {
+				add(link)
+				i = end
+				continue
+			}
+			text.WriteByte(c)
+			i++
+		case c == '\n':
+			line := strings.TrimRight(text.String(), " ")
+			hard := len(text.String())-len(line) >= 2
+			text.Reset()
+			text.WriteString(line)
+			if hard {
+				add(&node{kind: kindLineBreak})
+			} else {
+				add(&node{kind: kindSoftBreak})
+			}
+			i++
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+				i++
+			}
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+
+	return processEmphasis(tokens)
+}
+
+func isPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// backticks lists the start of every backtick run of a string by the length
+// of the run.
+type backticks map[int][]int
+
+func indexBackticks(s string) backticks {
+	ticks := backticks{}
+	for i := 0; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		n := runLength(s, i, '`')
+		ticks[n] = append(ticks[n], i)
+		i += n
+	}
+	return ticks
+}
+
+// codeSpan returns the content of the code span that starts with n
+// backticks at i.
+func (ticks backticks) codeSpan(s string, i, n int) (string, int, bool) {
+	runs := ticks[n]
+	k, _ := slices.BinarySearch(runs, i+n)
+	if k == len(runs) {
+		return "", 0, false
+	}
+	j := runs[k]
+
+	code := strings.ReplaceAll(s[i+n:j], "\n", " ")
+	if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+		code = code[1 : len(code)-1]
+	}
+	return code, j + n, true
+}
+
+// flanking reports whether the delimiter run of n characters at i can open
+// and close emphasis.
+func flanking(s string, i, n int) (canOpen, canClose bool) {
+	before, _ := utf8.DecodeLastRuneInString(s[:i])
+	after, _ := utf8.DecodeRuneInString(s[i+n:])
+	if i == 0 {
+		before = ' '
+	}
+	if i+n == len(s) {
+		after = ' '
+	}
+
+	spaceBefore, spaceAfter := unicode.IsSpace(before), unicode.IsSpace(after)
+	punctBefore := unicode.IsPunct(before) || unicode.IsSymbol(before)
+	punctAfter := unicode.IsPunct(after) || unicode.IsSymbol(after)
+
+	left := !spaceAfter && (!punctAfter || spaceBefore || punctBefore)
+	right := !spaceBefore && (!punctBefore || spaceAfter || punctAfter)
+
+	if s[i] == '_' {
+		return left && (!right || punctBefore), right && (!left || punctAfter)
+	}
+	return left, right
+}
+
+// parseLink parses an inline link like [text](url "title") at i. brackets
+// are the closing brackets of matchBrackets.
+func parseLink(s string, i int, brackets map[int]int) (*node, int, bool) {
+	textEnd, ok := brackets[i]
+	if !ok || textEnd+1 >= len(s) || s[textEnd+1] != '(' {
+		return nil, 0, false
+	}
+
+	j := skipSpaces(s, textEnd+2)
+	dest, j, ok := linkDestination(s, j)
+	if !ok {
+		return nil, 0, false
+	}
+
+	title := ""
+	if k := skipSpaces(s, j); k > j && k < len(s) && strings.IndexByte(`"'(`, s[k]) >= 0 {
+		title, j, ok = linkTitle(s, k)
+		if !ok {
+			return nil, 0, false
+		}
+	}
+
+	j = skipSpaces(s, j)
+	if j >= len(s) || s[j] != ')' {
+		return nil, 0, false
+	}
+
+	link := &node{kind: kindLink, title: title, children: parseInlinesIn(s[i+1:textEnd], true)}
+	link.href, _ = safeURL(unescape(dest))
+	return link, j + 1, true
+}
+
+// matchBrackets returns the index of the ] that closes each [ of s, skipping
+// escaped brackets and brackets in code spans.
+func matchBrackets(s string, ticks backticks) map[int]int {
+	brackets := make(map[int]int)
+	var open []int
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			n := runLength(s, j, '`')
+			if _, end, ok := ticks.codeSpan(s, j, n); ok {
+				j = end - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			open = append(open, j)
+		case ']':
+			if len(open) > 0 {
+				brackets[open[len(open)-1]] = j
+				open = open[:len(open)-1]
+			}
+		}
+	}
+	return brackets
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func linkDestination(s string, i int) (string, int, bool) {
+	if i < len(s) && s[i] == '<' {
+		end := strings.IndexAny(s[i+1:], "<>\n")
+		if end < 0 || s[i+1+end] != '>' {
+			return "", 0, false
+		}
+		return s[i+1 : i+1+end], i + end + 2, true
+	}
+
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		c := s[j]
+		if c == '\\' && j+1 < len(s) && isPunct(s[j+1]) {
+			j++
+			continue
+		}
+		if c <= ' ' {
+			break
+		}
+		if c == '(' {
+			depth++
+			if depth > maxParenDepth {
+				return "", 0, false
+			}
+		}
+		if c == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	return s[i:j], j, depth == 0
+}
+
+func linkTitle(s string, i int) (string, int, bool) {
+	closing := s[i]
+	if closing == '(' {
+		closing = ')'
+	}
+
+	for j := i + 1; j < len(s); j++ {
+		if s[j] == '\\' {
+			j++
+			continue
+		}
+		if s[j] == closing {
+			return unescape(s[i+1 : j]), j + 1, true
+		}
+		// Titles in parentheses can't contain unescaped parentheses.
+		if closing == ')' && s[j] == '(' {
+			return "", 0, false
+		}
+	}
+	return "", 0, false
+}
+
+// unescape removes backslash escapes from link destinations and titles.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func parseAutolink(s string, i int) (*node, int, bool) {
+	if m := autolinkRX.FindStringSubmatch(s[i:]); m != nil {
+		href, ok := safeURL(m[1])
+		if !ok {
+			return nil, 0, false
+		}
+		return &node{kind: kindLink, href: href, children: []*node{{kind: kindText, text: m[1]}}}, i + len(m[0]), true
+	}
+
+	if m := emailRX.FindStringSubmatch(s[i:]); m != nil {
+		return &node{kind: kindLink, href: "mailto:" + m[1], children: []*node{{kind: kindText, text: m[1]}}}, i + len(m[0]), true
+	}
+
+	return nil, 0, false
+}
+
+// processEmphasis matches delimiter runs to emphasis and strong emphasis,
+// like the algorithm of the CommonMark spec. The tokens are linked in lists,
+// so matches are spliced in place, and openersBottom remembers where the
+// search for an opener failed before, so it never passes there again.
+func processEmphasis(tokens []*token) []*node {
+	head := &token{}
+	prev, prevDelim := head, (*token)(nil)
+	for _, t := range tokens {
+		t.prev, prev.next = prev, t
+		prev = t
+		if t.delim != 0 {
+			t.prevDelim = prevDelim
+			if prevDelim != nil {
+				prevDelim.nextDelim = t
+			}
+			prevDelim = t
+		}
+	}
+
+	var first *token
+	for t := prevDelim; t != nil; t = t.prevDelim {
+		first = t
+	}
+
+	type bottomKey struct {
+		delim   byte
+		canOpen bool
+		length  int
+	}
+	openersBottom := make(map[bottomKey]int)
+
+	for closer := first; closer != nil; {
+		if !closer.canClose {
+			closer = closer.nextDelim
+			continue
+		}
+
+		key := bottomKey{closer.delim, closer.canOpen, closer.length % 3}
+		bottom, ok := openersBottom[key]
+		if !ok {
+			bottom = -1
+		}
+
+		opener := closer.prevDelim
+		for ; opener != nil && opener.pos > bottom; opener = opener.prevDelim {
+			if opener.delim != closer.delim || !opener.canOpen {
+				continue
+			}
+			// The rule of three of the spec.
+			if (opener.canClose || closer.canOpen) && (opener.length+closer.length)%3 == 0 &&
+				!(opener.length%3 == 0 && closer.length%3 == 0) {
+				continue
+			}
+			break
+		}
+
+		if opener == nil || opener.pos <= bottom {
+			// Closers like this one won't find an opener before here.
+			openersBottom[key] = closer.pos - 1
+			next := closer.nextDelim
+			if !closer.canOpen {
+				unlinkDelim(closer)
+			}
+			closer = next
+			continue
+		}
+
+		use := 1
+		k := kindEmphasis
+		if opener.count >= 2 && closer.count >= 2 {
+			use = 2
+			k = kindStrong
+		}
+		opener.count -= use
+		closer.count -= use
+
+		// The tokens in between become the children, and the delimiters
+		// among them text.
+		var inner []*token
+		for t := opener.next; t != closer; t = t.next {
+			inner = append(inner, t)
+		}
+		em := &token{node: &node{kind: k, children: flatten(inner)}}
+		em.prev, em.next = opener, closer
+		opener.next, closer.prev = em, em
+		opener.nextDelim, closer.prevDelim = closer, opener
+
+		if opener.count == 0 {
+			unlink(opener)
+			unlinkDelim(opener)
+		}
+		if closer.count == 0 {
+			next := closer.nextDelim
+			unlink(closer)
+			unlinkDelim(closer)
+			closer = next
+		}
+		// Otherwise the closer may close more emphasis.
+	}
+
+	var rest []*token
+	for t := head.next; t != nil; t = t.next {
+		rest = append(rest, t)
+	}
+	return flatten(rest)
+}
+
+func unlink(t *token) {
+	t.prev.next = t.next
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+}
+
+func unlinkDelim(t *token) {
+	if t.prevDelim != nil {
+		t.prevDelim.nextDelim = t.nextDelim
+	}
+	if t.nextDelim != nil {
+		t.nextDelim.prevDelim = t.prevDelim
+	}
+}
+
+// flatten returns the nodes of tokens. Unmatched delimiters become text.
+func flatten(tokens []*token) []*node {
+	nodes := make([]*node, 0, len(tokens))
+	for _, t := range tokens {
+		if t.delim == 0 {
+			nodes = append(nodes, t.node)
+			continue
+		}
+		if t.count > 0 {
+			nodes = append(nodes, &node{kind: kindText, text: strings.Repeat(string(t.delim), t.count)})
+		}
+	}
+	return nodes
+}
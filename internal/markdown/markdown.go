@@ -0,0 +1,258 @@
+// Package markdown renders the CommonMark subset goals and branding
+// descriptions may use: paragraphs, lists, emphasis, code spans, fenced code
+// blocks and links. Everything else, raw HTML included, is shown as text.
+//
+// Rendering doubles as the sanitizer: the output only ever contains the
+// elements p, ul, ol, li, pre, code, em, strong, a and br, and the attributes
+// start on ol, class="language-…" on code, and href, title and rel on a. All
+// text and attribute values are escaped. Links are kept only for http, https
+// and mailto URLs and relative URLs; outbound links get rel="nofollow
+// noopener".
+package markdown
+
+import (
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type kind int
+
+const (
+	kindDocument kind = iota
+	kindParagraph
+	kindList
+	kindItem
+	kindCodeBlock
+	kindText
+	kindCode
+	kindEmphasis
+	kindStrong
+	kindLink
+	kindLineBreak
+	kindSoftBreak
+)
+
+type node struct {
+	kind     kind
+	children []*node
+	// text is the literal of text, code and code block nodes.
+	text string
+	// ordered and start describe lists.
+	ordered bool
+	start   int
+	// tight lists render their paragraphs without <p>.
+	tight bool
+	// lang is the info string of fenced code blocks.
+	lang string
+	// href and title describe links.
+	href  string
+	title string
+}
+
+// Render converts src to HTML.
+func Render(src string) template.HTML {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.ReplaceAll(src, "\x00", "�")
+
+	doc := &node{kind: kindDocument, children: parseBlocks(strings.Split(src, "\n"))}
+
+	var b strings.Builder
+	renderChildren(&b, doc, false)
+	return template.HTML(strings.TrimSuffix(b.String(), "\n"))
+}
+
+var (
+	fenceRX    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)[^`]*$")
+	listItemRX = regexp.MustCompile(`^( {0,3})([-*+]|(\d{1,9})[.)])( +|$)`)
+	langRX     = regexp.MustCompile(`^[A-Za-z0-9_+#-]+$`)
+)
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func parseBlocks(lines []string) []*node {
+	var blocks []*node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fenceRX.MatchString(line):
+			var block *node
+			block, i = parseFence(lines, i)
+			blocks = append(blocks, block)
+		case listItemRX.MatchString(line):
+			var block *node
+			block, i = parseList(lines, i)
+			blocks = append(blocks, block)
+		default:
+			var block *node
+			block, i = parseParagraph(lines, i)
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func parseFence(lines []string, i int) (*node, int) {
+	match := fenceRX.FindStringSubmatch(lines[i])
+	fence := match[1]
+	indent := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+
+	block := &node{kind: kindCodeBlock}
+	if langRX.MatchString(match[2]) {
+		block.lang = match[2]
+	}
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		code = append(code, removeIndent(lines[i], indent))
+	}
+
+	block.text = strings.Join(code, "\n")
+	if len(code) > 0 {
+		block.text += "\n"
+	}
+	return block, i
+}
+
+// removeIndent removes up to n leading spaces from line.
+func removeIndent(line string, n int) string {
+	for n > 0 && strings.HasPrefix(line, " ") {
+		line = line[1:]
+		n--
+	}
+	return line
+}
+
+// listMarker describes the marker of a list item.
+type listMarker struct {
+	bullet  byte
+	ordered bool
+	start   int
+	// width is the indent of the item content.
+	width int
+}
+
+func parseMarker(line string) (listMarker, string, bool) {
+	match := listItemRX.FindStringSubmatchIndex(line)
+	if match == nil {
+		return listMarker{}, "", false
+	}
+
+	m := listMarker{width: match[1]}
+	marker := line[match[4]:match[5]]
+	if match[6] >= 0 {
+		m.ordered = true
+		m.start, _ = strconv.Atoi(line[match[6]:match[7]])
+		m.bullet = marker[len(marker)-1]
+	} else {
+		m.bullet = marker[0]
+	}
+
+	content := line[match[1]:]
+	if spaces := match[9] - match[8]; spaces > 4 {
+		// Content indented by five or more spaces starts one space after
+		// the marker.
+		m.width = match[8] + 1
+		content = line[m.width:]
+	} else if content == "" {
+		m.width = match[8] + 1
+	}
+
+	return m, content, true
+}
+
+func (m listMarker) sameList(other listMarker) bool {
+	return m.ordered == other.ordered && m.bullet == other.bullet
+}
+
+func parseList(lines []string, i int) (*node, int) {
+	first, _, _ := parseMarker(lines[i])
+	list := &node{kind: kindList, ordered: first.ordered, start: first.start, tight: true}
+
+	for i < len(lines) {
+		marker, content, ok := parseMarker(lines[i])
+		if !ok || !marker.sameList(first) {
+			break
+		}
+
+		itemLines := []string{content}
+		blankBefore := false
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				itemLines = append(itemLines, "")
+				blankBefore = true
+				continue
+			}
+
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+			if indent >= marker.width {
+				if blankBefore {
+					list.tight = false
+				}
+				itemLines = append(itemLines, line[marker.width:])
+				blankBefore = false
+				continue
+			}
+
+			// Lazy continuation lines belong to the paragraph of the item.
+			if !blankBefore && !listItemRX.MatchString(line) && !fenceRX.MatchString(line) {
+				itemLines = append(itemLines, line)
+				continue
+			}
+			break
+		}
+
+		// Trailing blank lines separate items; they don't belong to them.
+		for len(itemLines) > 0 && itemLines[len(itemLines)-1] == "" {
+			itemLines = itemLines[:len(itemLines)-1]
+		}
+		if blankBefore && i < len(lines) {
+			if next, _, ok := parseMarker(lines[i]); ok && next.sameList(first) {
+				list.tight = false
+			}
+		}
+
+		list.children = append(list.children, &node{kind: kindItem, children: parseBlocks(itemLines)})
+	}
+
+	return list, i
+}
+
+// interruptsParagraph reports whether line starts a block that ends a
+// paragraph. Like CommonMark, only lists starting with 1 and non-empty items
+// do.
+func interruptsParagraph(line string) bool {
+	if fenceRX.MatchString(line) {
+		return true
+	}
+	marker, content, ok := parseMarker(line)
+	if !ok || isBlank(content) {
+		return false
+	}
+	return !marker.ordered || marker.start == 1
+}
+
+func parseParagraph(lines []string, i int) (*node, int) {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) || (len(text) > 0 && interruptsParagraph(line)) {
+			break
+		}
+		text = append(text, strings.TrimLeft(line, " \t"))
+	}
+
+	return &node{kind: kindParagraph, children: parseInlines(strings.Join(text, "\n"))}, i
+}
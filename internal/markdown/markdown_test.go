@@ -0,0 +1,181 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "empty", src: "", want: ""},
+		{name: "paragraphs", src: "One\ntwo\n\nThree", want: "<p>One\ntwo</p>\n<p>Three</p>"},
+		{name: "hard line break", src: "One  \ntwo\\\nthree", want: "<p>One<br />\ntwo<br />\nthree</p>"},
+		{name: "emphasis", src: "*em* _em_ **strong** __strong__", want: "<p><em>em</em> <em>em</em> <strong>strong</strong> <strong>strong</strong></p>"},
+		{name: "nested emphasis", src: "***both*** and **bold *inside***", want: "<p><em><strong>both</strong></em> and <strong>bold <em>inside</em></strong></p>"},
+		{name: "intraword underscore", src: "snake_case_name", want: "<p>snake_case_name</p>"},
+		{name: "unmatched delimiters", src: "2 * 3 * 4 and *open", want: "<p>2 * 3 * 4 and *open</p>"},
+		{name: "code span", src: "Run `go test ./...` or ``a ` b``", want: "<p>Run <code>go test ./...</code> or <code>a ` b</code></p>"},
+		{name: "no emphasis in code", src: "`*not em*`", want: "<p><code>*not em*</code></p>"},
+		{name: "backslash escapes", src: `\*not em\* \[not a link\]`, want: "<p>*not em* [not a link]</p>"},
+		{
+			name: "fenced code block",
+			src:  "```go\nfunc main() {\n\t<b>\n}\n```",
+			want: "<pre><code class=\"language-go\">func main() {\n\t&lt;b&gt;\n}\n</code></pre>",
+		},
+		{
+			name: "unordered list",
+			src:  "- one\n- *two*\n- three",
+			want: "<ul>\n<li>one</li>\n<li><em>two</em></li>\n<li>three</li>\n</ul>",
+		},
+		{
+			name: "ordered list with start",
+			src:  "3. three\n4. four",
+			want: "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>",
+		},
+		{
+			name: "nested list",
+			src:  "- one\n  - nested\n- two",
+			want: "<ul>\n<li>one\n<ul>\n<li>nested</li>\n</ul>\n</li>\n<li>two</li>\n</ul>",
+		},
+		{
+			name: "loose list",
+			src:  "- one\n\n- two",
+			want: "<ul>\n<li><p>one</p>\n</li>\n<li><p>two</p>\n</li>\n</ul>",
+		},
+		{
+			name: "list after paragraph",
+			src:  "Steps:\n1. plan\n2. do",
+			want: "<p>Steps:</p>\n<ol>\n<li>plan</li>\n<li>do</li>\n</ol>",
+		},
+		{
+			name: "link",
+			src:  `See [the *docs*](https://example.com/docs "Docs").`,
+			want: `<p>See <a href="https://example.com/docs" title="Docs" rel="nofollow noopener">the <em>docs</em></a>.</p>`,
+		},
+		{
+			name: "relative and mail links",
+			src:  "[home](/goals) [mail](mailto:me@example.com)",
+			want: `<p><a href="/goals">home</a> <a href="mailto:me@example.com">mail</a></p>`,
+		},
+		{
+			name: "autolinks",
+			src:  "<https://example.com> <me@example.com>",
+			want: `<p><a href="https://example.com" rel="nofollow noopener">https://example.com</a> <a href="mailto:me@example.com">me@example.com</a></p>`,
+		},
+		{name: "headings stay text", src: "# Title", want: "<p># Title</p>"},
+		{name: "emphasis in emphasis", src: "*a **b** c* and **a *b* c**", want: "<p><em>a <strong>b</strong> c</em> and <strong>a <em>b</em> c</strong></p>"},
+		{name: "leftover delimiters", src: "**a* b*** c", want: "<p><em><em>a</em> b</em>** c</p>"},
+		{name: "link in brackets", src: "[[x](/a)] [y]", want: `<p>[<a href="/a">x</a>] [y]</p>`},
+		{name: "title in parentheses", src: "[x](/a (A)) [y](/b (B(C)))", want: `<p><a href="/a" title="A">x</a> [y](/b (B(C)))</p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Render(tt.src)); got != tt.want {
+				t.Errorf("expected\n%q\ngot\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderIsSafe(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "raw html", src: `<script>alert(1)</script>`, want: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{name: "inline html", src: `Hi <img src=x onerror=alert(1)>`, want: "<p>Hi &lt;img src=x onerror=alert(1)&gt;</p>"},
+		{name: "javascript link", src: `[click](javascript:alert(1))`, want: "<p>click</p>"},
+		{name: "obfuscated javascript link", src: `[click](JaVaScRiPt:alert(1))`, want: "<p>click</p>"},
+		{name: "data link", src: `[click](data:text/html;base64,PHNjcmlwdD4=)`, want: "<p>click</p>"},
+		{name: "protocol-relative link", src: `[click](//evil.example)`, want: "<p>click</p>"},
+		{name: "javascript autolink", src: `<javascript:alert(1)>`, want: "<p>&lt;javascript:alert(1)&gt;</p>"},
+		{name: "quotes in href", src: `[x](https://example.com/"onmouseover="alert(1))`, want: `<p><a href="https://example.com/&#34;onmouseover=&#34;alert(1)" rel="nofollow noopener">x</a></p>`},
+		{name: "quotes in title", src: `[x](/a "a\" onclick=\"b")`, want: `<p><a href="/a" title="a&#34; onclick=&#34;b">x</a></p>`},
+		{name: "code block language", src: "```\"><script>\nx\n```", want: "<pre><code>x\n</code></pre>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Render(tt.src))
+			if got != tt.want {
+				t.Errorf("expected\n%q\ngot\n%q", tt.want, got)
+			}
+			if strings.Contains(got, "<script") || strings.Contains(got, "javascript:alert") && strings.Contains(got, "href") {
+				t.Errorf("unsafe output %q", got)
+			}
+		})
+	}
+}
+
+// TestRenderHostileInput renders inputs that take quadratic time with naive
+// emphasis and link matching.
+func TestRenderHostileInput(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "emphasis closers", src: strings.Repeat("a**", 50000)},
+		{name: "mixed delimiters", src: strings.Repeat("a*_", 50000)},
+		{name: "unclosed links", src: strings.Repeat("[a](", 50000)},
+		{name: "unclosed brackets", src: strings.Repeat("[", 150000)},
+		{name: "nested brackets", src: strings.Repeat("[", 50000) + strings.Repeat("]", 50000)},
+		{name: "unclosed titles", src: strings.Repeat("[a](b (", 30000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				Render(tt.src)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(3 * time.Second):
+				t.Fatalf("rendering %d bytes took longer than 3s", len(tt.src))
+			}
+		})
+	}
+}
+
+func FuzzRender(f *testing.F) {
+	f.Add("*a* **b** `c` [d](https://e) <f@g.h>")
+	f.Add("- a\n  1. b\n\n```\nc\n```")
+	f.Add("<b onclick=x>")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		got := string(Render(src))
+		for _, bad := range []string{"<script", "<img", "<iframe", "javascript:", " on"} {
+			if strings.Contains(strings.ToLower(stripText(got)), bad) {
+				t.Errorf("output of %q contains %q: %q", src, bad, got)
+			}
+		}
+	})
+}
+
+// stripText returns only the tags of html.
+func stripText(html string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range html {
+		switch {
+		case r == '<':
+			inTag = true
+			b.WriteRune(r)
+		case r == '>':
+			inTag = false
+			b.WriteRune(r)
+		case inTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
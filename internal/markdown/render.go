@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+func renderChildren(b *strings.Builder, n *node, tight bool) {
+	for _, child := range n.children {
+		render(b, child, tight)
+	}
+}
+
+func render(b *strings.Builder, n *node, tight bool) {
+	switch n.kind {
+	case kindParagraph:
+		if tight {
+			renderChildren(b, n, false)
+			return
+		}
+		b.WriteString("<p>")
+		renderChildren(b, n, false)
+		b.WriteString("</p>\n")
+	case kindList:
+		tag := "ul"
+		if n.ordered {
+			tag = "ol"
+		}
+		b.WriteString("<" + tag)
+		if n.ordered && n.start != 1 {
+			b.WriteString(` start="` + strconv.Itoa(n.start) + `"`)
+		}
+		b.WriteString(">\n")
+		renderChildren(b, n, n.tight)
+		b.WriteString("</" + tag + ">\n")
+	case kindItem:
+		b.WriteString("<li>")
+		for i, child := range n.children {
+			// Nested blocks of tight items start on a new line.
+			if i > 0 && tight && n.children[i-1].kind == kindParagraph {
+				b.WriteString("\n")
+			}
+			render(b, child, tight)
+		}
+		b.WriteString("</li>\n")
+	case kindCodeBlock:
+		b.WriteString("<pre><code")
+		if n.lang != "" {
+			b.WriteString(` class="language-` + html.EscapeString(n.lang) + `"`)
+		}
+		b.WriteString(">" + html.EscapeString(n.text) + "</code></pre>\n")
+	case kindText:
+		b.WriteString(html.EscapeString(n.text))
+	case kindCode:
+		b.WriteString("<code>" + html.EscapeString(n.text) + "</code>")
+	case kindEmphasis:
+		b.WriteString("<em>")
+		renderChildren(b, n, false)
+		b.WriteString("</em>")
+	case kindStrong:
+		b.WriteString("<strong>")
+		renderChildren(b, n, false)
+		b.WriteString("</strong>")
+	case kindLink:
+		b.WriteString(`<a href="` + html.EscapeString(n.href) + `"`)
+		if n.title != "" {
+			b.WriteString(` title="` + html.EscapeString(n.title) + `"`)
+		}
+		if outbound(n.href) {
+			b.WriteString(` rel="nofollow noopener"`)
+		}
+		b.WriteString(">")
+		renderChildren(b, n, false)
+		b.WriteString("</a>")
+	case kindLineBreak:
+		b.WriteString("<br />\n")
+	case kindSoftBreak:
+		b.WriteString("\n")
+	}
+}
+
+// safeURL returns the URL of a link if it is allowed: http, https and mailto
+// URLs and relative URLs.
+func safeURL(raw string) (string, bool) {
+	u := strings.TrimSpace(raw)
+	if u == "" {
+		return "", false
+	}
+	for _, r := range u {
+		if r <= ' ' || r == 0x7f {
+			return "", false
+		}
+	}
+
+	// Protocol-relative URLs point to other hosts.
+	if strings.HasPrefix(u, "//") || strings.HasPrefix(u, `\`) {
+		return "", false
+	}
+
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.IndexAny(u[:colon], "/?#") >= 0 {
+		return u, true
+	}
+
+	switch strings.ToLower(u[:colon]) {
+	case "http", "https", "mailto":
+		return u, true
+	}
+	return "", false
+}
+
+// outbound reports whether href points to another site.
+func outbound(href string) bool {
+	scheme, _, ok := strings.Cut(href, ":")
+	if !ok {
+		return false
+	}
+	scheme = strings.ToLower(scheme)
+	return scheme == "http" || scheme == "https"
+}
@@ -0,0 +1,143 @@
+// Package preferences stores per-user settings that change how goals are
+// handled, like automatic archiving, when goals count as at risk and which
+// emails are sent when.
+package preferences
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bit8bytes/goalkeepr/internal/digest"
+	"github.com/bit8bytes/goalkeepr/internal/reminders"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+type Form struct {
+	// AutoArchiveDays is the number of days after which achieved goals are
+	// archived. 0 turns automatic archiving off.
+	AutoArchiveDays     int `form:"auto_archive_days"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(f.AutoArchiveDays >= 0, "auto_archive_days", "Days cannot be negative")
+	f.Check(f.AutoArchiveDays <= 3650, "auto_archive_days", "Days cannot be more than 3650")
+}
+
+const (
+	// DefaultRiskDays and DefaultRiskProgress are the risk thresholds of
+	// users who never changed them.
+	DefaultRiskDays     = 14
+	DefaultRiskProgress = 50
+)
+
+// RiskForm holds the thresholds that decide when a goal is at risk.
+type RiskForm struct {
+	// RiskDays is how many days before their due date goals can be at risk.
+	RiskDays int `form:"risk_days"`
+	// RiskProgress is the share of completed success criteria in percent
+	// that goals need to not be at risk.
+	RiskProgress        int `form:"risk_progress"`
+	validator.Validator `form:"-"`
+}
+
+func (f *RiskForm) Validate() {
+	f.Check(f.RiskDays >= 1, "risk_days", "Days must be at least 1")
+	f.Check(f.RiskDays <= 365, "risk_days", "Days cannot be more than 365")
+	f.Check(f.RiskProgress >= 1, "risk_progress", "Progress must be at least 1%")
+	f.Check(f.RiskProgress <= 100, "risk_progress", "Progress cannot be more than 100%")
+}
+
+// ReminderForm holds the days before the due date reminders are sent on.
+type ReminderForm struct {
+	// ReminderDays are days separated by commas, like "7, 1". Empty turns
+	// reminders off.
+	ReminderDays        string `form:"reminder_days"`
+	validator.Validator `form:"-"`
+
+	days []int
+}
+
+func (f *ReminderForm) Validate() {
+	days, err := reminders.ParseDays(f.ReminderDays)
+	f.Check(!errors.Is(err, reminders.ErrTooManyDays), "reminder_days", fmt.Sprintf("Choose at most %d days", reminders.MaxReminders))
+	f.Check(err == nil, "reminder_days", fmt.Sprintf("Days must be numbers from 0 to %d, separated by commas", reminders.MaxDays))
+	f.days = days
+}
+
+// DigestForm holds the weekday the weekly digest is sent on.
+type DigestForm struct {
+	// DigestDay is a weekday like "monday", or "off".
+	DigestDay           string `form:"digest_day"`
+	validator.Validator `form:"-"`
+}
+
+func (f *DigestForm) Validate() {
+	f.Check(digest.ValidDay(f.DigestDay), "digest_day", "Choose a day of the week or off")
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// GetByUserID returns the preferences of a user. Users who never changed
+// their preferences get the defaults.
+func (s *Service) GetByUserID(ctx context.Context, userID int) (Preference, error) {
+	preference, err := s.queries.GetByUserID(ctx, int64(userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Preference{UserID: int64(userID)}, nil
+	}
+	if err != nil {
+		return Preference{}, err
+	}
+	return preference, nil
+}
+
+func (s *Service) SetAutoArchiveDays(ctx context.Context, userID int, form *Form) error {
+	return s.queries.SetAutoArchiveDays(ctx, SetAutoArchiveDaysParams{
+		UserID:          int64(userID),
+		AutoArchiveDays: sql.NullInt64{Int64: int64(form.AutoArchiveDays), Valid: form.AutoArchiveDays > 0},
+	})
+}
+
+func (s *Service) SetRiskThresholds(ctx context.Context, userID int, form *RiskForm) error {
+	return s.queries.SetRiskThresholds(ctx, SetRiskThresholdsParams{
+		UserID:       int64(userID),
+		RiskDays:     sql.NullInt64{Int64: int64(form.RiskDays), Valid: true},
+		RiskProgress: sql.NullInt64{Int64: int64(form.RiskProgress), Valid: true},
+	})
+}
+
+// SetReminderDays saves the reminder days of a validated form.
+func (s *Service) SetReminderDays(ctx context.Context, userID int, form *ReminderForm) error {
+	return s.queries.SetReminderDays(ctx, SetReminderDaysParams{
+		UserID:       int64(userID),
+		ReminderDays: sql.NullString{String: reminders.FormatDays(form.days), Valid: true},
+	})
+}
+
+func (s *Service) SetDigestDay(ctx context.Context, userID int, form *DigestForm) error {
+	return s.queries.SetDigestDay(ctx, SetDigestDayParams{
+		UserID:    int64(userID),
+		DigestDay: sql.NullString{String: form.DigestDay, Valid: true},
+	})
+}
+
+// UnsubscribeDigest stops the weekly digest of a user.
+func (s *Service) UnsubscribeDigest(ctx context.Context, userID int) error {
+	return s.SetDigestDay(ctx, userID, &DigestForm{DigestDay: digest.Off})
+}
+
+// GetAllWithAutoArchive returns the preferences of all users who turned on
+// automatic archiving.
+func (s *Service) GetAllWithAutoArchive(ctx context.Context) ([]Preference, error) {
+	return s.queries.GetAllWithAutoArchive(ctx)
+}
@@ -0,0 +1,45 @@
+package preferences
+
+import (
+	"github.com/bit8bytes/goalkeepr/internal/digest"
+	"github.com/bit8bytes/goalkeepr/internal/reminders"
+)
+
+type View struct {
+	AutoArchiveDays int
+	RiskDays        int
+	RiskProgress    int
+	// ReminderDays are the reminder days formatted for the settings form.
+	ReminderDays string
+	// DigestDay is the weekday the digest is sent on, like "monday", or
+	// "off".
+	DigestDay string
+}
+
+func (p *Preference) ToView() View {
+	view := View{
+		AutoArchiveDays: int(p.AutoArchiveDays.Int64),
+		RiskDays:        DefaultRiskDays,
+		RiskProgress:    DefaultRiskProgress,
+		ReminderDays:    reminders.FormatDays(reminders.DefaultDays),
+		DigestDay:       digest.DayName(digest.DefaultDay),
+	}
+
+	if p.RiskDays.Valid {
+		view.RiskDays = int(p.RiskDays.Int64)
+	}
+
+	if p.RiskProgress.Valid {
+		view.RiskProgress = int(p.RiskProgress.Int64)
+	}
+
+	if p.ReminderDays.Valid {
+		view.ReminderDays = p.ReminderDays.String
+	}
+
+	if p.DigestDay.Valid {
+		view.DigestDay = p.DigestDay.String
+	}
+
+	return view
+}
@@ -0,0 +1,125 @@
+// Package reactions lets share page viewers cheer on goals with a small set
+// of emoji. Each viewer can give each reaction once per goal.
+package reactions
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Reaction struct {
+	Name  string
+	Emoji string
+	Label string
+}
+
+// All are the reactions viewers can choose from, in the order they are shown.
+var All = []Reaction{
+	{Name: "clap", Emoji: "👏", Label: "Applause"},
+	{Name: "fire", Emoji: "🔥", Label: "On fire"},
+	{Name: "strong", Emoji: "💪", Label: "Keep going"},
+	{Name: "party", Emoji: "🎉", Label: "Celebrate"},
+	{Name: "heart", Emoji: "❤️", Label: "Love it"},
+}
+
+// Valid reports whether name is one of All.
+func Valid(name string) bool {
+	for _, r := range All {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts holds the number of each reaction on a goal, by name.
+type Counts map[string]int
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Toggle adds the reaction of a viewer to a goal or takes it back if the
+// viewer already gave it. It reports whether the viewer has the reaction
+// now.
+func (s *Service) Toggle(ctx context.Context, goalID, userID int, viewerID, reaction string) (bool, error) {
+	result, err := s.queries.DeleteReaction(ctx, DeleteReactionParams{
+		GoalID:   int64(goalID),
+		ViewerID: viewerID,
+		Reaction: reaction,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected > 0 {
+		return false, nil
+	}
+
+	_, err = s.queries.CreateReaction(ctx, CreateReactionParams{
+		GoalID:   int64(goalID),
+		UserID:   int64(userID),
+		ViewerID: viewerID,
+		Reaction: reaction,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetCountsByGoals returns the reaction counts of the given goals of a user,
+// by goal ID. The counts are kept up to date by the database, so this is a
+// single lookup no matter how many viewers reacted.
+func (s *Service) GetCountsByGoals(ctx context.Context, userID int, goalIDs []int64) (map[int64]Counts, error) {
+	rows, err := s.queries.GetAllReactionCountsByGoals(ctx, GetAllReactionCountsByGoalsParams{
+		UserID:  int64(userID),
+		GoalIds: goalIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64]Counts)
+	for _, row := range rows {
+		if byGoal[row.GoalID] == nil {
+			byGoal[row.GoalID] = make(Counts)
+		}
+		byGoal[row.GoalID][row.Reaction] = int(row.Count)
+	}
+
+	return byGoal, nil
+}
+
+// GetByViewer returns the reactions a viewer gave to the goals of a user,
+// by goal ID.
+func (s *Service) GetByViewer(ctx context.Context, userID int, viewerID string) (map[int64]map[string]bool, error) {
+	rows, err := s.queries.GetAllReactionsByViewer(ctx, GetAllReactionsByViewerParams{
+		UserID:   int64(userID),
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64]map[string]bool)
+	for _, row := range rows {
+		if byGoal[row.GoalID] == nil {
+			byGoal[row.GoalID] = make(map[string]bool)
+		}
+		byGoal[row.GoalID][row.Reaction] = true
+	}
+
+	return byGoal, nil
+}
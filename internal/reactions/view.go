@@ -0,0 +1,29 @@
+package reactions
+
+type View struct {
+	Reaction
+	Count int
+	// Mine is set if the viewer gave this reaction.
+	Mine bool
+}
+
+// Buttons returns all reactions with their counts, as the reaction buttons
+// on the share page show them.
+func Buttons(counts Counts, mine map[string]bool) []View {
+	views := make([]View, len(All))
+	for i, r := range All {
+		views[i] = View{Reaction: r, Count: counts[r.Name], Mine: mine[r.Name]}
+	}
+	return views
+}
+
+// Summary returns only the reactions a goal got, as its owner sees them.
+func Summary(counts Counts) []View {
+	var views []View
+	for _, r := range All {
+		if counts[r.Name] > 0 {
+			views = append(views, View{Reaction: r, Count: counts[r.Name]})
+		}
+	}
+	return views
+}
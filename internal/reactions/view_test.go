@@ -0,0 +1,57 @@
+package reactions
+
+import "testing"
+
+func TestButtons(t *testing.T) {
+	views := Buttons(Counts{"fire": 2}, map[string]bool{"fire": true})
+
+	if len(views) != len(All) {
+		t.Fatalf("expected %d buttons, got %d", len(All), len(views))
+	}
+	for _, v := range views {
+		wantCount, wantMine := 0, false
+		if v.Name == "fire" {
+			wantCount, wantMine = 2, true
+		}
+		if v.Count != wantCount || v.Mine != wantMine {
+			t.Errorf("expected %s to have count %d and mine %t, got %d and %t", v.Name, wantCount, wantMine, v.Count, v.Mine)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts Counts
+		want   []string
+	}{
+		{name: "no reactions", counts: nil, want: nil},
+		{name: "in the order of All", counts: Counts{"heart": 1, "clap": 3}, want: []string{"clap", "heart"}},
+		{name: "zero counts are left out", counts: Counts{"party": 0, "fire": 1}, want: []string{"fire"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summary(tt.counts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d reactions, got %d", len(tt.want), len(got))
+			}
+			for i, v := range got {
+				if v.Name != tt.want[i] {
+					t.Errorf("expected %q, got %q", tt.want[i], v.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, r := range All {
+		if !Valid(r.Name) {
+			t.Errorf("expected %q to be valid", r.Name)
+		}
+	}
+	if Valid("thumbsdown") {
+		t.Error("expected unknown reaction to be invalid")
+	}
+}
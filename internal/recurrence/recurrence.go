@@ -0,0 +1,225 @@
+// Package recurrence implements the subset of iCalendar recurrence rules
+// (RFC 5545 RRULE) used for repeating goals: FREQ, INTERVAL, UNTIL and COUNT.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const untilFormat = "20060102"
+
+// Frequency is the unit a rule repeats in.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// Frequencies returns all supported frequencies in ascending order.
+func Frequencies() []Frequency {
+	return []Frequency{Daily, Weekly, Monthly, Yearly}
+}
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Rule describes how a goal repeats. Until and Count are optional and
+// mutually exclusive. Count is the number of occurrences left, including
+// the current one.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Until    time.Time
+	Count    int
+}
+
+// Parse parses a rule like "FREQ=MONTHLY;INTERVAL=3;COUNT=4". An optional
+// "RRULE:" prefix is ignored.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	rule := Rule{Interval: 1}
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return Rule{}, fmt.Errorf("%w: interval %q", ErrInvalidRule, value)
+			}
+			rule.Interval = n
+		case "UNTIL":
+			// Only the date part is relevant for goals.
+			if len(value) < len(untilFormat) {
+				return Rule{}, fmt.Errorf("%w: until %q", ErrInvalidRule, value)
+			}
+			until, err := time.Parse(untilFormat, value[:len(untilFormat)])
+			if err != nil {
+				return Rule{}, fmt.Errorf("%w: until %q", ErrInvalidRule, value)
+			}
+			rule.Until = until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return Rule{}, fmt.Errorf("%w: count %q", ErrInvalidRule, value)
+			}
+			rule.Count = n
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// Validate reports whether the rule can be used.
+func (r Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return fmt.Errorf("%w: frequency %q", ErrInvalidRule, r.Freq)
+	}
+
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: interval must be at least 1", ErrInvalidRule)
+	}
+
+	if r.Count < 0 {
+		return fmt.Errorf("%w: count cannot be negative", ErrInvalidRule)
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: until and count cannot be combined", ErrInvalidRule)
+	}
+
+	return nil
+}
+
+// String formats the rule in RRULE syntax without the "RRULE:" prefix.
+func (r Rule) String() string {
+	var b strings.Builder
+	b.WriteString("FREQ=" + string(r.Freq))
+
+	if r.Interval > 1 {
+		b.WriteString(";INTERVAL=" + strconv.Itoa(r.Interval))
+	}
+	if !r.Until.IsZero() {
+		b.WriteString(";UNTIL=" + r.Until.Format(untilFormat))
+	}
+	if r.Count > 0 {
+		b.WriteString(";COUNT=" + strconv.Itoa(r.Count))
+	}
+
+	return b.String()
+}
+
+// Describe returns a human readable summary, e.g. "Every 3 months, 4 times".
+func (r Rule) Describe() string {
+	units := map[Frequency]string{
+		Daily:   "day",
+		Weekly:  "week",
+		Monthly: "month",
+		Yearly:  "year",
+	}
+
+	desc := "Every " + units[r.Freq]
+	if r.Interval > 1 {
+		desc = fmt.Sprintf("Every %d %ss", r.Interval, units[r.Freq])
+	}
+
+	switch {
+	case r.Count == 1:
+		desc += ", last time"
+	case r.Count > 1:
+		desc += fmt.Sprintf(", %d times", r.Count)
+	case !r.Until.IsZero():
+		desc += " until " + r.Until.Format("January 2, 2006")
+	}
+
+	return desc
+}
+
+// Next returns the occurrence following t and the rule that applies to it.
+// It reports false when the series ends with t.
+func (r Rule) Next(t time.Time) (time.Time, Rule, bool) {
+	if r.Count == 1 {
+		return time.Time{}, Rule{}, false
+	}
+
+	next := r.step(t)
+	if !r.Until.IsZero() && next.After(endOfDay(r.Until)) {
+		return time.Time{}, Rule{}, false
+	}
+
+	rule := r
+	if rule.Count > 0 {
+		rule.Count--
+	}
+
+	return next, rule, true
+}
+
+// Upcoming returns at most n occurrences after t.
+func (r Rule) Upcoming(t time.Time, n int) []time.Time {
+	occurrences := []time.Time{}
+
+	rule := r
+	for len(occurrences) < n {
+		next, nextRule, ok := rule.Next(t)
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, next)
+		t, rule = next, nextRule
+	}
+
+	return occurrences
+}
+
+// step advances t by one interval. Months and years are clamped to the last
+// day of the target month, so January 31 is followed by February 28.
+func (r Rule) step(t time.Time) time.Time {
+	switch r.Freq {
+	case Daily:
+		return t.AddDate(0, 0, r.Interval)
+	case Weekly:
+		return t.AddDate(0, 0, 7*r.Interval)
+	case Monthly:
+		return addMonths(t, r.Interval)
+	default:
+		return addMonths(t, 12*r.Interval)
+	}
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := first.AddDate(0, months, 0)
+
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := min(t.Day(), lastDay)
+
+	return target.AddDate(0, 0, day-1)
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
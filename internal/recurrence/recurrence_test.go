@@ -0,0 +1,122 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	tests := []string{
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2",
+		"FREQ=MONTHLY;INTERVAL=3;COUNT=4",
+		"FREQ=YEARLY;UNTIL=20301231",
+	}
+
+	for _, rule := range tests {
+		t.Run(rule, func(t *testing.T) {
+			r, err := Parse(rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", rule, err)
+			}
+			if r.String() != rule {
+				t.Errorf("expected %q, got %q", rule, r.String())
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=DAILY;UNTIL=2030",
+		"FREQ=DAILY;COUNT=2;UNTIL=20300101",
+		"FREQ=DAILY;BYDAY=MO",
+	}
+
+	for _, rule := range tests {
+		t.Run(rule, func(t *testing.T) {
+			if _, err := Parse(rule); err == nil {
+				t.Errorf("expected error for %q, got nil", rule)
+			}
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		want time.Time
+	}{
+		{"daily", "FREQ=DAILY;INTERVAL=10", date(2026, 12, 25), date(2027, 1, 4)},
+		{"weekly", "FREQ=WEEKLY", date(2026, 1, 1), date(2026, 1, 8)},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3", date(2026, 11, 15), date(2027, 2, 15)},
+		{"end of month is clamped", "FREQ=MONTHLY", date(2026, 1, 31), date(2026, 2, 28)},
+		{"leap day is clamped", "FREQ=YEARLY", date(2028, 2, 29), date(2029, 2, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, _, ok := r.Next(tt.from)
+			if !ok {
+				t.Fatal("expected another occurrence")
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRule_NextEndsSeries(t *testing.T) {
+	r, _ := Parse("FREQ=MONTHLY;COUNT=2")
+
+	_, next, ok := r.Next(date(2026, 1, 1))
+	if !ok {
+		t.Fatal("expected a second occurrence")
+	}
+	if next.Count != 1 {
+		t.Errorf("expected count 1, got %d", next.Count)
+	}
+	if _, _, ok := next.Next(date(2026, 2, 1)); ok {
+		t.Error("expected series to end after count is used up")
+	}
+
+	r, _ = Parse("FREQ=YEARLY;UNTIL=20270101")
+	if _, _, ok := r.Next(date(2026, 1, 1)); !ok {
+		t.Error("expected occurrence on the until date")
+	}
+	if _, _, ok := r.Next(date(2026, 1, 2)); ok {
+		t.Error("expected no occurrence after the until date")
+	}
+}
+
+func TestRule_Upcoming(t *testing.T) {
+	r, _ := Parse("FREQ=YEARLY;COUNT=3")
+
+	got := r.Upcoming(date(2026, 3, 31), 5)
+	want := []time.Time{date(2027, 3, 31), date(2028, 3, 31)}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d occurrences, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package reminders finds the goals that reminders are due for, at the days
+// before their due date each user chose, and makes sure each reminder is only
+// sent once.
+package reminders
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDays is the furthest before the due date a reminder can be sent.
+	MaxDays = 90
+	// MaxReminders is how many reminders a goal can get.
+	MaxReminders = 5
+)
+
+// DefaultDays are the days before the due date reminders are sent on for
+// users who never changed them.
+var DefaultDays = []int{7, 1}
+
+var (
+	ErrInvalidDays = fmt.Errorf("days must be numbers from 0 to %d", MaxDays)
+	ErrTooManyDays = fmt.Errorf("at most %d days are allowed", MaxReminders)
+)
+
+// ParseDays parses days before the due date separated by commas, like
+// "7, 1". The days are returned from furthest to closest to the due date,
+// without duplicates. An empty string means no reminders.
+func ParseDays(s string) ([]int, error) {
+	var days []int
+	for field := range strings.SplitSeq(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		day, err := strconv.Atoi(field)
+		if err != nil || day < 0 || day > MaxDays {
+			return nil, ErrInvalidDays
+		}
+
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	if len(days) > MaxReminders {
+		return nil, ErrTooManyDays
+	}
+
+	slices.Sort(days)
+	slices.Reverse(days)
+	return days, nil
+}
+
+// FormatDays formats days the way ParseDays parses them.
+func FormatDays(days []int) string {
+	fields := make([]string, len(days))
+	for i, day := range days {
+		fields[i] = strconv.Itoa(day)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// Offset returns which of days a reminder is due for, for a goal that is due
+// in daysLeft days. It is the closest day to the due date that daysLeft has
+// reached, so a goal only gets the latest reminder it missed. days must be
+// sorted like ParseDays returns them.
+func Offset(days []int, daysLeft int) (int, bool) {
+	for _, day := range slices.Backward(days) {
+		if day >= daysLeft {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// Goal is a goal a reminder is due for.
+type Goal struct {
+	ID   int64
+	Goal string
+	Due  time.Time
+	// DaysBefore is the reminder day the reminder is for.
+	DaysBefore int
+}
+
+// Batch holds the goals of a user that reminders are due for, so they can be
+// sent in one email.
+type Batch struct {
+	UserID int64
+	Email  string
+	Goals  []Goal
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Claim returns the reminders that are due at now, grouped by user, and
+// records them as sent. A reminder is claimed only once, even across
+// restarts, so reminders that can't be sent must be given back with Release.
+// On error, the batches claimed so far are returned as well.
+func (s *Service) Claim(ctx context.Context, now time.Time) ([]Batch, error) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := s.queries.GetAllOpenGoalsDueBetween(ctx, GetAllOpenGoalsDueBetweenParams{
+		DueFrom: today.Unix(),
+		DueTo:   today.AddDate(0, 0, MaxDays+1).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []Batch
+	for _, row := range rows {
+		days := DefaultDays
+		if row.ReminderDays.Valid {
+			days, err = ParseDays(row.ReminderDays.String)
+			if err != nil {
+				continue
+			}
+		}
+
+		due := time.Unix(row.Due.Int64, 0).UTC()
+		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+		daysLeft := int(dueDay.Sub(today).Hours() / 24)
+
+		daysBefore, ok := Offset(days, daysLeft)
+		if !ok {
+			continue
+		}
+
+		result, err := s.queries.CreateReminder(ctx, CreateReminderParams{
+			GoalID:     row.ID,
+			UserID:     row.UserID,
+			DaysBefore: int64(daysBefore),
+			Due:        row.Due.Int64,
+		})
+		if err != nil {
+			return batches, err
+		}
+
+		claimed, err := result.RowsAffected()
+		if err != nil {
+			return batches, err
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		if len(batches) == 0 || batches[len(batches)-1].UserID != row.UserID {
+			batches = append(batches, Batch{UserID: row.UserID, Email: row.Email})
+		}
+		batch := &batches[len(batches)-1]
+		batch.Goals = append(batch.Goals, Goal{
+			ID:         row.ID,
+			Goal:       row.Goal.String,
+			Due:        due,
+			DaysBefore: daysBefore,
+		})
+	}
+
+	return batches, nil
+}
+
+// Release gives back the reminders of a batch that could not be sent, so
+// they are claimed again.
+func (s *Service) Release(ctx context.Context, batch Batch) error {
+	var errs []error
+	for _, goal := range batch.Goals {
+		errs = append(errs, s.queries.DeleteReminder(ctx, DeleteReminderParams{
+			GoalID:     goal.ID,
+			DaysBefore: int64(goal.DaysBefore),
+			Due:        goal.Due.Unix(),
+		}))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package reminders
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int
+		err   error
+	}{
+		{input: "7, 1", want: []int{7, 1}},
+		{input: "1,7", want: []int{7, 1}},
+		{input: " 3 , 3, 0 ", want: []int{3, 0}},
+		{input: "90", want: []int{90}},
+		{input: "", want: nil},
+		{input: " , ", want: nil},
+		{input: "91", err: ErrInvalidDays},
+		{input: "-1", err: ErrInvalidDays},
+		{input: "7 days", err: ErrInvalidDays},
+		{input: "1, 2, 3, 4, 5, 6", err: ErrTooManyDays},
+		{input: "1, 1, 2, 3, 4, 5", want: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDays(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatDays(t *testing.T) {
+	if got := FormatDays([]int{14, 7, 1}); got != "14, 7, 1" {
+		t.Errorf("expected %q, got %q", "14, 7, 1", got)
+	}
+	if got := FormatDays(nil); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	days := []int{7, 1}
+
+	tests := []struct {
+		daysLeft int
+		want     int
+		ok       bool
+	}{
+		{daysLeft: 10},
+		{daysLeft: 8},
+		{daysLeft: 7, want: 7, ok: true},
+		{daysLeft: 3, want: 7, ok: true},
+		{daysLeft: 1, want: 1, ok: true},
+		{daysLeft: 0, want: 1, ok: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := Offset(days, tt.daysLeft)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%d days left: expected %d, %t, got %d, %t", tt.daysLeft, tt.want, tt.ok, got, ok)
+		}
+	}
+
+	if _, ok := Offset(nil, 0); ok {
+		t.Errorf("expected no reminder without days")
+	}
+}
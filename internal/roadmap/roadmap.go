@@ -0,0 +1,140 @@
+// Package roadmap lays out goals as horizontal bars on a calendar of months
+// and quarters.
+package roadmap
+
+import (
+	"fmt"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// Item is a goal placed on the roadmap. Items without a start date are
+// milestones on their end date.
+type Item struct {
+	ID     int64
+	Title  string
+	Status string
+	Start  time.Time
+	End    time.Time
+}
+
+// Bar is an item positioned on the roadmap. Offset and Width are in percent
+// of the roadmap's width.
+type Bar struct {
+	Item
+	Offset    float64
+	Width     float64
+	Milestone bool
+}
+
+// Period is a month or quarter column of the roadmap. Offset and Width are in
+// percent of the roadmap's width.
+type Period struct {
+	Label  string
+	Offset float64
+	Width  float64
+}
+
+// Roadmap holds everything needed to render the roadmap. It always covers
+// whole months, including the month of today.
+type Roadmap struct {
+	Start    time.Time
+	End      time.Time
+	Months   []Period
+	Quarters []Period
+	Bars     []Bar
+	// Today is the position of today in percent.
+	Today float64
+}
+
+// New lays out the items between the first month and the last month that
+// contain an item or today. Bars keep the order of items.
+func New(items []Item, today time.Time) Roadmap {
+	today = date(today)
+	first, last := today, today
+	for _, item := range items {
+		start, end := span(item)
+		if start.Before(first) {
+			first = start
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+
+	r := Roadmap{
+		Start: time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(last.Year(), last.Month()+1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	r.Today = r.position(today.Add(day / 2))
+
+	for month := r.Start; month.Before(r.End); month = month.AddDate(0, 1, 0) {
+		next := month.AddDate(0, 1, 0)
+		r.Months = append(r.Months, r.period(month.Format("Jan"), month, next))
+
+		// Quarters start in January, April, July and October, but the
+		// roadmap may start in the middle of one.
+		if quarterStart(month) == month || month == r.Start {
+			end := quarterStart(month).AddDate(0, 3, 0)
+			if end.After(r.End) {
+				end = r.End
+			}
+			label := fmt.Sprintf("Q%d %d", (int(month.Month())-1)/3+1, month.Year())
+			r.Quarters = append(r.Quarters, r.period(label, month, end))
+		}
+	}
+
+	for _, item := range items {
+		start, end := span(item)
+		bar := Bar{
+			Item:      item,
+			Offset:    r.position(start),
+			Milestone: item.Start.IsZero() || start.Equal(end),
+		}
+		if bar.Milestone {
+			bar.Offset = r.position(end.Add(day / 2))
+		} else {
+			// The bar ends at the end of the due day.
+			bar.Width = r.position(end.Add(day)) - bar.Offset
+		}
+		r.Bars = append(r.Bars, bar)
+	}
+
+	return r
+}
+
+func (r Roadmap) period(label string, start, end time.Time) Period {
+	offset := r.position(start)
+	return Period{
+		Label:  label,
+		Offset: offset,
+		Width:  r.position(end) - offset,
+	}
+}
+
+// position returns the position of t in percent of the roadmap's width.
+func (r Roadmap) position(t time.Time) float64 {
+	return float64(t.Sub(r.Start)) / float64(r.End.Sub(r.Start)) * 100
+}
+
+// span returns the first and last day of the item. A start after the end is
+// ignored.
+func span(item Item) (time.Time, time.Time) {
+	end := date(item.End)
+	if item.Start.IsZero() || date(item.Start).After(end) {
+		return end, end
+	}
+	return date(item.Start), end
+}
+
+func quarterStart(t time.Time) time.Time {
+	month := time.Month((int(t.Month())-1)/3*3 + 1)
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// date returns midnight UTC of the day of t.
+func date(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
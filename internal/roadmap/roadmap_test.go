@@ -0,0 +1,108 @@
+package roadmap
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func on(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
+func TestNewAcrossYearBoundary(t *testing.T) {
+	items := []Item{
+		{ID: 1, Title: "Winter training", Start: on(2026, 11, 15), End: on(2027, 2, 1)},
+		{ID: 2, Title: "Race", End: on(2027, 3, 14)},
+	}
+
+	r := New(items, on(2026, 12, 24))
+
+	if !r.Start.Equal(on(2026, 11, 1)) || !r.End.Equal(on(2027, 4, 1)) {
+		t.Fatalf("expected Nov 2026 to Mar 2027, got %v to %v", r.Start, r.End)
+	}
+
+	months := []string{"Nov", "Dec", "Jan", "Feb", "Mar"}
+	if len(r.Months) != len(months) {
+		t.Fatalf("expected %d months, got %d", len(months), len(r.Months))
+	}
+	for i, label := range months {
+		if r.Months[i].Label != label {
+			t.Errorf("month %d: expected %s, got %s", i, label, r.Months[i].Label)
+		}
+	}
+
+	quarters := []string{"Q4 2026", "Q1 2027"}
+	if len(r.Quarters) != len(quarters) {
+		t.Fatalf("expected %d quarters, got %d", len(quarters), len(r.Quarters))
+	}
+	for i, label := range quarters {
+		if r.Quarters[i].Label != label {
+			t.Errorf("quarter %d: expected %s, got %s", i, label, r.Quarters[i].Label)
+		}
+	}
+
+	// Nov 2026 to Mar 2027 has 30 + 31 + 31 + 28 + 31 = 151 days.
+	total := 151.0
+	if got := r.Quarters[0].Width; !near(got, 61/total*100) {
+		t.Errorf("expected Q4 2026 to be %.3f%% wide, got %.3f%%", 61/total*100, got)
+	}
+
+	bar := r.Bars[0]
+	if bar.Milestone {
+		t.Error("expected a span, got a milestone")
+	}
+	if !near(bar.Offset, 14/total*100) {
+		t.Errorf("expected offset %.3f%%, got %.3f%%", 14/total*100, bar.Offset)
+	}
+	// Nov 15 through Feb 1 are 16 + 31 + 31 + 1 = 79 days.
+	if !near(bar.Width, 79/total*100) {
+		t.Errorf("expected width %.3f%%, got %.3f%%", 79/total*100, bar.Width)
+	}
+
+	if !r.Bars[1].Milestone {
+		t.Error("expected a milestone for an item without a start date")
+	}
+
+	if !near(r.Today, 53.5/total*100) {
+		t.Errorf("expected today at %.3f%%, got %.3f%%", 53.5/total*100, r.Today)
+	}
+}
+
+func TestNewIncludesToday(t *testing.T) {
+	items := []Item{{ID: 1, Start: on(2027, 1, 10), End: on(2027, 1, 20)}}
+
+	r := New(items, on(2026, 10, 19))
+
+	if !r.Start.Equal(on(2026, 10, 1)) {
+		t.Errorf("expected the roadmap to start in October 2026, got %v", r.Start)
+	}
+	if len(r.Quarters) != 2 || r.Quarters[0].Label != "Q4 2026" || r.Quarters[1].Label != "Q1 2027" {
+		t.Errorf("unexpected quarters %+v", r.Quarters)
+	}
+}
+
+func TestNewStartsMidQuarter(t *testing.T) {
+	r := New(nil, on(2026, 5, 5))
+
+	if len(r.Months) != 1 || r.Months[0].Label != "May" {
+		t.Fatalf("expected only May, got %+v", r.Months)
+	}
+	if len(r.Quarters) != 1 || r.Quarters[0].Label != "Q2 2026" || !near(r.Quarters[0].Width, 100) {
+		t.Errorf("expected Q2 2026 to fill the roadmap, got %+v", r.Quarters)
+	}
+}
+
+func TestNewIgnoresStartAfterEnd(t *testing.T) {
+	items := []Item{{ID: 1, Start: on(2026, 6, 10), End: on(2026, 6, 1)}}
+
+	r := New(items, on(2026, 6, 1))
+
+	if !r.Bars[0].Milestone {
+		t.Error("expected a milestone when the start is after the end")
+	}
+}
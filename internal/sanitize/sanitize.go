@@ -2,6 +2,7 @@ package sanitize
 
 import (
 	"strings"
+	"unicode"
 )
 
 func Email(e string) string {
@@ -14,7 +15,15 @@ func Password(pw string) string {
 	return strings.TrimSpace(pw)
 }
 
+// Text trims t and drops control characters other than tabs and line breaks.
+// Search uses control characters to mark matches, so they must never be stored.
 func Text(t string) string {
+	t = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, t)
 	return strings.TrimSpace(t)
 }
 
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"html"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxResults is the number of goals a search returns at most.
+const MaxResults = 20
+
+// Highlight markers wrapped around matches by highlight() and snippet(). They
+// are control characters, which sanitize.Text drops before goals and success
+// criteria are saved.
+const (
+	markStart = "\x02"
+	markEnd   = "\x03"
+)
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Query turns what the user typed into an FTS5 query. Every word must match
+// and the last one may be the start of a word, so results show up while
+// typing. Operators and punctuation are dropped, so any input is valid. It
+// returns an empty string if there is nothing to search for.
+func Query(raw string) string {
+	words := strings.FieldsFunc(raw, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"`
+	}
+	terms[len(terms)-1] += "*"
+
+	return strings.Join(terms, " ")
+}
+
+// Search returns the user's goals matching the query in their title,
+// description or success criteria, best match first.
+func (s *Service) Search(ctx context.Context, userID int, raw string) ([]Result, error) {
+	query := Query(raw)
+	if query == "" {
+		return nil, nil
+	}
+
+	goals, err := s.queries.SearchGoals(ctx, SearchGoalsParams{
+		Query:  query,
+		UserID: int64(userID),
+		Limit:  MaxResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.queries.SearchCriteria(ctx, SearchCriteriaParams{
+		Query:  query,
+		UserID: int64(userID),
+		Limit:  MaxResults,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	byGoal := map[int64]int{}
+	for _, g := range goals {
+		result := Result{
+			GoalID:   g.ID,
+			Goal:     Highlight(g.Goal),
+			Status:   g.Status,
+			Archived: g.ArchivedAt.Valid,
+			Rank:     g.Rank,
+		}
+		if g.Due.Valid {
+			result.Due = time.Unix(g.Due.Int64, 0)
+		}
+		if strings.Contains(g.Snippet, markStart) {
+			result.Snippets = append(result.Snippets, Highlight(g.Snippet))
+		}
+
+		byGoal[g.ID] = len(results)
+		results = append(results, result)
+	}
+
+	for _, c := range criteria {
+		i, ok := byGoal[c.GoalID]
+		if !ok {
+			i = len(results)
+			byGoal[c.GoalID] = i
+			results = append(results, Result{
+				GoalID: c.GoalID,
+				Goal:   template.HTML(html.EscapeString(c.Goal.String)),
+				Rank:   c.Rank,
+			})
+		}
+
+		result := &results[i]
+		result.Criteria = append(result.Criteria, Highlight(c.Snippet))
+		result.Rank = min(result.Rank, c.Rank)
+	}
+
+	// bm25 ranks are negative; the lower, the better the match.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank < results[j].Rank
+	})
+
+	if len(results) > MaxResults {
+		results = results[:MaxResults]
+	}
+
+	return results, nil
+}
+
+// Highlight escapes text from the search index and wraps matches in <mark>.
+func Highlight(s string) template.HTML {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, markStart, "<mark>")
+	s = strings.ReplaceAll(s, markEnd, "</mark>")
+	return template.HTML(s)
+}
@@ -0,0 +1,34 @@
+package search
+
+import "testing"
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{name: "empty", raw: "   ", want: ""},
+		{name: "single word", raw: "marathon", want: `"marathon"*`},
+		{name: "several words", raw: "run a marat", want: `"run" "a" "marat"*`},
+		{name: "operators are dropped", raw: `run OR "walk" -bike NEAR(x)`, want: `"run" "OR" "walk" "bike" "NEAR" "x"*`},
+		{name: "punctuation only", raw: `"*()^:`, want: ""},
+		{name: "unicode", raw: "Größe café", want: `"Größe" "café"*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Query(tt.raw); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	got := Highlight("Run a \x02<b>marathon</b>\x03 & more")
+	want := "Run a <mark>&lt;b&gt;marathon&lt;/b&gt;</mark> &amp; more"
+	if string(got) != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
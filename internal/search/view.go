@@ -0,0 +1,21 @@
+package search
+
+import (
+	"html/template"
+	"time"
+)
+
+// Result is a goal that matched a search. Goal and the snippets are escaped
+// HTML with matches wrapped in <mark>.
+type Result struct {
+	GoalID   int64
+	Goal     template.HTML
+	Due      time.Time
+	Status   string
+	Archived bool
+	// Snippets holds the matching parts of the description.
+	Snippets []template.HTML
+	// Criteria holds the matching success criteria.
+	Criteria []template.HTML
+	Rank     float64
+}
@@ -5,7 +5,8 @@
 package share
 
 type Share struct {
-	ID       int64
-	UserID   int64
-	PublicID string
+	ID              int64
+	UserID          int64
+	PublicID        string
+	IncludeArchived int64
 }
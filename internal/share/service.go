@@ -80,12 +80,40 @@ func (s *Service) GetAll(ctx context.Context, userID int) ([]Share, error) {
 	return shares, nil
 }
 
-func (s *Service) GetUserIDByPublicID(ctx context.Context, publicID string) (int, error) {
-	userID, err := s.queries.GetUserIDByPublicID(ctx, publicID)
+func (s *Service) GetByPublicID(ctx context.Context, publicID string) (Share, error) {
+	share, err := s.queries.GetByPublicID(ctx, publicID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get user ID by public ID: %w", err)
+		return Share{}, fmt.Errorf("failed to get share by public ID: %w", err)
 	}
-	return int(userID), nil
+	return share, nil
+}
+
+// SetIncludeArchived sets whether the share link shows archived goals.
+func (s *Service) SetIncludeArchived(ctx context.Context, id, userID int, include bool) error {
+	includeArchived := int64(0)
+	if include {
+		includeArchived = 1
+	}
+
+	result, err := s.queries.SetIncludeArchived(ctx, SetIncludeArchivedParams{
+		IncludeArchived: includeArchived,
+		ID:              int64(id),
+		UserID:          int64(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update share: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("share not found")
+	}
+
+	return nil
 }
 
 // generatePublicID generates a crypto random string. On error, it returns an emtry string.
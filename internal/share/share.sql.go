@@ -44,7 +44,7 @@ func (q *Queries) Delete(ctx context.Context, id int64) (sql.Result, error) {
 }
 
 const getAll = `-- name: GetAll :many
-SELECT id, user_id, public_id
+SELECT id, user_id, public_id, include_archived
 FROM share
 WHERE user_id = ?
 `
@@ -58,7 +58,12 @@ func (q *Queries) GetAll(ctx context.Context, userID int64) ([]Share, error) {
 	var items []Share
 	for rows.Next() {
 		var i Share
-		if err := rows.Scan(&i.ID, &i.UserID, &i.PublicID); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PublicID,
+			&i.IncludeArchived,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -72,13 +77,36 @@ func (q *Queries) GetAll(ctx context.Context, userID int64) ([]Share, error) {
 	return items, nil
 }
 
-const getUserIDByPublicID = `-- name: GetUserIDByPublicID :one
-SELECT user_id FROM share WHERE public_id = ?
+const getByPublicID = `-- name: GetByPublicID :one
+SELECT id, user_id, public_id, include_archived
+FROM share
+WHERE public_id = ?
 `
 
-func (q *Queries) GetUserIDByPublicID(ctx context.Context, publicID string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getUserIDByPublicID, publicID)
-	var user_id int64
-	err := row.Scan(&user_id)
-	return user_id, err
+func (q *Queries) GetByPublicID(ctx context.Context, publicID string) (Share, error) {
+	row := q.db.QueryRowContext(ctx, getByPublicID, publicID)
+	var i Share
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PublicID,
+		&i.IncludeArchived,
+	)
+	return i, err
+}
+
+const setIncludeArchived = `-- name: SetIncludeArchived :execresult
+UPDATE share
+SET include_archived = ?
+WHERE id = ? AND user_id = ?
+`
+
+type SetIncludeArchivedParams struct {
+	IncludeArchived int64
+	ID              int64
+	UserID          int64
+}
+
+func (q *Queries) SetIncludeArchived(ctx context.Context, arg SetIncludeArchivedParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, setIncludeArchived, arg.IncludeArchived, arg.ID, arg.UserID)
 }
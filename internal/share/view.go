@@ -1,9 +1,10 @@
 package share
 
 type View struct {
-	ID       int64
-	UserID   int64
-	PublicID string
+	ID              int64
+	UserID          int64
+	PublicID        string
+	IncludeArchived bool
 }
 
 func (s *Share) ToView() View {
@@ -11,6 +12,8 @@ func (s *Share) ToView() View {
 		ID:       s.ID,
 		PublicID: s.PublicID,
 		UserID:   s.UserID,
+		// Archived goals are only shown when the link opts in.
+		IncludeArchived: s.IncludeArchived == 1,
 	}
 
 	return v
@@ -0,0 +1,67 @@
+// Package signing signs values that are handed out to browsers, like the
+// cookie that tells share page viewers apart, so they can't be forged.
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+)
+
+// KeySize is the size of the keys created by LoadKey.
+const KeySize = 32
+
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign returns value followed by a dot and its signature.
+func (s *Signer) Sign(value string) string {
+	return value + "." + base64.RawURLEncoding.EncodeToString(s.mac(value))
+}
+
+// Verify returns the value of a string created by Sign and whether its
+// signature is valid.
+func (s *Signer) Verify(signed string) (string, bool) {
+	value, signature, ok := strings.Cut(signed, ".")
+	if !ok || value == "" {
+		return "", false
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(value)) {
+		return "", false
+	}
+
+	return value, true
+}
+
+func (s *Signer) mac(value string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
+
+// LoadKey returns the key with the given name. The key is created on first
+// use and kept in the database, so signatures stay valid across restarts.
+func LoadKey(ctx context.Context, db *sql.DB, name string) ([]byte, error) {
+	queries := New(db)
+
+	key := make([]byte, KeySize)
+	rand.Read(key)
+
+	// Keeps the existing key if there is one.
+	if err := queries.CreateSigningKey(ctx, CreateSigningKeyParams{Name: name, Key: key}); err != nil {
+		return nil, err
+	}
+
+	return queries.GetSigningKey(ctx, name)
+}
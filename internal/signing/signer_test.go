@@ -0,0 +1,48 @@
+package signing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignerVerify(t *testing.T) {
+	s := NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	signed := s.Sign("viewer")
+	value, signature, _ := strings.Cut(signed, ".")
+
+	tests := []struct {
+		name   string
+		signed string
+		want   string
+		valid  bool
+	}{
+		{name: "signed", signed: signed, want: "viewer", valid: true},
+		{name: "other value", signed: "intruder." + signature, valid: false},
+		{name: "other signature", signed: value + ".AAAA", valid: false},
+		{name: "no signature", signed: value, valid: false},
+		{name: "no value", signed: "." + signature, valid: false},
+		{name: "not base64", signed: value + ".!!", valid: false},
+		{name: "other key", signed: NewSigner([]byte("other")).Sign("viewer"), valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Verify(tt.signed)
+			if ok != tt.valid {
+				t.Errorf("expected valid %t, got %t", tt.valid, ok)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	s := NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	signed := s.Sign("BRCZVKL5ZBS6YHPOTNBIUMZXNS")
+
+	for b.Loop() {
+		s.Verify(signed)
+	}
+}
@@ -0,0 +1,271 @@
+// Package subscriptions lets viewers of a share link subscribe to updates of
+// its timeline by email: public goals that are added, achieved or
+// rescheduled. Subscribers confirm their email address before they get any
+// update, and subscriptions end with the share link.
+package subscriptions
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+const (
+	// unconfirmedDays is how long subscriptions wait for confirmation.
+	unconfirmedDays = 7
+	// updateDays is how long updates are kept for subscribers who could not
+	// be notified yet.
+	updateDays = 30
+)
+
+// Kind is what happened to a goal.
+type Kind string
+
+const (
+	Added       Kind = "added"
+	Achieved    Kind = "achieved"
+	Rescheduled Kind = "rescheduled"
+)
+
+type Form struct {
+	Email               string `form:"email"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(validator.NotBlank(f.Email), "email", "Email cannot be blank")
+	f.Check(validator.MaxChars(f.Email, 254), "email", "Email cannot be more than 254 characters")
+	f.Check(validator.Matches(f.Email, validator.EmailRX), "email", "This field must be a valid email address")
+}
+
+// Subscription is the subscription of an email address to a share link.
+type Subscription struct {
+	ID      int64
+	ShareID int64
+	Email   string
+	// Token is the secret in the confirm and unsubscribe links.
+	Token     string
+	Confirmed bool
+}
+
+// Update is a change to a goal on a shared timeline.
+type Update struct {
+	GoalID int64
+	Goal   string
+	Kind   Kind
+	Due    time.Time
+	// PreviousDue is the due date of rescheduled goals before.
+	PreviousDue time.Time
+}
+
+// dateFormat is how due dates are written in updates.
+const dateFormat = "Jan 2, 2006"
+
+// Label describes what happened to the goal, like "Moved from Jan 2, 2026 to
+// Feb 1, 2026".
+func (u Update) Label() string {
+	switch u.Kind {
+	case Achieved:
+		return "Achieved"
+	case Rescheduled:
+		if u.PreviousDue.IsZero() {
+			return "Now due " + u.Due.Format(dateFormat)
+		}
+		if u.Due.IsZero() {
+			return "No longer due " + u.PreviousDue.Format(dateFormat)
+		}
+		return "Moved from " + u.PreviousDue.Format(dateFormat) + " to " + u.Due.Format(dateFormat)
+	default:
+		if u.Due.IsZero() {
+			return "New goal"
+		}
+		return "New goal, due " + u.Due.Format(dateFormat)
+	}
+}
+
+// Batch is the updates a subscriber is notified of in one email.
+type Batch struct {
+	SubscriptionID int64
+	Email          string
+	Token          string
+	// UserID is the owner of the shared timeline.
+	UserID   int64
+	PublicID string
+	Updates  []Update
+
+	fromID int64
+	toID   int64
+}
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// Subscribe subscribes the email of form to a share link. Subscribing again
+// returns the existing subscription, so its confirmation can be sent again.
+func (s *Service) Subscribe(ctx context.Context, shareID int64, form *Form) (Subscription, error) {
+	email := strings.ToLower(strings.TrimSpace(form.Email))
+
+	_, err := s.queries.CreateSubscription(ctx, CreateSubscriptionParams{
+		ShareID: shareID,
+		Email:   email,
+		Token:   rand.Text(),
+	})
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub, err := s.queries.GetSubscriptionByEmail(ctx, GetSubscriptionByEmailParams{
+		ShareID: shareID,
+		Email:   email,
+	})
+	if err != nil {
+		return Subscription{}, err
+	}
+	return sub.toSubscription(), nil
+}
+
+// GetByToken returns the subscription to a share link with the token of its
+// links.
+func (s *Service) GetByToken(ctx context.Context, shareID int64, token string) (Subscription, error) {
+	sub, err := s.queries.GetSubscriptionByToken(ctx, GetSubscriptionByTokenParams{
+		ShareID: shareID,
+		Token:   token,
+	})
+	if err != nil {
+		return Subscription{}, err
+	}
+	return sub.toSubscription(), nil
+}
+
+// Confirm starts the updates of a subscription. Confirming twice does
+// nothing.
+func (s *Service) Confirm(ctx context.Context, id int64, now time.Time) error {
+	_, err := s.queries.ConfirmSubscription(ctx, ConfirmSubscriptionParams{
+		ConfirmedAt: now.Unix(),
+		ID:          id,
+	})
+	return err
+}
+
+func (s *Service) Unsubscribe(ctx context.Context, id int64) error {
+	return s.queries.DeleteSubscription(ctx, id)
+}
+
+// Prune deletes subscriptions that were not confirmed in time and updates
+// that are too old to be worth a notification.
+func (s *Service) Prune(ctx context.Context, now time.Time) error {
+	return errors.Join(
+		s.queries.DeleteUnconfirmedSubscriptionsBefore(ctx, now.AddDate(0, 0, -unconfirmedDays).Unix()),
+		s.queries.DeleteUpdatesBefore(ctx, now.AddDate(0, 0, -updateDays).Unix()),
+	)
+}
+
+// Claim returns the updates every confirmed subscriber was not notified of
+// yet, and records them as notified. Updates are claimed only once, even
+// across restarts, so batches that can't be sent must be given back with
+// Release. On error, the batches claimed so far are returned as well.
+func (s *Service) Claim(ctx context.Context) ([]Batch, error) {
+	rows, err := s.queries.GetAllNotifiableSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []Batch
+	for _, row := range rows {
+		// Updates recorded while claiming are left for the next time.
+		toID, err := s.queries.GetLastUpdateID(ctx, row.UserID)
+		if err != nil {
+			return batches, err
+		}
+
+		updates, err := s.queries.GetAllUpdatesBetween(ctx, GetAllUpdatesBetweenParams{
+			UserID:          row.UserID,
+			AfterID:         row.LastUpdateID,
+			UntilID:         toID,
+			IncludeArchived: row.IncludeArchived,
+		})
+		if err != nil {
+			return batches, err
+		}
+
+		result, err := s.queries.SetLastUpdateID(ctx, SetLastUpdateIDParams{
+			ToID:   toID,
+			ID:     row.ID,
+			FromID: row.LastUpdateID,
+		})
+		if err != nil {
+			return batches, err
+		}
+
+		claimed, err := result.RowsAffected()
+		if err != nil {
+			return batches, err
+		}
+		// Goals may have left the timeline since, then there is nothing to
+		// tell.
+		if claimed == 0 || len(updates) == 0 {
+			continue
+		}
+
+		batch := Batch{
+			SubscriptionID: row.ID,
+			Email:          row.Email,
+			Token:          row.Token,
+			UserID:         row.UserID,
+			PublicID:       row.PublicID,
+			fromID:         row.LastUpdateID,
+			toID:           toID,
+		}
+		for _, u := range updates {
+			batch.Updates = append(batch.Updates, Update{
+				GoalID:      u.GoalID,
+				Goal:        u.Goal.String,
+				Kind:        Kind(u.Kind),
+				Due:         unixDate(u.Due),
+				PreviousDue: unixDate(u.PreviousDue),
+			})
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// Release gives back the updates of a batch, so they are claimed again.
+func (s *Service) Release(ctx context.Context, batch Batch) error {
+	_, err := s.queries.SetLastUpdateID(ctx, SetLastUpdateIDParams{
+		ToID:   batch.fromID,
+		ID:     batch.SubscriptionID,
+		FromID: batch.toID,
+	})
+	return err
+}
+
+func (s ShareSubscription) toSubscription() Subscription {
+	return Subscription{
+		ID:        s.ID,
+		ShareID:   s.ShareID,
+		Email:     s.Email,
+		Token:     s.Token,
+		Confirmed: s.ConfirmedAt.Valid,
+	}
+}
+
+func unixDate(v sql.NullInt64) time.Time {
+	if !v.Valid {
+		return time.Time{}
+	}
+	return time.Unix(v.Int64, 0).UTC()
+}
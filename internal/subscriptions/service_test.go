@@ -0,0 +1,55 @@
+package subscriptions
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  Form
+		valid bool
+	}{
+		{name: "email", form: Form{Email: "fan@example.com"}, valid: true},
+		{name: "blank", form: Form{}, valid: false},
+		{name: "no domain", form: Form{Email: "fan@"}, valid: false},
+		{name: "long", form: Form{Email: strings.Repeat("a", 250) + "@example.com"}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			if got := tt.form.Valid(); got != tt.valid {
+				t.Errorf("Valid() = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestUpdateLabel(t *testing.T) {
+	jan := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		update Update
+		want   string
+	}{
+		{"added", Update{Kind: Added, Due: jan}, "New goal, due Jan 2, 2026"},
+		{"added without due date", Update{Kind: Added}, "New goal"},
+		{"achieved", Update{Kind: Achieved, Due: jan}, "Achieved"},
+		{"rescheduled", Update{Kind: Rescheduled, Due: feb, PreviousDue: jan}, "Moved from Jan 2, 2026 to Feb 1, 2026"},
+		{"due date set", Update{Kind: Rescheduled, Due: feb}, "Now due Feb 1, 2026"},
+		{"due date removed", Update{Kind: Rescheduled, PreviousDue: jan}, "No longer due Jan 2, 2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.update.Label(); got != tt.want {
+				t.Errorf("Label() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
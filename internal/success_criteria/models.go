@@ -16,4 +16,6 @@ type SuccessCriterium struct {
 	Completed   sql.NullInt64
 	Position    sql.NullInt64
 	CreatedAt   int64
+	DeletedAt   sql.NullInt64
+	CompletedAt sql.NullInt64
 }
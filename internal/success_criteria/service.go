@@ -32,6 +32,14 @@ func NewService(db *sql.DB) *Service {
 	}
 }
 
+// WithTx returns a Service that runs all queries inside tx. The caller is
+// responsible for committing or rolling back the transaction.
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	return &Service{
+		queries: s.queries.WithTx(tx),
+	}
+}
+
 func (s *Service) Add(ctx context.Context, goalID, userID int, form *Form) error {
 	completed := int64(0)
 	if form.Completed {
@@ -60,6 +68,26 @@ func (s *Service) Add(ctx context.Context, goalID, userID int, form *Form) error
 	return err
 }
 
+// CopyToGoal copies all success criteria of one goal to another goal of the
+// same user. The copies start out as not completed.
+func (s *Service) CopyToGoal(ctx context.Context, fromGoalID, toGoalID, userID int) error {
+	criteria, err := s.GetAllByGoal(ctx, fromGoalID, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range criteria {
+		if err := s.Add(ctx, toGoalID, userID, &Form{
+			Description: c.Description,
+			Position:    int(c.Position.Int64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) GetAllByGoal(ctx context.Context, goalID, userID int) ([]SuccessCriterium, error) {
 	criteria, err := s.queries.GetAllSuccessCriteriaByGoal(ctx, GetAllSuccessCriteriaByGoalParams{
 		GoalID: int64(goalID),
@@ -72,6 +100,29 @@ func (s *Service) GetAllByGoal(ctx context.Context, goalID, userID int) ([]Succe
 	return criteria, nil
 }
 
+// Counts holds how many success criteria a goal has and how many of them are
+// completed.
+type Counts struct {
+	Total     int
+	Completed int
+}
+
+// GetCountsByUser returns the success criteria counts of all goals of a user,
+// by goal ID.
+func (s *Service) GetCountsByUser(ctx context.Context, userID int) (map[int64]Counts, error) {
+	rows, err := s.queries.GetSuccessCriteriaCountsByUser(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[int64]Counts, len(rows))
+	for _, row := range rows {
+		byGoal[row.GoalID] = Counts{Total: int(row.Total), Completed: int(row.Completed)}
+	}
+
+	return byGoal, nil
+}
+
 func (s *Service) Get(ctx context.Context, criteriaID, userID int) (SuccessCriterium, error) {
 	criteria, err := s.queries.GetSuccessCriteria(ctx, GetSuccessCriteriaParams{
 		ID:     int64(criteriaID),
@@ -137,6 +188,73 @@ func (s *Service) Toggle(ctx context.Context, criteriaID, userID int) (int, erro
 	return int(rowsAffected), nil
 }
 
+// Trash moves a success criterion to the trash.
+func (s *Service) Trash(ctx context.Context, criteriaID, userID int) (int, error) {
+	result, err := s.queries.TrashSuccessCriteria(ctx, TrashSuccessCriteriaParams{
+		ID:     int64(criteriaID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Restore moves a success criterion out of the trash.
+func (s *Service) Restore(ctx context.Context, criteriaID, userID int) (int, error) {
+	result, err := s.queries.RestoreSuccessCriteria(ctx, RestoreSuccessCriteriaParams{
+		ID:     int64(criteriaID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetAllTrashed returns the success criteria in the trash, most recently
+// deleted first.
+func (s *Service) GetAllTrashed(ctx context.Context, userID int) ([]SuccessCriterium, error) {
+	criteria, err := s.queries.GetAllTrashedSuccessCriteria(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return criteria, nil
+}
+
+// PurgeTrash permanently deletes all success criteria of all users that were
+// moved to the trash before the given time.
+func (s *Service) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.queries.PurgeTrashedSuccessCriteria(ctx, sql.NullInt64{
+		Int64: before.Unix(),
+		Valid: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Delete permanently deletes a success criterion from the trash.
 func (s *Service) Delete(ctx context.Context, criteriaID, userID int) (int, error) {
 	result, err := s.queries.DeleteSuccessCriteria(ctx, DeleteSuccessCriteriaParams{
 		ID:     int64(criteriaID),
@@ -13,7 +13,7 @@ import (
 const createSuccessCriteria = `-- name: CreateSuccessCriteria :one
 INSERT INTO success_criteria (goal_id, user_id, description, completed, position, created_at)
 VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, goal_id, user_id, description, completed, position, created_at
+RETURNING id, goal_id, user_id, description, completed, position, created_at, deleted_at, completed_at
 `
 
 type CreateSuccessCriteriaParams struct {
@@ -43,6 +43,8 @@ func (q *Queries) CreateSuccessCriteria(ctx context.Context, arg CreateSuccessCr
 		&i.Completed,
 		&i.Position,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
 	)
 	return i, err
 }
@@ -63,7 +65,7 @@ func (q *Queries) DeleteAllSuccessCriteriaByGoal(ctx context.Context, arg Delete
 
 const deleteSuccessCriteria = `-- name: DeleteSuccessCriteria :execresult
 DELETE FROM success_criteria
-WHERE id = ? AND user_id = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
 `
 
 type DeleteSuccessCriteriaParams struct {
@@ -76,8 +78,8 @@ func (q *Queries) DeleteSuccessCriteria(ctx context.Context, arg DeleteSuccessCr
 }
 
 const getAllSuccessCriteriaByGoal = `-- name: GetAllSuccessCriteriaByGoal :many
-SELECT id, goal_id, user_id, description, completed, position, created_at FROM success_criteria
-WHERE goal_id = ? AND user_id = ?
+SELECT id, goal_id, user_id, description, completed, position, created_at, deleted_at, completed_at FROM success_criteria
+WHERE goal_id = ? AND user_id = ? AND deleted_at IS NULL
 ORDER BY position ASC, created_at ASC
 `
 
@@ -103,6 +105,47 @@ func (q *Queries) GetAllSuccessCriteriaByGoal(ctx context.Context, arg GetAllSuc
 			&i.Completed,
 			&i.Position,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTrashedSuccessCriteria = `-- name: GetAllTrashedSuccessCriteria :many
+SELECT id, goal_id, user_id, description, completed, position, created_at, deleted_at, completed_at FROM success_criteria
+WHERE user_id = ? AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetAllTrashedSuccessCriteria(ctx context.Context, userID int64) ([]SuccessCriterium, error) {
+	rows, err := q.db.QueryContext(ctx, getAllTrashedSuccessCriteria, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SuccessCriterium
+	for rows.Next() {
+		var i SuccessCriterium
+		if err := rows.Scan(
+			&i.ID,
+			&i.GoalID,
+			&i.UserID,
+			&i.Description,
+			&i.Completed,
+			&i.Position,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -118,8 +161,8 @@ func (q *Queries) GetAllSuccessCriteriaByGoal(ctx context.Context, arg GetAllSuc
 }
 
 const getSuccessCriteria = `-- name: GetSuccessCriteria :one
-SELECT id, goal_id, user_id, description, completed, position, created_at FROM success_criteria
-WHERE id = ? AND user_id = ?
+SELECT id, goal_id, user_id, description, completed, position, created_at, deleted_at, completed_at FROM success_criteria
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
 `
 
 type GetSuccessCriteriaParams struct {
@@ -138,10 +181,75 @@ func (q *Queries) GetSuccessCriteria(ctx context.Context, arg GetSuccessCriteria
 		&i.Completed,
 		&i.Position,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
 	)
 	return i, err
 }
 
+const getSuccessCriteriaCountsByUser = `-- name: GetSuccessCriteriaCountsByUser :many
+SELECT
+    goal_id,
+    COUNT(*) AS total,
+    CAST(SUM(completed = 1) AS INTEGER) AS completed
+FROM success_criteria
+WHERE user_id = ? AND deleted_at IS NULL
+GROUP BY goal_id
+`
+
+type GetSuccessCriteriaCountsByUserRow struct {
+	GoalID    int64
+	Total     int64
+	Completed int64
+}
+
+func (q *Queries) GetSuccessCriteriaCountsByUser(ctx context.Context, userID int64) ([]GetSuccessCriteriaCountsByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSuccessCriteriaCountsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSuccessCriteriaCountsByUserRow
+	for rows.Next() {
+		var i GetSuccessCriteriaCountsByUserRow
+		if err := rows.Scan(&i.GoalID, &i.Total, &i.Completed); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeTrashedSuccessCriteria = `-- name: PurgeTrashedSuccessCriteria :execresult
+DELETE FROM success_criteria
+WHERE deleted_at IS NOT NULL AND deleted_at < ?
+`
+
+func (q *Queries) PurgeTrashedSuccessCriteria(ctx context.Context, deletedAt sql.NullInt64) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeTrashedSuccessCriteria, deletedAt)
+}
+
+const restoreSuccessCriteria = `-- name: RestoreSuccessCriteria :execresult
+UPDATE success_criteria
+SET deleted_at = NULL
+WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL
+`
+
+type RestoreSuccessCriteriaParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) RestoreSuccessCriteria(ctx context.Context, arg RestoreSuccessCriteriaParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, restoreSuccessCriteria, arg.ID, arg.UserID)
+}
+
 const toggleSuccessCriteriaCompleted = `-- name: ToggleSuccessCriteriaCompleted :execresult
 UPDATE success_criteria
 SET completed = CASE WHEN completed = 0 THEN 1 ELSE 0 END
@@ -157,6 +265,21 @@ func (q *Queries) ToggleSuccessCriteriaCompleted(ctx context.Context, arg Toggle
 	return q.db.ExecContext(ctx, toggleSuccessCriteriaCompleted, arg.ID, arg.UserID)
 }
 
+const trashSuccessCriteria = `-- name: TrashSuccessCriteria :execresult
+UPDATE success_criteria
+SET deleted_at = unixepoch()
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type TrashSuccessCriteriaParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) TrashSuccessCriteria(ctx context.Context, arg TrashSuccessCriteriaParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, trashSuccessCriteria, arg.ID, arg.UserID)
+}
+
 const updateSuccessCriteria = `-- name: UpdateSuccessCriteria :execresult
 UPDATE success_criteria
 SET description = ?, completed = ?, position = ?
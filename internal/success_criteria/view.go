@@ -10,6 +10,7 @@ type View struct {
 	Completed   bool
 	Position    int
 	CreatedAt   time.Time
+	DeletedAt   time.Time
 }
 
 func (s SuccessCriterium) ToView() View {
@@ -23,6 +24,11 @@ func (s SuccessCriterium) ToView() View {
 		position = int(s.Position.Int64)
 	}
 
+	deletedAt := time.Time{}
+	if s.DeletedAt.Valid {
+		deletedAt = time.Unix(s.DeletedAt.Int64, 0)
+	}
+
 	return View{
 		ID:          int(s.ID),
 		GoalID:      int(s.GoalID),
@@ -31,5 +37,6 @@ func (s SuccessCriterium) ToView() View {
 		Completed:   completed,
 		Position:    position,
 		CreatedAt:   time.Unix(s.CreatedAt, 0),
+		DeletedAt:   deletedAt,
 	}
 }
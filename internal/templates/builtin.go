@@ -0,0 +1,62 @@
+package templates
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+//go:embed builtin/*.json
+var builtinFiles embed.FS
+
+// builtIns are parsed once at startup. An invalid built-in template is a bug,
+// so it panics.
+var builtIns = mustLoadBuiltIns(builtinFiles)
+
+// BuiltIns returns the templates that ship with the binary, sorted by name.
+func BuiltIns() []Template {
+	return slices.Clone(builtIns)
+}
+
+func mustLoadBuiltIns(fsys fs.FS) []Template {
+	templates, err := loadBuiltIns(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+func loadBuiltIns(fsys fs.FS) ([]Template, error) {
+	names, err := fs.Glob(fsys, "builtin/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var t Template
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, err
+		}
+		t.Slug = strings.TrimSuffix(path.Base(name), ".json")
+
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+
+	slices.SortFunc(templates, func(a, b Template) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return templates, nil
+}
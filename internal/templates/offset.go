@@ -0,0 +1,99 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOffset is returned for offsets that can't be parsed.
+var ErrInvalidOffset = errors.New("invalid offset")
+
+// Unit is the unit of an Offset.
+type Unit string
+
+const (
+	Days   Unit = "day"
+	Weeks  Unit = "week"
+	Months Unit = "month"
+	Years  Unit = "year"
+)
+
+// MaxOffset is the largest number of units an offset may have.
+const MaxOffset = 3650
+
+// Offset is a due date relative to the start date a template is used with,
+// e.g. "+30 days".
+type Offset struct {
+	N    int
+	Unit Unit
+}
+
+var offsetRX = regexp.MustCompile(`^([+-]?)\s*(\d+)\s*([a-z]+)$`)
+
+// ParseOffset parses offsets like "+30 days", "+2 weeks", "-1 month" or the
+// short forms "30d", "2w", "3m" and "1y". The sign is optional.
+func ParseOffset(s string) (Offset, error) {
+	match := offsetRX.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if match == nil {
+		return Offset{}, fmt.Errorf("%w: %q", ErrInvalidOffset, s)
+	}
+
+	n, err := strconv.Atoi(match[2])
+	if err != nil || n > MaxOffset {
+		return Offset{}, fmt.Errorf("%w: %q", ErrInvalidOffset, s)
+	}
+	if match[1] == "-" {
+		n = -n
+	}
+
+	var unit Unit
+	switch match[3] {
+	case "d", "day", "days":
+		unit = Days
+	case "w", "week", "weeks":
+		unit = Weeks
+	case "m", "month", "months":
+		unit = Months
+	case "y", "year", "years":
+		unit = Years
+	default:
+		return Offset{}, fmt.Errorf("%w: %q", ErrInvalidOffset, s)
+	}
+
+	return Offset{N: n, Unit: unit}, nil
+}
+
+// String returns the offset in its long form, e.g. "+1 week".
+func (o Offset) String() string {
+	sign := "+"
+	n := o.N
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+
+	if n == 1 {
+		return fmt.Sprintf("%s1 %s", sign, o.Unit)
+	}
+	return fmt.Sprintf("%s%d %ss", sign, n, o.Unit)
+}
+
+// Apply returns the date the offset points to from start. Months and years
+// are calendar months and years, so "+1 month" from January 31 is March 3 or
+// March 2, like time.AddDate.
+func (o Offset) Apply(start time.Time) time.Time {
+	switch o.Unit {
+	case Weeks:
+		return start.AddDate(0, 0, 7*o.N)
+	case Months:
+		return start.AddDate(0, o.N, 0)
+	case Years:
+		return start.AddDate(o.N, 0, 0)
+	default:
+		return start.AddDate(0, 0, o.N)
+	}
+}
@@ -0,0 +1,106 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+)
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Offset
+		err  bool
+	}{
+		{in: "+30 days", want: Offset{N: 30, Unit: Days}},
+		{in: "+1 day", want: Offset{N: 1, Unit: Days}},
+		{in: "2 weeks", want: Offset{N: 2, Unit: Weeks}},
+		{in: "-1 month", want: Offset{N: -1, Unit: Months}},
+		{in: " +3 Months ", want: Offset{N: 3, Unit: Months}},
+		{in: "+1 year", want: Offset{N: 1, Unit: Years}},
+		{in: "0 days", want: Offset{N: 0, Unit: Days}},
+		{in: "30d", want: Offset{N: 30, Unit: Days}},
+		{in: "+2w", want: Offset{N: 2, Unit: Weeks}},
+		{in: "3m", want: Offset{N: 3, Unit: Months}},
+		{in: "-1y", want: Offset{N: -1, Unit: Years}},
+		{in: "", err: true},
+		{in: "+30", err: true},
+		{in: "days", err: true},
+		{in: "+1.5 days", err: true},
+		{in: "+30 fortnights", err: true},
+		{in: "+3651 days", err: true},
+		{in: "+99999999999999999999 days", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOffset(tt.in)
+			if tt.err {
+				if !errors.Is(err, ErrInvalidOffset) {
+					t.Fatalf("expected ErrInvalidOffset, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOffsetString(t *testing.T) {
+	tests := []struct {
+		offset Offset
+		want   string
+	}{
+		{offset: Offset{N: 30, Unit: Days}, want: "+30 days"},
+		{offset: Offset{N: 1, Unit: Weeks}, want: "+1 week"},
+		{offset: Offset{N: -2, Unit: Months}, want: "-2 months"},
+		{offset: Offset{N: 0, Unit: Days}, want: "+0 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.offset.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+
+			parsed, err := ParseOffset(tt.want)
+			if err != nil || parsed != tt.offset {
+				t.Errorf("expected %q to parse back to %+v, got %+v (%v)", tt.want, tt.offset, parsed, err)
+			}
+		})
+	}
+}
+
+func TestOffsetApply(t *testing.T) {
+	start := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		offset string
+		want   string
+	}{
+		{offset: "+30 days", want: "2026-12-02"},
+		{offset: "-2 days", want: "2026-10-31"},
+		{offset: "+2 weeks", want: "2026-11-16"},
+		{offset: "+3 months", want: "2027-02-02"},
+		{offset: "+1 year", want: "2027-11-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.offset, func(t *testing.T) {
+			offset, err := ParseOffset(tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := offset.Apply(start).Format(goals.HTMLDateFormat); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
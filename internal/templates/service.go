@@ -0,0 +1,105 @@
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+type Service struct {
+	queries *Queries
+}
+
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		queries: New(db),
+	}
+}
+
+// GetAll returns the built-in templates followed by the templates the user
+// saved.
+func (s *Service) GetAll(ctx context.Context, userID int) ([]Template, error) {
+	rows, err := s.queries.GetAll(ctx, int64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := BuiltIns()
+	for _, row := range rows {
+		t, err := row.toTemplate()
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+// Get returns the template with the given key, see Template.Key. It returns
+// sql.ErrNoRows if there is no such template for the user.
+func (s *Service) Get(ctx context.Context, key string, userID int) (Template, error) {
+	for _, t := range builtIns {
+		if t.Slug == key {
+			return t, nil
+		}
+	}
+
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return Template{}, sql.ErrNoRows
+	}
+
+	row, err := s.queries.Get(ctx, GetParams{
+		ID:     id,
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return Template{}, err
+	}
+
+	return row.toTemplate()
+}
+
+// Save stores the template for the user and returns its ID.
+func (s *Service) Save(ctx context.Context, userID int, t Template) (int, error) {
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	goals, err := t.marshal()
+	if err != nil {
+		return 0, err
+	}
+
+	row, err := s.queries.Create(ctx, CreateParams{
+		UserID:      int64(userID),
+		Name:        t.Name,
+		Description: t.Description,
+		Goals:       goals,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(row.ID), nil
+}
+
+// Delete deletes a template the user saved. Built-in templates can't be
+// deleted.
+func (s *Service) Delete(ctx context.Context, templateID, userID int) (int, error) {
+	result, err := s.queries.Delete(ctx, DeleteParams{
+		ID:     int64(templateID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
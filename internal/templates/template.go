@@ -0,0 +1,242 @@
+// Package templates holds reusable sets of goals. Goals in a template are due
+// relative to a start date, so a template can be used again and again, e.g.
+// for every new hire.
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// MaxGoals is the number of goals a template may hold.
+const MaxGoals = 100
+
+// Template is a named set of goals. Built-in templates ship with the binary
+// and have a Slug; templates saved by users have an ID.
+type Template struct {
+	ID          int64  `json:"-"`
+	Slug        string `json:"-"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Goals       []Item `json:"goals"`
+}
+
+// Item is a goal of a template.
+type Item struct {
+	Goal        string `json:"goal"`
+	Description string `json:"description,omitempty"`
+	// Due is the offset of the due date from the start date, e.g. "+30 days".
+	Due string `json:"due"`
+	// Start is the offset of the start date for goals that span a date range.
+	Start           string   `json:"start,omitempty"`
+	SuccessCriteria []string `json:"success_criteria,omitempty"`
+}
+
+// Key identifies the template in forms: the slug of built-in templates and
+// the ID of saved ones.
+func (t Template) Key() string {
+	if t.Slug != "" {
+		return t.Slug
+	}
+	return strconv.FormatInt(t.ID, 10)
+}
+
+// BuiltIn reports whether the template ships with the binary.
+func (t Template) BuiltIn() bool {
+	return t.Slug != ""
+}
+
+// Validate checks that the template has goals and that all offsets parse.
+func (t Template) Validate() error {
+	if t.Name == "" {
+		return errors.New("template has no name")
+	}
+	if len(t.Goals) == 0 || len(t.Goals) > MaxGoals {
+		return fmt.Errorf("template %q must have between 1 and %d goals", t.Name, MaxGoals)
+	}
+
+	for _, item := range t.Goals {
+		if item.Goal == "" {
+			return fmt.Errorf("template %q has a goal without a title", t.Name)
+		}
+		if _, err := ParseOffset(item.Due); err != nil {
+			return fmt.Errorf("template %q, goal %q: %w", t.Name, item.Goal, err)
+		}
+		if item.Start == "" {
+			continue
+		}
+		if _, err := ParseOffset(item.Start); err != nil {
+			return fmt.Errorf("template %q, goal %q: %w", t.Name, item.Goal, err)
+		}
+	}
+
+	return nil
+}
+
+// Planned is a goal of a template with its dates for a start date.
+type Planned struct {
+	Item
+	DueDate time.Time
+	// StartDate is zero for goals without a start offset.
+	StartDate time.Time
+}
+
+// Plan returns the goals of the template with due dates counted from start,
+// earliest first. Start dates after the due date are dropped.
+func (t Template) Plan(start time.Time) ([]Planned, error) {
+	planned := make([]Planned, 0, len(t.Goals))
+	for _, item := range t.Goals {
+		due, err := ParseOffset(item.Due)
+		if err != nil {
+			return nil, err
+		}
+
+		p := Planned{Item: item, DueDate: due.Apply(start)}
+		if item.Start != "" {
+			offset, err := ParseOffset(item.Start)
+			if err != nil {
+				return nil, err
+			}
+			if date := offset.Apply(start); !date.After(p.DueDate) {
+				p.StartDate = date
+			}
+		}
+		planned = append(planned, p)
+	}
+
+	slices.SortStableFunc(planned, func(a, b Planned) int {
+		return a.DueDate.Compare(b.DueDate)
+	})
+
+	return planned, nil
+}
+
+// Source is an existing goal to save in a template.
+type Source struct {
+	Goal            string
+	Description     string
+	Start           time.Time
+	Due             time.Time
+	SuccessCriteria []string
+}
+
+// FromGoals returns a template of the given goals. Offsets are counted in days
+// from the earliest due date, so that goal is due on the start date when the
+// template is used.
+func FromGoals(name, description string, sources []Source) Template {
+	t := Template{Name: name, Description: description, Goals: []Item{}}
+	if len(sources) == 0 {
+		return t
+	}
+
+	sources = slices.Clone(sources)
+	slices.SortStableFunc(sources, byDue)
+	base := day(sources[0].Due)
+
+	for _, s := range sources {
+		item := Item{
+			Goal:            s.Goal,
+			Description:     s.Description,
+			Due:             daysBetween(base, s.Due).String(),
+			SuccessCriteria: s.SuccessCriteria,
+		}
+		if !s.Start.IsZero() {
+			item.Start = daysBetween(base, s.Start).String()
+		}
+		t.Goals = append(t.Goals, item)
+	}
+
+	return t
+}
+
+func byDue(a, b Source) int {
+	return a.Due.Compare(b.Due)
+}
+
+func daysBetween(from, to time.Time) Offset {
+	return Offset{N: int(day(to).Sub(from) / (24 * time.Hour)), Unit: Days}
+}
+
+// day returns midnight UTC of the day of t.
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// UseForm instantiates a template from a start date.
+type UseForm struct {
+	Template            string `form:"template"`
+	Start               string `form:"start"`
+	validator.Validator `form:"-"`
+}
+
+func (f *UseForm) Validate() {
+	f.Check(validator.NotBlank(f.Template), "template", "Choose a template")
+	f.Check(validator.NotBlank(f.Start), "start", "Start date cannot be blank")
+
+	if f.Start != "" {
+		_, err := time.Parse(goals.HTMLDateFormat, f.Start)
+		f.Check(err == nil, "start", "Start date must be a valid date")
+	}
+}
+
+// SaveForm saves existing goals as a template.
+type SaveForm struct {
+	Name                string `form:"name"`
+	Description         string `form:"description"`
+	IDs                 []int  `form:"id"`
+	validator.Validator `form:"-"`
+}
+
+func (f *SaveForm) Validate() {
+	f.Check(validator.NotBlank(f.Name), "name", "Name cannot be blank")
+	f.Check(validator.MaxChars(f.Name, 100), "name", "Name cannot be more than 100 characters")
+	f.Check(validator.MaxChars(f.Description, 500), "description", "Description cannot be more than 500 characters")
+	f.Check(len(f.IDs) > 0, "id", "Select at least one goal")
+	f.Check(len(f.IDs) <= MaxGoals, "id", "Select no more than 100 goals")
+}
+
+// CheckSpan checks that the offsets of the goals fit in a template: every
+// start and due date must be within MaxOffset days of the earliest due date.
+func (f *SaveForm) CheckSpan(sources []Source) {
+	if len(sources) == 0 {
+		return
+	}
+
+	base := day(slices.MinFunc(sources, byDue).Due)
+	fits := func(t time.Time) bool {
+		n := daysBetween(base, t).N
+		return n >= -MaxOffset && n <= MaxOffset
+	}
+
+	for _, s := range sources {
+		if !fits(s.Due) || (!s.Start.IsZero() && !fits(s.Start)) {
+			f.AddError("id", fmt.Sprintf("Selected goals must start and be due within %d days of each other", MaxOffset))
+			return
+		}
+	}
+}
+
+func (t Template) marshal() (string, error) {
+	goals, err := json.Marshal(t.Goals)
+	return string(goals), err
+}
+
+func (t *GoalTemplate) toTemplate() (Template, error) {
+	template := Template{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+	if err := json.Unmarshal([]byte(t.Goals), &template.Goals); err != nil {
+		return Template{}, err
+	}
+	return template, nil
+}
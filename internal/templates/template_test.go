@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+)
+
+func TestPlan(t *testing.T) {
+	template := Template{
+		Name: "Launch",
+		Goals: []Item{
+			{Goal: "Launch", Due: "+2 months"},
+			{Goal: "Build", Due: "+6 weeks", Start: "+1 week"},
+			{Goal: "Kick-off", Due: "+0 days"},
+			{Goal: "Backwards span", Due: "+1 day", Start: "+1 month"},
+		},
+	}
+	if err := template.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	planned, err := template.Plan(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, p := range planned {
+		entry := p.Goal + " " + p.DueDate.Format(goals.HTMLDateFormat)
+		if !p.StartDate.IsZero() {
+			entry += " from " + p.StartDate.Format(goals.HTMLDateFormat)
+		}
+		got = append(got, entry)
+	}
+
+	want := []string{
+		"Kick-off 2026-11-02",
+		"Backwards span 2026-11-03",
+		"Build 2026-12-14 from 2026-11-09",
+		"Launch 2027-01-02",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Template
+	}{
+		{name: "no name", template: Template{Goals: []Item{{Goal: "A", Due: "+1 day"}}}},
+		{name: "no goals", template: Template{Name: "Empty"}},
+		{name: "no title", template: Template{Name: "T", Goals: []Item{{Due: "+1 day"}}}},
+		{name: "invalid due", template: Template{Name: "T", Goals: []Item{{Goal: "A", Due: "soon"}}}},
+		{name: "invalid start", template: Template{Name: "T", Goals: []Item{{Goal: "A", Due: "+1 day", Start: "later"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.template.Validate(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFromGoals(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(goals.HTMLDateFormat, s)
+		return d
+	}
+
+	template := FromGoals("Quarter", "Every quarter", []Source{
+		{Goal: "Review", Due: date("2026-12-31").Add(9 * time.Hour)},
+		{Goal: "Plan", Due: date("2026-10-01"), SuccessCriteria: []string{"Goals are written down"}},
+		{Goal: "Deliver", Start: date("2026-10-05"), Due: date("2026-12-15")},
+	})
+
+	want := []Item{
+		{Goal: "Plan", Due: "+0 days", SuccessCriteria: []string{"Goals are written down"}},
+		{Goal: "Deliver", Due: "+75 days", Start: "+4 days"},
+		{Goal: "Review", Due: "+91 days"},
+	}
+
+	if len(template.Goals) != len(want) {
+		t.Fatalf("expected %d goals, got %d", len(want), len(template.Goals))
+	}
+	for i := range want {
+		got := template.Goals[i]
+		if got.Goal != want[i].Goal || got.Due != want[i].Due || got.Start != want[i].Start ||
+			!slices.Equal(got.SuccessCriteria, want[i].SuccessCriteria) {
+			t.Errorf("goal %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+
+	// Using the template from the first due date recreates the schedule.
+	planned, err := template.Plan(date("2026-10-01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := planned[2].DueDate.Format(goals.HTMLDateFormat); got != "2026-12-31" {
+		t.Errorf("expected the last goal to be due 2026-12-31, got %s", got)
+	}
+}
+
+func TestSaveFormCheckSpan(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(goals.HTMLDateFormat, s)
+		return d
+	}
+
+	tests := []struct {
+		name    string
+		sources []Source
+		valid   bool
+	}{
+		{"close dates", []Source{{Due: date("2026-10-01")}, {Due: date("2036-09-28")}}, true},
+		{"due too late", []Source{{Due: date("2026-10-01")}, {Due: date("2036-09-29")}}, false},
+		{"start too early", []Source{{Start: date("2016-10-01"), Due: date("2026-10-01")}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := &SaveForm{}
+			form.CheckSpan(tt.sources)
+			if form.Valid() != tt.valid {
+				t.Errorf("expected valid to be %v, got errors %v", tt.valid, form.Errors)
+			}
+		})
+	}
+}
+
+func TestBuiltIns(t *testing.T) {
+	builtIns := BuiltIns()
+	if len(builtIns) == 0 {
+		t.Fatal("expected built-in templates")
+	}
+
+	for _, template := range builtIns {
+		if template.Slug == "" || !template.BuiltIn() {
+			t.Errorf("template %q has no slug", template.Name)
+		}
+		if template.Key() != template.Slug {
+			t.Errorf("template %q: expected key %q, got %q", template.Name, template.Slug, template.Key())
+		}
+	}
+
+	_, err := loadBuiltIns(fstest.MapFS{
+		"builtin/broken.json": {Data: []byte(`{"name": "Broken", "goals": [{"goal": "A", "due": "whenever"}]}`)},
+	})
+	if err == nil {
+		t.Error("expected an invalid template to fail loading")
+	}
+}
@@ -0,0 +1,309 @@
+// Package timeline filters goals and groups them for the timeline views.
+package timeline
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+)
+
+const dateFormat = "2006-01-02"
+
+// PageSize is the number of goals shown before more are loaded.
+const PageSize = 50
+
+// Zoom sets how goals are grouped on the timeline.
+type Zoom string
+
+const (
+	Day     Zoom = "day"
+	Week    Zoom = "week"
+	Month   Zoom = "month"
+	Quarter Zoom = "quarter"
+	Year    Zoom = "year"
+)
+
+// Zooms returns all zoom levels from the finest to the coarsest.
+func Zooms() []Zoom {
+	return []Zoom{Day, Week, Month, Quarter, Year}
+}
+
+// Label returns the zoom level for display.
+func (z Zoom) Label() string {
+	switch z {
+	case Week:
+		return "Week"
+	case Month:
+		return "Month"
+	case Quarter:
+		return "Quarter"
+	case Year:
+		return "Year"
+	default:
+		return "Day"
+	}
+}
+
+func (z Zoom) valid() bool {
+	switch z {
+	case Day, Week, Month, Quarter, Year:
+		return true
+	}
+	return false
+}
+
+// Start returns the first day of the group t falls into.
+func (z Zoom) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch z {
+	case Week:
+		// Weeks start on Monday.
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case Quarter:
+		return time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case Year:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Next returns the first day of the group after the one starting at start.
+func (z Zoom) Next(start time.Time) time.Time {
+	switch z {
+	case Week:
+		return start.AddDate(0, 0, 7)
+	case Month:
+		return start.AddDate(0, 1, 0)
+	case Quarter:
+		return start.AddDate(0, 3, 0)
+	case Year:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Format returns the label of the group starting at start.
+func (z Zoom) Format(start time.Time) string {
+	switch z {
+	case Week:
+		return "Week of " + start.Format("January 2, 2006")
+	case Month:
+		return start.Format("January 2006")
+	case Quarter:
+		return fmt.Sprintf("Q%d %d", (int(start.Month())-1)/3+1, start.Year())
+	case Year:
+		return start.Format("2006")
+	default:
+		return start.Format("January 2, 2006")
+	}
+}
+
+// Visibility values of Filter.
+const (
+	Public  = "public"
+	Private = "private"
+)
+
+// State values of Filter.
+const (
+	Open     = "open"
+	Achieved = "achieved"
+)
+
+// Filter selects the goals shown on the timeline. Zero values match all
+// goals.
+type Filter struct {
+	Year       int
+	From       time.Time
+	To         time.Time
+	Visibility string
+	State      string
+	Zoom       Zoom
+}
+
+// ParseFilter reads a filter from query parameters. Invalid values are
+// ignored.
+func ParseFilter(query url.Values) Filter {
+	f := Filter{Zoom: Day}
+
+	if year, err := strconv.Atoi(query.Get("year")); err == nil && year > 0 && year < 10000 {
+		f.Year = year
+	}
+	if from, err := time.Parse(dateFormat, query.Get("from")); err == nil {
+		f.From = from
+	}
+	if to, err := time.Parse(dateFormat, query.Get("to")); err == nil {
+		f.To = to
+	}
+	if v := query.Get("visibility"); v == Public || v == Private {
+		f.Visibility = v
+	}
+	if s := query.Get("state"); s == Open || s == Achieved {
+		f.State = s
+	}
+	if z := Zoom(query.Get("zoom")); z.valid() {
+		f.Zoom = z
+	}
+
+	return f
+}
+
+// Active reports whether the filter hides any goals.
+func (f Filter) Active() bool {
+	return f.Year != 0 || !f.From.IsZero() || !f.To.IsZero() || f.Visibility != "" || f.State != ""
+}
+
+// Values returns the filter as query parameters, leaving out defaults.
+func (f Filter) Values() url.Values {
+	v := url.Values{}
+	if f.Year != 0 {
+		v.Set("year", strconv.Itoa(f.Year))
+	}
+	if !f.From.IsZero() {
+		v.Set("from", f.From.Format(dateFormat))
+	}
+	if !f.To.IsZero() {
+		v.Set("to", f.To.Format(dateFormat))
+	}
+	if f.Visibility != "" {
+		v.Set("visibility", f.Visibility)
+	}
+	if f.State != "" {
+		v.Set("state", f.State)
+	}
+	if f.Zoom != "" && f.Zoom != Day {
+		v.Set("zoom", string(f.Zoom))
+	}
+	return v
+}
+
+// FromDate returns the start of the date range for a date input.
+func (f Filter) FromDate() string {
+	return formatDate(f.From)
+}
+
+// ToDate returns the end of the date range for a date input.
+func (f Filter) ToDate() string {
+	return formatDate(f.To)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateFormat)
+}
+
+// Match reports whether the goal passes the filter. Goals with a start date
+// match a year or date range they overlap.
+func (f Filter) Match(g goals.View) bool {
+	switch f.Visibility {
+	case Public:
+		if !g.VisibleToPublic {
+			return false
+		}
+	case Private:
+		if g.VisibleToPublic {
+			return false
+		}
+	}
+
+	switch f.State {
+	case Open:
+		if g.Achieved {
+			return false
+		}
+	case Achieved:
+		if !g.Achieved {
+			return false
+		}
+	}
+
+	start, end := g.Due.UTC(), g.Due.UTC()
+	if g.HasSpan() {
+		start = g.Start.UTC()
+	}
+
+	if f.Year != 0 && (start.Year() > f.Year || end.Year() < f.Year) {
+		return false
+	}
+	// To is inclusive, so compare against the day after.
+	if !f.From.IsZero() && end.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !start.Before(f.To.AddDate(0, 0, 1)) {
+		return false
+	}
+
+	return true
+}
+
+// Params returns the page parameters that select the goals passing the
+// filter, like Match does.
+func (f Filter) Params(scope goals.Scope) goals.PageParams {
+	params := goals.PageParams{Scope: scope}
+
+	switch f.Visibility {
+	case Public:
+		params.Visible = sql.NullBool{Bool: true, Valid: true}
+	case Private:
+		params.Visible = sql.NullBool{Bool: false, Valid: true}
+	}
+
+	switch f.State {
+	case Open:
+		params.Achieved = sql.NullBool{Bool: false, Valid: true}
+	case Achieved:
+		params.Achieved = sql.NullBool{Bool: true, Valid: true}
+	}
+
+	params.From = f.From
+	if !f.To.IsZero() {
+		// To is inclusive, so compare against the day after.
+		params.StartBefore = f.To.AddDate(0, 0, 1)
+	}
+	if f.Year != 0 {
+		from := time.Date(f.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if from.After(params.From) {
+			params.From = from
+		}
+		before := from.AddDate(1, 0, 0)
+		if params.StartBefore.IsZero() || before.Before(params.StartBefore) {
+			params.StartBefore = before
+		}
+	}
+
+	return params
+}
+
+// Group is a run of goals that fall into the same day, week, month, quarter
+// or year.
+type Group struct {
+	Date  time.Time
+	Label string
+	Goals []goals.View
+}
+
+// GroupBy groups goals sorted by due date at the given zoom level.
+func GroupBy(views []goals.View, zoom Zoom) []Group {
+	groups := []Group{}
+	for _, v := range views {
+		start := zoom.Start(v.Due)
+		if len(groups) == 0 || !groups[len(groups)-1].Date.Equal(start) {
+			groups = append(groups, Group{Date: start, Label: zoom.Format(start)})
+		}
+		last := &groups[len(groups)-1]
+		last.Goals = append(last.Goals, v)
+	}
+	return groups
+}
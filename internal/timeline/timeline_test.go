@@ -0,0 +1,175 @@
+package timeline
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bit8bytes/goalkeepr/internal/goals"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestZoom(t *testing.T) {
+	due := date(2027, 1, 1) // A Friday
+
+	tests := []struct {
+		zoom  Zoom
+		start time.Time
+		label string
+	}{
+		{zoom: Day, start: date(2027, 1, 1), label: "January 1, 2027"},
+		{zoom: Week, start: date(2026, 12, 28), label: "Week of December 28, 2026"},
+		{zoom: Month, start: date(2027, 1, 1), label: "January 2027"},
+		{zoom: Quarter, start: date(2027, 1, 1), label: "Q1 2027"},
+		{zoom: Year, start: date(2027, 1, 1), label: "2027"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.zoom), func(t *testing.T) {
+			start := tt.zoom.Start(due)
+			if !start.Equal(tt.start) {
+				t.Errorf("expected start %v, got %v", tt.start, start)
+			}
+			if got := tt.zoom.Format(start); got != tt.label {
+				t.Errorf("expected label %q, got %q", tt.label, got)
+			}
+		})
+	}
+
+	if got := Quarter.Start(date(2026, 11, 30)); !got.Equal(date(2026, 10, 1)) {
+		t.Errorf("expected Q4 to start on October 1, got %v", got)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	query := url.Values{
+		"year":       {"2026"},
+		"from":       {"2026-03-01"},
+		"to":         {"not a date"},
+		"visibility": {"everyone"},
+		"state":      {"achieved"},
+		"zoom":       {"quarter"},
+	}
+
+	f := ParseFilter(query)
+	if f.Year != 2026 || !f.From.Equal(date(2026, 3, 1)) || !f.To.IsZero() ||
+		f.Visibility != "" || f.State != Achieved || f.Zoom != Quarter {
+		t.Errorf("unexpected filter %+v", f)
+	}
+
+	want := "from=2026-03-01&state=achieved&year=2026&zoom=quarter"
+	if got := f.Values().Encode(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if f := ParseFilter(url.Values{}); f.Active() || f.Zoom != Day {
+		t.Errorf("expected an inactive filter zoomed to days, got %+v", f)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	span := goals.View{Start: date(2026, 11, 15), Due: date(2027, 2, 1), VisibleToPublic: true}
+	milestone := goals.View{Due: date(2026, 6, 30), Achieved: true}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		goal   goals.View
+		want   bool
+	}{
+		{name: "no filter", goal: milestone, want: true},
+		{name: "public", filter: Filter{Visibility: Public}, goal: milestone, want: false},
+		{name: "private", filter: Filter{Visibility: Private}, goal: milestone, want: true},
+		{name: "open", filter: Filter{State: Open}, goal: milestone, want: false},
+		{name: "achieved", filter: Filter{State: Achieved}, goal: milestone, want: true},
+		{name: "year of due date", filter: Filter{Year: 2026}, goal: milestone, want: true},
+		{name: "other year", filter: Filter{Year: 2027}, goal: milestone, want: false},
+		{name: "span overlaps start year", filter: Filter{Year: 2026}, goal: span, want: true},
+		{name: "span overlaps end year", filter: Filter{Year: 2027}, goal: span, want: true},
+		{name: "inclusive range end", filter: Filter{To: date(2026, 6, 30)}, goal: milestone, want: true},
+		{name: "range before", filter: Filter{To: date(2026, 6, 29)}, goal: milestone, want: false},
+		{name: "range after", filter: Filter{From: date(2026, 7, 1)}, goal: milestone, want: false},
+		{name: "range inside span", filter: Filter{From: date(2026, 12, 1), To: date(2026, 12, 31)}, goal: span, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.goal); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	views := []goals.View{
+		{ID: 1, Due: date(2026, 12, 30)},
+		{ID: 2, Due: date(2026, 12, 31)},
+		{ID: 3, Due: date(2027, 1, 1)},
+		{ID: 4, Due: date(2027, 4, 1)},
+	}
+
+	groups := GroupBy(views, Quarter)
+	if len(groups) != 3 || groups[0].Label != "Q4 2026" || len(groups[0].Goals) != 2 || groups[1].Label != "Q1 2027" {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+
+	if groups := GroupBy(views, Week); len(groups) != 2 {
+		t.Errorf("expected December 30 to January 1 to be one week, got %d groups", len(groups))
+	}
+
+	for _, zoom := range Zooms() {
+		start := zoom.Start(date(2026, 12, 30))
+		if next := zoom.Next(start); !zoom.Start(next).Equal(next) || !zoom.Start(next.AddDate(0, 0, -1)).Equal(start) {
+			t.Errorf("%s: expected the group after %v to start right after it, got %v", zoom, start, next)
+		}
+	}
+}
+
+func TestFilterParams(t *testing.T) {
+	scope := goals.Scope{Shared: true}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   goals.PageParams
+	}{
+		{name: "no filter", want: goals.PageParams{Scope: scope}},
+		{
+			name:   "visibility and state",
+			filter: Filter{Visibility: Private, State: Open},
+			want: goals.PageParams{
+				Scope:    scope,
+				Visible:  sql.NullBool{Valid: true},
+				Achieved: sql.NullBool{Valid: true},
+			},
+		},
+		{
+			name:   "year",
+			filter: Filter{Year: 2026},
+			want:   goals.PageParams{Scope: scope, From: date(2026, 1, 1), StartBefore: date(2027, 1, 1)},
+		},
+		{
+			name:   "range inside year",
+			filter: Filter{Year: 2026, From: date(2026, 3, 1), To: date(2026, 3, 31)},
+			want:   goals.PageParams{Scope: scope, From: date(2026, 3, 1), StartBefore: date(2026, 4, 1)},
+		},
+		{
+			name:   "range beyond year",
+			filter: Filter{Year: 2026, From: date(2025, 3, 1), To: date(2027, 3, 31)},
+			want:   goals.PageParams{Scope: scope, From: date(2026, 1, 1), StartBefore: date(2027, 1, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Params(scope); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+// Package webhooks sends events like created goals and toggled success
+// criteria to URLs users configure, so they can connect Goalkeepr to chats
+// and dashboards. Events are queued by database triggers, and requests are
+// signed, retried with exponential backoff and kept in a delivery log.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bit8bytes/toolbox/validator"
+)
+
+// The events webhooks can receive.
+const (
+	GoalCreated      = "goal.created"
+	GoalUpdated      = "goal.updated"
+	GoalAchieved     = "goal.achieved"
+	GoalDeleted      = "goal.deleted"
+	CriterionToggled = "criterion.toggled"
+	ShareCreated     = "share.created"
+)
+
+// Events lists all events in the order they are offered to users.
+var Events = []string{GoalCreated, GoalUpdated, GoalAchieved, GoalDeleted, CriterionToggled, ShareCreated}
+
+// The statuses of deliveries.
+const (
+	Pending   = "pending"
+	Delivered = "delivered"
+	Failed    = "failed"
+)
+
+const (
+	// MaxWebhooks is how many webhooks each user can add.
+	MaxWebhooks = 5
+	// MaxAttempts is how often a delivery is tried before it fails.
+	MaxAttempts = 8
+	// retryBase is the wait after the first failed attempt. It doubles with
+	// every attempt after that.
+	retryBase = 30 * time.Second
+	// deliveryDays is how long finished deliveries are kept in the log.
+	deliveryDays = 30
+	// claimLimit is how many deliveries are sent at a time.
+	claimLimit = 50
+	// logLimit is how many deliveries the log shows.
+	logLimit = 50
+)
+
+// The headers of webhook requests.
+const (
+	EventHeader     = "X-Goalkeepr-Event"
+	DeliveryHeader  = "X-Goalkeepr-Delivery"
+	SignatureHeader = "X-Goalkeepr-Signature"
+)
+
+var ErrTooManyWebhooks = errors.New("too many webhooks")
+
+type Form struct {
+	URL                 string   `form:"url"`
+	Events              []string `form:"events"`
+	validator.Validator `form:"-"`
+}
+
+func (f *Form) Validate() {
+	f.Check(validator.NotBlank(f.URL), "url", "URL cannot be blank")
+	f.Check(validator.MaxChars(f.URL, 2000), "url", "URL cannot be more than 2000 characters")
+	f.Check(f.URL == "" || validURL(f.URL), "url", "URL must start with http:// or https://")
+	f.Check(len(f.Events) > 0, "events", "Choose at least one event")
+	for _, event := range f.Events {
+		f.Check(slices.Contains(Events, event), "events", "Choose only events from the list")
+	}
+}
+
+// Has reports whether event is chosen in the form.
+func (f *Form) Has(event string) bool {
+	return slices.Contains(f.Events, event)
+}
+
+// validURL reports whether rawURL is an absolute http or https URL.
+func validURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Delivery is a request to a webhook that is due.
+type Delivery struct {
+	ID      int64
+	Event   string
+	Payload string
+	// Attempts counts the attempts so far, including this one.
+	Attempts  int64
+	CreatedAt time.Time
+	URL       string
+	Secret    string
+}
+
+// Envelope is the JSON body of webhook requests.
+type Envelope struct {
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type Service struct {
+	queries *Queries
+	client  *http.Client
+}
+
+// NewService returns a Service that sends requests with client.
+func NewService(db *sql.DB, client *http.Client) *Service {
+	return &Service{
+		queries: New(db),
+		client:  client,
+	}
+}
+
+// Add adds a webhook for the events of form. Its secret is generated.
+func (s *Service) Add(ctx context.Context, userID int, form *Form) (Webhook, error) {
+	count, err := s.queries.CountWebhooksByUser(ctx, int64(userID))
+	if err != nil {
+		return Webhook{}, err
+	}
+	if count >= MaxWebhooks {
+		return Webhook{}, ErrTooManyWebhooks
+	}
+
+	// Keep the order of Events, however the form was sent.
+	var events []string
+	for _, event := range Events {
+		if slices.Contains(form.Events, event) {
+			events = append(events, event)
+		}
+	}
+
+	return s.queries.CreateWebhook(ctx, CreateWebhookParams{
+		UserID: int64(userID),
+		Url:    strings.TrimSpace(form.URL),
+		Secret: rand.Text(),
+		Events: strings.Join(events, ","),
+	})
+}
+
+func (s *Service) GetAll(ctx context.Context, userID int) ([]Webhook, error) {
+	return s.queries.GetAllWebhooksByUser(ctx, int64(userID))
+}
+
+// Delete deletes a webhook with its deliveries.
+func (s *Service) Delete(ctx context.Context, id, userID int) (int, error) {
+	result, err := s.queries.DeleteWebhook(ctx, DeleteWebhookParams{
+		ID:     int64(id),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetRecentDeliveries returns the latest deliveries to the webhooks of a
+// user, newest first.
+func (s *Service) GetRecentDeliveries(ctx context.Context, userID int) ([]GetRecentDeliveriesByUserRow, error) {
+	return s.queries.GetRecentDeliveriesByUser(ctx, GetRecentDeliveriesByUserParams{
+		UserID: int64(userID),
+		Limit:  logLimit,
+	})
+}
+
+// Redeliver queues the event of a delivery again, as a new delivery.
+func (s *Service) Redeliver(ctx context.Context, id, userID int) (int, error) {
+	result, err := s.queries.Redeliver(ctx, RedeliverParams{
+		ID:     int64(id),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+// Prune deletes finished deliveries that are too old for the log.
+func (s *Service) Prune(ctx context.Context, now time.Time) error {
+	return s.queries.DeleteDeliveriesBefore(ctx, now.AddDate(0, 0, -deliveryDays).Unix())
+}
+
+// Claim returns the deliveries that are due and counts the attempt. Each
+// delivery is claimed only once per attempt, and its next attempt is
+// scheduled when it is claimed, so a delivery that is cut off is retried.
+// On error, the deliveries claimed so far are returned as well.
+func (s *Service) Claim(ctx context.Context, now time.Time) ([]Delivery, error) {
+	rows, err := s.queries.GetAllDueDeliveries(ctx, GetAllDueDeliveriesParams{
+		NextAttemptAt: now.Unix(),
+		Limit:         claimLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []Delivery
+	for _, row := range rows {
+		attempts := row.Attempts + 1
+
+		result, err := s.queries.ClaimDelivery(ctx, ClaimDeliveryParams{
+			AttemptedAt:   now.Unix(),
+			NextAttemptAt: now.Add(Backoff(attempts)).Unix(),
+			ID:            row.ID,
+			Attempts:      row.Attempts,
+		})
+		if err != nil {
+			return deliveries, err
+		}
+
+		claimed, err := result.RowsAffected()
+		if err != nil {
+			return deliveries, err
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		deliveries = append(deliveries, Delivery{
+			ID:        row.ID,
+			Event:     row.Event,
+			Payload:   row.Payload,
+			Attempts:  attempts,
+			CreatedAt: time.Unix(row.CreatedAt, 0).UTC(),
+			URL:       row.Url,
+			Secret:    row.Secret,
+		})
+	}
+
+	return deliveries, nil
+}
+
+// Backoff returns how long to wait after the given number of failed
+// attempts.
+func Backoff(attempts int64) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return retryBase << (attempts - 1)
+}
+
+// Send sends a claimed delivery and records the result: delivered, or failed
+// once it was tried MaxAttempts times. Errors of the request are recorded in
+// the delivery, the returned error is only about recording them.
+func (s *Service) Send(ctx context.Context, d Delivery, now time.Time) (bool, error) {
+	status, sendErr := s.send(ctx, d, now)
+
+	params := SetDeliveryResultParams{
+		Status:         Pending,
+		ResponseStatus: sql.NullInt64{Int64: int64(status), Valid: status != 0},
+		ID:             d.ID,
+	}
+	switch {
+	case sendErr == nil:
+		params.Status = Delivered
+		params.DeliveredAt = sql.NullInt64{Int64: now.Unix(), Valid: true}
+	case d.Attempts >= MaxAttempts:
+		params.Status = Failed
+	}
+	if sendErr != nil {
+		params.LastError = sql.NullString{String: sendErr.Error(), Valid: true}
+	}
+
+	// The context may be done already, the result must still be recorded.
+	return sendErr == nil, s.queries.SetDeliveryResult(context.WithoutCancel(ctx), params)
+}
+
+// send posts the event of a delivery and returns the status of the response,
+// or 0 if there was none. Responses other than 2xx are errors.
+func (s *Service) send(ctx context.Context, d Delivery, now time.Time) (int, error) {
+	body, err := json.Marshal(Envelope{
+		ID:        d.ID,
+		Event:     d.Event,
+		CreatedAt: d.CreatedAt,
+		Data:      json.RawMessage(d.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Goalkeepr-Webhooks")
+	req.Header.Set(EventHeader, d.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(SignatureHeader, Signature(d.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Read some of the body, so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Signature returns the signature header of a request body sent at t, like
+// "t=1767225600,v1=5257a8…". v1 is the hex HMAC-SHA256 of "<t>.<body>" with
+// the secret of the webhook, and receivers should compare it in constant time
+// and reject old timestamps.
+func Signature(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + Sign(secret, ts, body)
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
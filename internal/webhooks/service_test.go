@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  Form
+		valid bool
+	}{
+		{name: "https", form: Form{URL: "https://example.com/hook", Events: []string{GoalCreated}}, valid: true},
+		{name: "all events", form: Form{URL: "http://example.com", Events: Events}, valid: true},
+		{name: "blank", form: Form{Events: []string{GoalCreated}}, valid: false},
+		{name: "relative", form: Form{URL: "/hook", Events: []string{GoalCreated}}, valid: false},
+		{name: "other scheme", form: Form{URL: "ftp://example.com", Events: []string{GoalCreated}}, valid: false},
+		{name: "long", form: Form{URL: "https://example.com/" + strings.Repeat("a", 2000), Events: []string{GoalCreated}}, valid: false},
+		{name: "no events", form: Form{URL: "https://example.com"}, valid: false},
+		{name: "unknown event", form: Form{URL: "https://example.com", Events: []string{"user.deleted"}}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			if got := tt.form.Valid(); got != tt.valid {
+				t.Errorf("Valid() = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{MaxAttempts - 1, 32 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	at := time.Unix(1767225600, 0)
+
+	got := Signature("secret", at, body)
+	want := "t=1767225600,v1=" + Sign("secret", "1767225600", body)
+	if got != want {
+		t.Errorf("Signature() = %q, want %q", got, want)
+	}
+
+	if Sign("secret", "1767225600", body) == Sign("other", "1767225600", body) {
+		t.Error("signatures with different secrets are equal")
+	}
+	if Sign("secret", "1767225600", body) == Sign("secret", "1767225601", body) {
+		t.Error("signatures at different times are equal")
+	}
+}
+
+func TestNewClientRefusesPrivateAddresses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewClient(false).Do(req)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Do() error = %v, want %v", err, ErrPrivateAddress)
+	}
+
+	resp, err := NewClient(true).Do(req)
+	if err != nil {
+		t.Fatalf("Do() with private addresses allowed: %v", err)
+	}
+	resp.Body.Close()
+}
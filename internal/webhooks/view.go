@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"strings"
+	"time"
+)
+
+type View struct {
+	ID     int
+	URL    string
+	Secret string
+	Events []string
+}
+
+func (w Webhook) ToView() View {
+	return View{
+		ID:     int(w.ID),
+		URL:    w.Url,
+		Secret: w.Secret,
+		Events: strings.Split(w.Events, ","),
+	}
+}
+
+// DeliveryView is a delivery in the log.
+type DeliveryView struct {
+	ID       int
+	URL      string
+	Event    string
+	Payload  string
+	Status   string
+	Attempts int
+	// NextAttemptAt is when pending deliveries are tried next.
+	NextAttemptAt time.Time
+	// ResponseStatus is 0 if the last attempt got no response.
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+func (d GetRecentDeliveriesByUserRow) ToView() DeliveryView {
+	return DeliveryView{
+		ID:             int(d.ID),
+		URL:            d.Url,
+		Event:          d.Event,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       int(d.Attempts),
+		NextAttemptAt:  time.Unix(d.NextAttemptAt, 0),
+		ResponseStatus: int(d.ResponseStatus.Int64),
+		LastError:      d.LastError.String,
+		CreatedAt:      time.Unix(d.CreatedAt, 0),
+	}
+}
@@ -1,4 +1,5 @@
-// Package ui provides embedded static file serving.
+// Package ui provides embedded static file serving, views and email
+// templates.
 package ui
 
 import (
@@ -10,6 +11,9 @@ import (
 //go:embed "views" "static/dist"
 var files embed.FS
 
+//go:embed "emails"
+var emails embed.FS
+
 func staticFiles() fs.FS {
 	return files
 }
@@ -22,6 +26,15 @@ func Views() fs.FS {
 	return fs
 }
 
+// Emails returns the email templates.
+func Emails() fs.FS {
+	fs, err := fs.Sub(emails, "emails")
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}
+
 // Func ServeStaticFiles serves all embeded static files.
 func ServeStaticFiles() http.Handler {
 	return http.FileServerFS(staticFiles())
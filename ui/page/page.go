@@ -36,11 +36,23 @@ var (
 	AddGoal           = New("goals/add.html", layout.Goals)
 	EditGoal          = New("goals/edit.html", layout.Goals)
 	ShareGoals        = New("goals/share.html", layout.Goals)
+	Trash             = New("goals/trash.html", layout.Goals)
+	Archive           = New("goals/archive.html", layout.Goals)
+	Roadmap           = New("goals/roadmap.html", layout.Goals)
+	Search            = New("goals/search.html", layout.Goals)
+	Reschedule        = New("goals/reschedule.html", layout.Goals)
+	Duplicate         = New("goals/duplicate.html", layout.Goals)
+	Templates         = New("goals/templates.html", layout.Goals)
+	Comments          = New("goals/comments.html", layout.Goals)
+	Overview          = New("goals/overview.html", layout.Goals)
 	Settings          = New("settings/index.html", layout.Settings)
+	Webhooks          = New("settings/webhooks.html", layout.Settings)
 	Share             = New("s/index.html", layout.Share)
 	NotFound          = New("(center)/not-found.html", layout.Center)
 	Error             = New("(center)/error.html", layout.Center)
 	RateLimitExceeded = New("(center)/rate-limit-exceeded.html", layout.Center)
+	Unsubscribe       = New("(center)/unsubscribe.html", layout.Center)
+	Subscription      = New("(center)/subscription.html", layout.Center)
 	Landing           = New("(landing)/landing.html", layout.Landing)
 	Privacy           = New("(landing)/privacy.html", layout.Landing)
 	Imprint           = New("(landing)/imprint.html", layout.Landing)
@@ -50,10 +62,10 @@ var (
 func All() []Page {
 	return []Page{
 		SignUp, SignIn,
-		Goals, AddGoal, EditGoal, ShareGoals,
-		Settings,
+		Goals, AddGoal, EditGoal, ShareGoals, Trash, Archive, Roadmap, Search, Reschedule, Duplicate, Templates, Comments, Overview,
+		Settings, Webhooks,
 		Share,
-		NotFound, Error, RateLimitExceeded,
+		NotFound, Error, RateLimitExceeded, Unsubscribe, Subscription,
 		Landing, Privacy, Imprint,
 	}
 }